@@ -18,13 +18,19 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net/url"
+	"reflect"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/common"
 	"github.com/3scale/3scale-operator/version"
 	"github.com/RHsyseng/operator-utils/pkg/olm"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -36,6 +42,17 @@ const (
 	Default3scaleAppLabel       = "3scale-api-management"
 )
 
+const (
+	DeploymentKindDeploymentConfig = "DeploymentConfig"
+	DeploymentKindDeployment       = "Deployment"
+)
+
+const (
+	ExposureTypeRoute      = "Route"
+	ExposureTypeIngress    = "Ingress"
+	ExposureTypeGatewayAPI = "GatewayAPI"
+)
+
 const (
 	defaultTenantName                  = "3scale"
 	defaultImageStreamImportInsecure   = false
@@ -69,6 +86,8 @@ type APIManagerSpec struct {
 	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	// +optional
 	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// +optional
+	Exposure *ExposureSpec `json:"exposure,omitempty"`
 }
 
 // APIManagerStatus defines the observed state of APIManager
@@ -76,11 +95,53 @@ type APIManagerStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	Conditions []APIManagerCondition `json:"conditions,omitempty" protobuf:"bytes,4,rep,name=conditions"`
+	// Current state of the APIManager.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,4,rep,name=conditions"`
 
 	// APIManager Deployment Configs
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Deployments",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
 	Deployments olm.DeploymentStatus `json:"deployments"`
+
+	// Health of the APIManager components, one per DeploymentConfig or Deployment
+	// +optional
+	Components []APIManagerComponentStatus `json:"components,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+func (s *APIManagerStatus) Equals(other *APIManagerStatus, logger logr.Logger) bool {
+	if s.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(s.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Deployments, other.Deployments) {
+		diff := cmp.Diff(s.Deployments, other.Deployments)
+		logger.V(1).Info("Deployments not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.Components, other.Components) {
+		diff := cmp.Diff(s.Components, other.Components)
+		logger.V(1).Info("Components not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := s.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
 }
 
 // +kubebuilder:object:root=true
@@ -102,34 +163,49 @@ type APIManager struct {
 	Status APIManagerStatus `json:"status,omitempty"`
 }
 
-type APIManagerConditionType string
-
 const (
-	// Ready means the APIManager is available. This is, when all of its
-	// elements are up and running
-	APIManagerReady APIManagerConditionType = "Ready"
-	// Progressing means the APIManager is being deployed
-	APIManagerProgressing APIManagerConditionType = "Progressing"
+	// APIManagerAvailableConditionType means all the APIManager components
+	// are rolled out and their pods are ready
+	APIManagerAvailableConditionType common.ConditionType = "Available"
+	// APIManagerProgressingConditionType means at least one component is
+	// being deployed or rolled out
+	APIManagerProgressingConditionType common.ConditionType = "Progressing"
+	// APIManagerDegradedConditionType means at least one component is
+	// unhealthy or the APIManager could not be reconciled
+	APIManagerDegradedConditionType common.ConditionType = "Degraded"
+	// APIManagerUpgradeInProgressConditionType means the operator is
+	// upgrading the APIManager to a new release
+	APIManagerUpgradeInProgressConditionType common.ConditionType = "UpgradeInProgress"
 )
 
-type APIManagerCondition struct {
-	Type   APIManagerConditionType `json:"type" description:"type of APIManager condition"`
-	Status v1.ConditionStatus      `json:"status" description:"status of the condition, one of True, False, Unknown"` //TODO should be a custom ConditionStatus or the core v1 one?
-
-	// The Reason, Message, LastHeartbeatTime and LastTransitionTime fields are
-	// optional. Unless we really use them they should directly not be used even
-	// if they are optional
+// ComponentHealth is the health of an APIManager component
+type ComponentHealth string
 
-	// +optional
-	//Reason *string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition"`
-	// +optional
-	//Message *string `json:"message,omitempty" description:"human-readable message indicating details about last transition"`
+const (
+	// ComponentHealthy means the component is rolled out and all its pods are ready
+	ComponentHealthy ComponentHealth = "Healthy"
+	// ComponentProgressing means the component is being rolled out
+	ComponentProgressing ComponentHealth = "Progressing"
+	// ComponentUnhealthy means the component rollout failed or some pods are not ready
+	ComponentUnhealthy ComponentHealth = "Unhealthy"
+)
 
-	// +optional
-	//LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty" description:"last time we got an update on a given condition"` // TODO the Kubernetes API convention guide says *unversioned.Time should be used but that seems to be a client-side package. I've seen that objects like PersistentVolumeClaim use metav1.Time
-	// +optional
-	//metav1.Time        `json:"lastProbeTime,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
-	//LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" description:"last time the condition transit from one status to another"`
+// APIManagerComponentStatus is the health of an APIManager component
+type APIManagerComponentStatus struct {
+	// Name of the DeploymentConfig or Deployment
+	Name string `json:"name"`
+	// Health of the component
+	Health ComponentHealth `json:"health"`
+	// One-word CamelCase reason of the health
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human-readable details of the health
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Desired number of pods
+	Replicas int32 `json:"replicas"`
+	// Number of ready pods
+	ReadyReplicas int32 `json:"readyReplicas"`
 }
 
 type APIManagerCommonSpec struct {
@@ -146,6 +222,12 @@ type APIManagerCommonSpec struct {
 	ResourceRequirementsEnabled *bool `json:"resourceRequirementsEnabled,omitempty"`
 	// +optional
 	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Kind of the workload resources deploying the 3scale components.
+	// DeploymentConfig requires OpenShift. Deployment renders apps/v1
+	// Deployments with plain image references. Defaults to DeploymentConfig
+	// +kubebuilder:validation:Enum=DeploymentConfig;Deployment
+	// +optional
+	DeploymentKind *string `json:"deploymentKind,omitempty"`
 }
 
 type ApicastSpec struct {
@@ -174,6 +256,10 @@ type ApicastProductionSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	ApicastCustomizationSpec `json:",inline"`
 }
 
 type ApicastStagingSpec struct {
@@ -185,6 +271,78 @@ type ApicastStagingSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	ApicastCustomizationSpec `json:",inline"`
+}
+
+// ApicastCustomizationSpec defines the configuration of an APIcast environment
+// beyond the settings managed by the operator
+type ApicastCustomizationSpec struct {
+	// LogLevel is the log level of the APIcast gateway
+	// +kubebuilder:validation:Enum=debug;info;notice;warn;error;crit;alert;emerg
+	// +optional
+	LogLevel *string `json:"logLevel,omitempty"`
+	// ServicesFilterByURL is a regular expression. Only the services whose
+	// public base URL matches it are loaded
+	// +optional
+	ServicesFilterByURL *string `json:"servicesFilterByURL,omitempty"`
+	// AllProxy is the proxy used for connections when no protocol specific proxy is set
+	// +optional
+	AllProxy *string `json:"allProxy,omitempty"`
+	// HTTPProxy is the proxy used for HTTP connections
+	// +optional
+	HTTPProxy *string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the proxy used for HTTPS connections
+	// +optional
+	HTTPSProxy *string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma separated list of hosts not proxied
+	// +optional
+	NoProxy *string `json:"noProxy,omitempty"`
+	// CACertificateSecretRef references a secret holding, in the ca-bundle.crt key,
+	// the CA certificates APIcast trusts
+	// +optional
+	CACertificateSecretRef *v1.LocalObjectReference `json:"caCertificateSecretRef,omitempty"`
+	// CustomPolicies are policies loaded from ConfigMaps or Secrets
+	// +optional
+	CustomPolicies []CustomPolicySpec `json:"customPolicies,omitempty"`
+	// Volumes are ConfigMaps or Secrets mounted in the APIcast container
+	// +optional
+	Volumes []CustomVolumeSpec `json:"volumes,omitempty"`
+	// Env are additional environment variables of the APIcast container.
+	// Environment variables managed by the operator cannot be set
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+}
+
+// CustomPolicySpec defines an APIcast custom policy. The ConfigMap or Secret
+// holds the policy files: init.lua, apicast-policy.json and the policy modules
+type CustomPolicySpec struct {
+	// Name is the policy name
+	Name string `json:"name"`
+	// Version is the policy version
+	Version string `json:"version"`
+	// ConfigMapRef references the ConfigMap with the policy files
+	// +optional
+	ConfigMapRef *v1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// SecretRef references the Secret with the policy files
+	// +optional
+	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// CustomVolumeSpec defines a ConfigMap or Secret mounted in a container
+type CustomVolumeSpec struct {
+	// Name is the volume name
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// MountPath is the path the volume is mounted at
+	MountPath string `json:"mountPath"`
+	// ConfigMapRef references the mounted ConfigMap
+	// +optional
+	ConfigMapRef *v1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// SecretRef references the mounted Secret
+	// +optional
+	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
 }
 
 type BackendSpec struct {
@@ -222,6 +380,8 @@ type BackendListenerSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 }
 
 type BackendWorkerSpec struct {
@@ -233,6 +393,8 @@ type BackendWorkerSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// +optional
 	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 }
 
 type BackendCronSpec struct {
@@ -308,6 +470,8 @@ type SystemAppSpec struct {
 	ProviderContainerResources *v1.ResourceRequirements `json:"providerContainerResources,omitempty"`
 	// +optional
 	DeveloperContainerResources *v1.ResourceRequirements `json:"developerContainerResources,omitempty"`
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 }
 
 type SystemSidekiqSpec struct {
@@ -477,6 +641,66 @@ type HighAvailabilitySpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 	// +optional
 	ExternalZyncDatabaseEnabled *bool `json:"externalZyncDatabaseEnabled,omitempty"`
+	// ExternalRedis defines the connection to the external Redis databases.
+	// When set, the backend-redis and system-redis secrets are managed by the operator
+	// +optional
+	ExternalRedis *ExternalRedisSpec `json:"externalRedis,omitempty"`
+}
+
+// ExternalRedisSpec defines the external Redis databases of backend and system
+type ExternalRedisSpec struct {
+	// BackendStorage is the backend storage database
+	BackendStorage RedisEndpointSpec `json:"backendStorage"`
+	// BackendQueues is the backend queues database
+	BackendQueues RedisEndpointSpec `json:"backendQueues"`
+	// System is the system database
+	System RedisEndpointSpec `json:"system"`
+	// SystemMessageBus is the system message bus database. Defaults to the system database
+	// +optional
+	SystemMessageBus *RedisEndpointSpec `json:"systemMessageBus,omitempty"`
+}
+
+// RedisEndpointSpec defines the connection to a Redis database
+type RedisEndpointSpec struct {
+	// URL of the database. The scheme is rediss when TLS is set, redis otherwise.
+	// With Sentinel, the host is the name of the monitored master
+	// +kubebuilder:validation:Pattern=`^rediss?://`
+	URL string `json:"url"`
+	// Sentinel defines the Sentinels monitoring the database
+	// +optional
+	Sentinel *RedisSentinelSpec `json:"sentinel,omitempty"`
+	// TLS enables TLS connections to the database
+	// +optional
+	TLS *RedisTLSSpec `json:"tls,omitempty"`
+	// Username is the Redis ACL user. Requires passwordSecretRef
+	// +optional
+	Username *string `json:"username,omitempty"`
+	// PasswordSecretRef references the secret key holding the password
+	// +optional
+	PasswordSecretRef *v1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// RedisSentinelSpec defines the Sentinels monitoring a Redis database
+type RedisSentinelSpec struct {
+	// Hosts are the Sentinel URLs, like redis://sentinel-0:26379
+	// +kubebuilder:validation:MinItems=1
+	Hosts []string `json:"hosts"`
+	// Role of the database instance the clients connect to
+	// +kubebuilder:validation:Enum=master;slave
+	// +optional
+	Role *string `json:"role,omitempty"`
+}
+
+// RedisTLSSpec defines the TLS material used to connect to a Redis database
+type RedisTLSSpec struct {
+	// CASecretRef references a secret holding, in the ca.crt key, the CA
+	// certificate of the database. When not set, the system CAs are trusted
+	// +optional
+	CASecretRef *v1.LocalObjectReference `json:"caSecretRef,omitempty"`
+	// ClientCertificateSecretRef references a secret holding, in the tls.crt
+	// and tls.key keys, the client certificate and key
+	// +optional
+	ClientCertificateSecretRef *v1.LocalObjectReference `json:"clientCertificateSecretRef,omitempty"`
 }
 
 type PodDisruptionBudgetSpec struct {
@@ -485,6 +709,106 @@ type PodDisruptionBudgetSpec struct {
 
 type MonitoringSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
+	// ExtraLabels are added to the PrometheusRules and GrafanaDashboards,
+	// i.e. to match the rule and dashboard selectors of the monitoring stack
+	// +optional
+	ExtraLabels map[string]string `json:"extraLabels,omitempty"`
+	// Alerts overrides the 3scale alerts by alert name
+	// +optional
+	Alerts map[string]AlertSpec `json:"alerts,omitempty"`
+}
+
+// AlertSpec overrides one of the alerts of the 3scale PrometheusRules
+type AlertSpec struct {
+	// Disabled removes the alert from the PrometheusRule
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// Severity replaces the severity label of the alert
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Severity *string `json:"severity,omitempty"`
+	// For replaces the time the alert condition must hold before the alert fires, i.e. 5m
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h|d|w|y))+$`
+	// +optional
+	For *string `json:"for,omitempty"`
+	// Threshold replaces the number the alert expression is compared with.
+	// Only alerts whose expression ends comparing with a number can be overridden
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Threshold *string `json:"threshold,omitempty"`
+}
+
+// AutoscalingSpec defines a HorizontalPodAutoscaler for the component.
+// Replicas of autoscaled components are managed by the HorizontalPodAutoscaler
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit of replicas. Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit of replicas
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilizationPercentage is the target average CPU utilization
+	// over all the pods, relative to the requested CPU.
+	// Defaults to 80 when no target is set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// TargetMemoryUtilizationPercentage is the target average memory utilization
+	// over all the pods, relative to the requested memory
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// ExposureSpec defines how the 3scale endpoints are exposed outside the cluster
+type ExposureSpec struct {
+	// Type of the objects exposing the 3scale endpoints.
+	// Route relies on zync creating OpenShift Routes.
+	// Ingress renders networking.k8s.io/v1 Ingresses.
+	// GatewayAPI renders gateway.networking.k8s.io/v1 HTTPRoutes.
+	// Defaults to Route
+	// +kubebuilder:validation:Enum=Route;Ingress;GatewayAPI
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// +optional
+	Ingress *IngressExposureSpec `json:"ingress,omitempty"`
+	// +optional
+	Gateway *GatewayExposureSpec `json:"gateway,omitempty"`
+}
+
+// IngressExposureSpec defines the Ingresses exposing the 3scale endpoints
+type IngressExposureSpec struct {
+	// IngressClassName of the Ingresses
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+	// Annotations added to the Ingresses
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// TLSSecretRef references the secret with the TLS certificate of the hosts.
+	// The certificate is expected to be valid for the wildcard domain.
+	// When not set, TLS is left to the ingress controller defaults
+	// +optional
+	TLSSecretRef *v1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+}
+
+// GatewayExposureSpec defines the HTTPRoutes exposing the 3scale endpoints
+type GatewayExposureSpec struct {
+	// ParentRefs references the Gateways the HTTPRoutes are attached to
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+}
+
+// GatewayParentReference references a Gateway listener
+type GatewayParentReference struct {
+	// Name of the Gateway
+	Name string `json:"name"`
+	// Namespace of the Gateway. Defaults to the APIManager namespace
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// SectionName is the name of the Gateway listener
+	// +optional
+	SectionName *string `json:"sectionName,omitempty"`
 }
 
 // PersistentVolumeClaimResources defines the resources configuration
@@ -800,6 +1124,13 @@ func (apimanager *APIManager) IsExternalDatabaseEnabled() bool {
 	return apimanager.Spec.HighAvailability != nil && apimanager.Spec.HighAvailability.Enabled
 }
 
+func (apimanager *APIManager) ExternalRedis() *ExternalRedisSpec {
+	if apimanager.Spec.HighAvailability == nil {
+		return nil
+	}
+	return apimanager.Spec.HighAvailability.ExternalRedis
+}
+
 func (apimanager *APIManager) IsZyncExternalDatabaseEnabled() bool {
 	return apimanager.IsExternalDatabaseEnabled() &&
 		apimanager.Spec.HighAvailability.ExternalZyncDatabaseEnabled != nil &&
@@ -825,6 +1156,221 @@ func (apimanager *APIManager) IsMonitoringEnabled() bool {
 	return apimanager.Spec.Monitoring != nil && apimanager.Spec.Monitoring.Enabled
 }
 
+// UsesDeployments returns true when the 3scale components are
+// deployed using apps/v1 Deployments instead of DeploymentConfigs
+func (apimanager *APIManager) UsesDeployments() bool {
+	return apimanager.Spec.DeploymentKind != nil && *apimanager.Spec.DeploymentKind == DeploymentKindDeployment
+}
+
+// ExposureType returns the type of the objects exposing the 3scale endpoints
+func (apimanager *APIManager) ExposureType() string {
+	if apimanager.Spec.Exposure == nil || apimanager.Spec.Exposure.Type == nil {
+		return ExposureTypeRoute
+	}
+	return *apimanager.Spec.Exposure.Type
+}
+
+// UsesRoutes returns true when the 3scale endpoints are exposed
+// using OpenShift Routes
+func (apimanager *APIManager) UsesRoutes() bool {
+	return apimanager.ExposureType() == ExposureTypeRoute
+}
+
+// Validate checks the consistency of the APIManager spec options
+// that cannot be expressed with the CRD OpenAPI validation
+func (apimanager *APIManager) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+
+	if apimanager.Spec.System != nil {
+		systemFldPath := specFldPath.Child("system")
+		fileStorageSpec := apimanager.Spec.System.FileStorageSpec
+		if fileStorageSpec != nil {
+			fileStorageOptions := 0
+			for _, isSet := range []bool{fileStorageSpec.PVC != nil, fileStorageSpec.S3 != nil, fileStorageSpec.DeprecatedS3 != nil} {
+				if isSet {
+					fileStorageOptions++
+				}
+			}
+			if fileStorageOptions > 1 {
+				errors = append(errors, field.Invalid(systemFldPath.Child("fileStorage"), nil, "Only one FileStorage can be chosen at the same time"))
+			}
+		}
+
+		databaseSpec := apimanager.Spec.System.DatabaseSpec
+		if !apimanager.IsExternalDatabaseEnabled() && databaseSpec != nil && databaseSpec.MySQL != nil && databaseSpec.PostgreSQL != nil {
+			errors = append(errors, field.Invalid(systemFldPath.Child("database"), nil, "Only one System Database can be chosen at the same time"))
+		}
+	}
+
+	if err := apimanager.ValidateExternalRedis(); err != nil {
+		errors = append(errors, field.Invalid(specFldPath.Child("highAvailability", "externalRedis"), nil, err.Error()))
+	}
+
+	if apimanager.ExposureType() == ExposureTypeGatewayAPI &&
+		(apimanager.Spec.Exposure.Gateway == nil || len(apimanager.Spec.Exposure.Gateway.ParentRefs) == 0) {
+		errors = append(errors, field.Required(specFldPath.Child("exposure", "gateway", "parentRefs"), "GatewayAPI exposure type requires at least one gateway parentRef"))
+	}
+
+	if apimanager.Spec.Apicast != nil {
+		apicastFldPath := specFldPath.Child("apicast")
+		if apimanager.Spec.Apicast.ProductionSpec != nil {
+			errors = append(errors, apimanager.Spec.Apicast.ProductionSpec.ApicastCustomizationSpec.validate(apicastFldPath.Child("productionSpec"))...)
+		}
+		if apimanager.Spec.Apicast.StagingSpec != nil {
+			errors = append(errors, apimanager.Spec.Apicast.StagingSpec.ApicastCustomizationSpec.validate(apicastFldPath.Child("stagingSpec"))...)
+		}
+	}
+
+	return errors
+}
+
+func (a *ApicastCustomizationSpec) validate(fldPath *field.Path) field.ErrorList {
+	errors := field.ErrorList{}
+
+	for idx, policy := range a.CustomPolicies {
+		if (policy.ConfigMapRef == nil) == (policy.SecretRef == nil) {
+			errors = append(errors, field.Invalid(fldPath.Child("customPolicies").Index(idx), policy.Name, "exactly one of configMapRef or secretRef must be set"))
+		}
+	}
+
+	for idx, volume := range a.Volumes {
+		if (volume.ConfigMapRef == nil) == (volume.SecretRef == nil) {
+			errors = append(errors, field.Invalid(fldPath.Child("volumes").Index(idx), volume.Name, "exactly one of configMapRef or secretRef must be set"))
+		}
+	}
+
+	if err := a.ValidateEnv(); err != nil {
+		errors = append(errors, field.Invalid(fldPath.Child("env"), nil, err.Error()))
+	}
+
+	return errors
+}
+
+// ApicastReservedEnvVarNames are the environment variables of the APIcast
+// containers set by the operator regardless of the customization options
+var ApicastReservedEnvVarNames = []string{
+	"THREESCALE_PORTAL_ENDPOINT",
+	"BACKEND_ENDPOINT_OVERRIDE",
+	"APICAST_MANAGEMENT_API",
+	"OPENSSL_VERIFY",
+	"APICAST_RESPONSE_CODES",
+	"APICAST_EXTENDED_METRICS",
+	"APICAST_CONFIGURATION_LOADER",
+	"APICAST_CONFIGURATION_CACHE",
+	"THREESCALE_DEPLOYMENT_ENV",
+}
+
+// ValidateEnv checks the custom environment variables do not override the
+// ones set by the operator, either reserved or set from the typed fields
+func (a *ApicastCustomizationSpec) ValidateEnv() error {
+	managedEnvVarNames := a.managedEnvVarNames()
+	for _, envVar := range a.Env {
+		for _, reservedName := range ApicastReservedEnvVarNames {
+			if envVar.Name == reservedName {
+				return fmt.Errorf("env %s: name is reserved by the operator", envVar.Name)
+			}
+		}
+		for _, managedName := range managedEnvVarNames {
+			if envVar.Name == managedName {
+				return fmt.Errorf("env %s: set by the operator from the APIcast customization fields", envVar.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// managedEnvVarNames returns the names of the environment variables the
+// operator sets from the typed customization fields
+func (a *ApicastCustomizationSpec) managedEnvVarNames() []string {
+	result := []string{}
+
+	optionalValues := []struct {
+		name  string
+		isSet bool
+	}{
+		{"APICAST_LOG_LEVEL", a.LogLevel != nil},
+		{"APICAST_SERVICES_FILTER_BY_URL", a.ServicesFilterByURL != nil},
+		{"ALL_PROXY", a.AllProxy != nil},
+		{"HTTP_PROXY", a.HTTPProxy != nil},
+		{"HTTPS_PROXY", a.HTTPSProxy != nil},
+		{"NO_PROXY", a.NoProxy != nil},
+		{"SSL_CERT_FILE", a.CACertificateSecretRef != nil},
+	}
+	for _, optionalValue := range optionalValues {
+		if optionalValue.isSet {
+			result = append(result, optionalValue.name)
+		}
+	}
+
+	return result
+}
+
+// ValidateExternalRedis checks the externalRedis settings are consistent,
+// so invalid settings are rejected before any workload is rolled out
+func (apimanager *APIManager) ValidateExternalRedis() error {
+	spec := apimanager.ExternalRedis()
+	if spec == nil {
+		return nil
+	}
+
+	if !apimanager.IsExternalDatabaseEnabled() {
+		return fmt.Errorf("externalRedis requires highAvailability.enabled")
+	}
+
+	endpoints := []struct {
+		name string
+		spec *RedisEndpointSpec
+	}{
+		{"backendStorage", &spec.BackendStorage},
+		{"backendQueues", &spec.BackendQueues},
+		{"system", &spec.System},
+		{"systemMessageBus", spec.SystemMessageBus},
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint.spec == nil {
+			continue
+		}
+		if err := endpoint.spec.validate(); err != nil {
+			return fmt.Errorf("externalRedis.%s: %w", endpoint.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (spec *RedisEndpointSpec) validate() error {
+	redisURL, err := url.Parse(spec.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	switch redisURL.Scheme {
+	case "redis":
+		if spec.TLS != nil {
+			return fmt.Errorf("tls requires a rediss:// url")
+		}
+	case "rediss":
+		if spec.TLS == nil {
+			return fmt.Errorf("rediss:// url requires tls")
+		}
+	default:
+		return fmt.Errorf("url scheme must be redis or rediss, found '%s'", redisURL.Scheme)
+	}
+
+	if redisURL.User != nil && (spec.Username != nil || spec.PasswordSecretRef != nil) {
+		return fmt.Errorf("credentials must be set either in the url or in username and passwordSecretRef")
+	}
+
+	if spec.Username != nil && spec.PasswordSecretRef == nil {
+		return fmt.Errorf("username requires passwordSecretRef")
+	}
+
+	return nil
+}
+
 // +kubebuilder:object:root=true
 
 // APIManagerList contains a list of APIManager
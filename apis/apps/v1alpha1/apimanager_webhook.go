@@ -0,0 +1,130 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var apimanagerlog = logf.Log.WithName("apimanager-resource")
+
+// SetupWebhookWithManager registers the APIManager admission webhooks in the manager
+func (apimanager *APIManager) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(apimanager).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-apps-3scale-net-v1alpha1-apimanager,mutating=true,failurePolicy=fail,groups=apps.3scale.net,resources=apimanagers,verbs=create;update,versions=v1alpha1,name=mapimanager.kb.io
+
+var _ webhook.Defaulter = &APIManager{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (apimanager *APIManager) Default() {
+	apimanagerlog.V(1).Info("default", "name", apimanager.Name)
+
+	// Conflicting options are rejected by the validating webhook
+	_, err := apimanager.SetDefaults()
+	if err != nil {
+		apimanagerlog.V(1).Info("defaults not fully applied", "name", apimanager.Name, "error", err.Error())
+	}
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-apps-3scale-net-v1alpha1-apimanager,mutating=false,failurePolicy=fail,groups=apps.3scale.net,resources=apimanagers,versions=v1alpha1,name=vapimanager.kb.io
+
+var _ webhook.Validator = &APIManager{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (apimanager *APIManager) ValidateCreate() error {
+	apimanagerlog.V(1).Info("validate create", "name", apimanager.Name)
+
+	return apimanager.invalidError(apimanager.Validate())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (apimanager *APIManager) ValidateUpdate(old runtime.Object) error {
+	apimanagerlog.V(1).Info("validate update", "name", apimanager.Name)
+
+	oldAPIManager := old.(*APIManager)
+	// Metadata only updates, i.e. finalizer removals, and updates of resources being deleted are not validated
+	if apimanager.DeletionTimestamp != nil || reflect.DeepEqual(oldAPIManager.Spec, apimanager.Spec) {
+		return nil
+	}
+
+	errors := apimanager.Validate()
+	errors = append(errors, apimanager.validateImmutableFields(oldAPIManager)...)
+	return apimanager.invalidError(errors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (apimanager *APIManager) ValidateDelete() error {
+	return nil
+}
+
+// validateImmutableFields rejects changes the operator cannot migrate
+func (apimanager *APIManager) validateImmutableFields(old *APIManager) field.ErrorList {
+	errors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+
+	if old.UsesDeployments() && !apimanager.UsesDeployments() {
+		errors = append(errors, field.Forbidden(specFldPath.Child("deploymentKind"), "migrating back from Deployment to DeploymentConfig is not supported"))
+	}
+
+	oldDatabase, newDatabase := old.systemDatabaseType(), apimanager.systemDatabaseType()
+	if oldDatabase != "" && newDatabase != "" && oldDatabase != newDatabase {
+		errors = append(errors, field.Forbidden(specFldPath.Child("system", "database"), "changing the system database type is not supported"))
+	}
+
+	if old.usesS3FileStorage() != apimanager.usesS3FileStorage() {
+		errors = append(errors, field.Forbidden(specFldPath.Child("system", "fileStorage"), "changing the system file storage type is not supported"))
+	}
+
+	return errors
+}
+
+// systemDatabaseType returns the internally managed system database type,
+// empty when the database is external. MySQL is the default
+func (apimanager *APIManager) systemDatabaseType() string {
+	if apimanager.IsExternalDatabaseEnabled() {
+		return ""
+	}
+	if apimanager.Spec.System != nil && apimanager.Spec.System.DatabaseSpec != nil && apimanager.Spec.System.DatabaseSpec.PostgreSQL != nil {
+		return "postgresql"
+	}
+	return "mysql"
+}
+
+func (apimanager *APIManager) usesS3FileStorage() bool {
+	if apimanager.Spec.System == nil || apimanager.Spec.System.FileStorageSpec == nil {
+		return false
+	}
+	return apimanager.Spec.System.FileStorageSpec.S3 != nil || apimanager.Spec.System.FileStorageSpec.DeprecatedS3 != nil
+}
+
+func (apimanager *APIManager) invalidError(errors field.ErrorList) error {
+	if len(errors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("APIManager").GroupKind(), apimanager.Name, errors)
+}
@@ -0,0 +1,161 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAPIManagerValidateCreate(t *testing.T) {
+	exposureType := ExposureTypeGatewayAPI
+
+	cases := []struct {
+		testName string
+		mutateFn func(*APIManager)
+		errorMsg string
+	}{
+		{"Minimum", func(*APIManager) {}, ""},
+		{"SeveralFileStorages", func(apimanager *APIManager) {
+			apimanager.Spec.System = &SystemSpec{FileStorageSpec: &SystemFileStorageSpec{
+				PVC: &SystemPVCSpec{},
+				S3:  &SystemS3Spec{},
+			}}
+		}, "Only one FileStorage"},
+		{"SeveralDatabases", func(apimanager *APIManager) {
+			apimanager.Spec.System = &SystemSpec{DatabaseSpec: &SystemDatabaseSpec{
+				MySQL:      &SystemMySQLSpec{},
+				PostgreSQL: &SystemPostgreSQLSpec{},
+			}}
+		}, "Only one System Database"},
+		{"ExternalRedisWithoutHA", func(apimanager *APIManager) {
+			apimanager.Spec.HighAvailability = &HighAvailabilitySpec{ExternalRedis: &ExternalRedisSpec{}}
+		}, "externalRedis requires highAvailability.enabled"},
+		{"GatewayWithoutParentRefs", func(apimanager *APIManager) {
+			apimanager.Spec.Exposure = &ExposureSpec{Type: &exposureType}
+		}, "at least one gateway parentRef"},
+		{"CustomVolumeWithoutSource", func(apimanager *APIManager) {
+			apimanager.Spec.Apicast = &ApicastSpec{ProductionSpec: &ApicastProductionSpec{
+				ApicastCustomizationSpec: ApicastCustomizationSpec{
+					Volumes: []CustomVolumeSpec{{Name: "myvolume", MountPath: "/myvolume"}},
+				},
+			}}
+		}, "spec.apicast.productionSpec.volumes[0]"},
+		{"ExternalRedisInvalidURL", func(apimanager *APIManager) {
+			apimanager.Spec.HighAvailability = &HighAvailabilitySpec{
+				Enabled: true,
+				ExternalRedis: &ExternalRedisSpec{
+					BackendStorage: RedisEndpointSpec{URL: "http://backend-redis:6379/0"},
+				},
+			}
+		}, "externalRedis.backendStorage: url scheme must be redis or rediss"},
+		{"ReservedApicastEnv", func(apimanager *APIManager) {
+			apimanager.Spec.Apicast = &ApicastSpec{StagingSpec: &ApicastStagingSpec{
+				ApicastCustomizationSpec: ApicastCustomizationSpec{
+					Env: []v1.EnvVar{{Name: "THREESCALE_DEPLOYMENT_ENV", Value: "production"}},
+				},
+			}}
+		}, "spec.apicast.stagingSpec.env"},
+		{"ApicastEnvSetByCustomizationField", func(apimanager *APIManager) {
+			apimanager.Spec.Apicast = &ApicastSpec{ProductionSpec: &ApicastProductionSpec{
+				ApicastCustomizationSpec: ApicastCustomizationSpec{
+					HTTPProxy: &[]string{"http://proxy:8080"}[0],
+					Env:       []v1.EnvVar{{Name: "HTTP_PROXY", Value: "http://other:8080"}},
+				},
+			}}
+		}, "env HTTP_PROXY: set by the operator"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			tc.mutateFn(apimanager)
+			apimanager.Default()
+
+			err := apimanager.ValidateCreate()
+			if tc.errorMsg == "" {
+				if err != nil {
+					subT.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), tc.errorMsg) {
+				subT.Errorf("expected invalid error '%s', got %v", tc.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestAPIManagerValidateUpdate(t *testing.T) {
+	deploymentKind := DeploymentKindDeployment
+	deploymentConfigKind := DeploymentKindDeploymentConfig
+	var replicas int64 = 2
+
+	cases := []struct {
+		testName    string
+		oldMutateFn func(*APIManager)
+		newMutateFn func(*APIManager)
+		errorMsg    string
+	}{
+		{"ReplicasUpdated", func(*APIManager) {}, func(apimanager *APIManager) {
+			apimanager.Spec.Apicast.ProductionSpec.Replicas = &replicas
+		}, ""},
+		{"MigrateToDeployment", func(*APIManager) {}, func(apimanager *APIManager) {
+			apimanager.Spec.DeploymentKind = &deploymentKind
+		}, ""},
+		{"MigrateBackToDeploymentConfig", func(apimanager *APIManager) {
+			apimanager.Spec.DeploymentKind = &deploymentKind
+		}, func(apimanager *APIManager) {
+			apimanager.Spec.DeploymentKind = &deploymentConfigKind
+		}, "spec.deploymentKind"},
+		{"DatabaseChanged", func(*APIManager) {}, func(apimanager *APIManager) {
+			apimanager.Spec.System.DatabaseSpec = &SystemDatabaseSpec{PostgreSQL: &SystemPostgreSQLSpec{}}
+		}, "spec.system.database"},
+		{"FileStorageChanged", func(*APIManager) {}, func(apimanager *APIManager) {
+			apimanager.Spec.System.FileStorageSpec = &SystemFileStorageSpec{
+				S3: &SystemS3Spec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "s3"}},
+			}
+		}, "spec.system.fileStorage"},
+		{"InvalidSpecMetadataUpdated", func(apimanager *APIManager) {
+			apimanager.Spec.HighAvailability = &HighAvailabilitySpec{ExternalRedis: &ExternalRedisSpec{}}
+		}, func(apimanager *APIManager) {
+			apimanager.Labels = map[string]string{"app": "3scale"}
+		}, ""},
+		{"InvalidSpecUpdated", func(apimanager *APIManager) {
+			apimanager.Spec.HighAvailability = &HighAvailabilitySpec{ExternalRedis: &ExternalRedisSpec{}}
+		}, func(apimanager *APIManager) {
+			apimanager.Spec.Apicast.ProductionSpec.Replicas = &replicas
+		}, "externalRedis requires highAvailability.enabled"},
+		{"InvalidSpecDeleting", func(apimanager *APIManager) {
+			apimanager.Spec.HighAvailability = &HighAvailabilitySpec{ExternalRedis: &ExternalRedisSpec{}}
+		}, func(apimanager *APIManager) {
+			now := metav1.Now()
+			apimanager.DeletionTimestamp = &now
+			apimanager.Spec.Apicast.ProductionSpec.Replicas = &replicas
+		}, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			oldAPIManager := minimumAPIManagerTest()
+			tc.oldMutateFn(oldAPIManager)
+			oldAPIManager.Default()
+
+			apimanager := oldAPIManager.DeepCopy()
+			tc.newMutateFn(apimanager)
+
+			err := apimanager.ValidateUpdate(oldAPIManager)
+			if tc.errorMsg == "" {
+				if err != nil {
+					subT.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), tc.errorMsg) {
+				subT.Errorf("expected invalid error '%s', got %v", tc.errorMsg, err)
+			}
+		})
+	}
+}
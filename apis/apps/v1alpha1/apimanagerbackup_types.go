@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -39,6 +40,31 @@ type APIManagerBackupDestination struct {
 	// PersistentVolumeClaim as backup data destination configuration
 	// +optional
 	PersistentVolumeClaim *PersistentVolumeClaimBackupDestination `json:"persistentVolumeClaim,omitempty"`
+	// S3 API-compatible object storage as backup data destination configuration
+	// +optional
+	S3 *S3ObjectStorage `json:"s3,omitempty"`
+}
+
+// S3ObjectStorage defines the location of the backup data
+// in a S3 API-compatible object storage
+type S3ObjectStorage struct {
+	// Endpoint URL of the S3 API-compatible object storage.
+	// Defaults to AWS S3 endpoint
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+	// Region of the bucket. Defaults to us-east-1
+	// +optional
+	Region *string `json:"region,omitempty"`
+	// Name of the bucket
+	// +kubebuilder:validation:Pattern=`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`
+	Bucket string `json:"bucket"`
+	// Path prefix inside the bucket
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9/!_.*()-]*$`
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+	// Secret containing the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+	// credentials of the object storage
+	CredentialsSecretRef v1.LocalObjectReference `json:"credentialsSecretRef"`
 }
 
 // PersistentVolumeClaimBackupDestination defines the configuration
@@ -81,6 +107,14 @@ type APIManagerBackupStatus struct {
 	// +optional
 	APIManagerSourceName *string `json:"apiManagerSourceName,omitempty"`
 
+	// Set to true when a backup step failed. Failed backups are not retried
+	// +optional
+	Failed *bool `json:"failed,omitempty"`
+
+	// Reason of the backup failure
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
 	// Backup start time. It is represented in RFC3339 form and is in UTC.
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
@@ -92,6 +126,12 @@ type APIManagerBackupStatus struct {
 	// PersistentVolumeClaim is used as the backup data destination
 	// +optional
 	BackupPersistentVolumeClaimName *string `json:"backupPersistentVolumeClaimName,omitempty"`
+
+	// Location of the backup data in the S3 API-compatible object storage,
+	// in s3://<bucket>/<path> form. Only set when S3 is used as the
+	// backup data destination
+	// +optional
+	BackupS3Location *string `json:"backupS3Location,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -116,6 +156,10 @@ func (a *APIManagerBackup) BackupCompleted() bool {
 	return a.Status.Completed != nil && *a.Status.Completed
 }
 
+func (a *APIManagerBackup) BackupFailed() bool {
+	return a.Status.Failed != nil && *a.Status.Failed
+}
+
 func (a *APIManagerBackup) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
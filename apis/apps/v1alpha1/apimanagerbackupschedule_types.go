@@ -0,0 +1,121 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// APIManagerBackupScheduleLabelKey labels the APIManagerBackups
+	// created by an APIManagerBackupSchedule with the schedule name
+	APIManagerBackupScheduleLabelKey = "apps.3scale.net/backup-schedule"
+)
+
+// APIManagerBackupScheduleSpec defines the desired state of APIManagerBackupSchedule
+type APIManagerBackupScheduleSpec struct {
+	// Schedule in Cron format, i.e. "0 2 * * *". Evaluated in UTC
+	Schedule string `json:"schedule"`
+
+	// Suspend stops creating new backups. Existing backups are still pruned
+	// according to the retention policy
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// Retention policy of the backups created by the schedule.
+	// When not set, backups are never pruned
+	// +optional
+	Retention *APIManagerBackupRetention `json:"retention,omitempty"`
+
+	// BackupTemplate is the spec of the created APIManagerBackups
+	BackupTemplate APIManagerBackupSpec `json:"backupTemplate"`
+}
+
+// APIManagerBackupRetention defines which finished backups are kept.
+// A backup is pruned when any of the limits is exceeded
+type APIManagerBackupRetention struct {
+	// KeepLast is the number of finished backups to keep
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// MaxAge of the finished backups, in Go duration format, i.e. "168h"
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// APIManagerBackupScheduleStatus defines the observed state of APIManagerBackupSchedule
+type APIManagerBackupScheduleStatus struct {
+	// Last time a backup was scheduled. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Name of the backup in progress
+	// +optional
+	ActiveBackup *string `json:"activeBackup,omitempty"`
+
+	// Name of the last completed backup
+	// +optional
+	LastSuccessfulBackup *string `json:"lastSuccessfulBackup,omitempty"`
+
+	// Completion time of the last completed backup. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// Name of the last failed backup
+	// +optional
+	LastFailedBackup *string `json:"lastFailedBackup,omitempty"`
+
+	// Reason of the last backup failure
+	// +optional
+	LastFailureMessage *string `json:"lastFailureMessage,omitempty"`
+
+	// Message describing why backups cannot be scheduled, i.e. invalid schedule
+	// +optional
+	ScheduleError *string `json:"scheduleError,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// APIManagerBackupSchedule creates APIManagerBackups on a schedule
+// +kubebuilder:resource:path=apimanagerbackupschedules,scope=Namespaced
+// +operator-sdk:csv:customresourcedefinitions:displayName="APIManagerBackupSchedule"
+type APIManagerBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIManagerBackupScheduleSpec   `json:"spec,omitempty"`
+	Status APIManagerBackupScheduleStatus `json:"status,omitempty"`
+}
+
+func (a *APIManagerBackupSchedule) IsSuspended() bool {
+	return a.Spec.Suspend != nil && *a.Spec.Suspend
+}
+
+// +kubebuilder:object:root=true
+
+// APIManagerBackupScheduleList contains a list of APIManagerBackupSchedule
+type APIManagerBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIManagerBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIManagerBackupSchedule{}, &APIManagerBackupScheduleList{})
+}
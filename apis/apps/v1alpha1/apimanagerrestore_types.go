@@ -39,6 +39,10 @@ type APIManagerRestoreSource struct {
 	// +optional
 	// Restore data soure configuration
 	PersistentVolumeClaim *PersistentVolumeClaimRestoreSource `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	// S3 API-compatible object storage restore data source configuration.
+	// The prefix is the path of the backup data in the bucket
+	S3 *S3ObjectStorage `json:"s3,omitempty"`
 }
 
 // PersistentVolumeClaimRestoreSource defines the configuration
@@ -69,6 +73,14 @@ type APIManagerRestoreStatus struct {
 	// +optional
 	MainStepsCompleted *bool `json:"mainStepsCompleted,omitempty"`
 
+	// Set to true when a restore step failed. Failed restores are not retried
+	// +optional
+	Failed *bool `json:"failed,omitempty"`
+
+	// Reason of the restore failure
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
 	// Restore start time. It is represented in RFC3339 form and is in UTC.
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
@@ -99,6 +111,10 @@ func (a *APIManagerRestore) RestoreCompleted() bool {
 	return a.Status.Completed != nil && *a.Status.Completed
 }
 
+func (a *APIManagerRestore) RestoreFailed() bool {
+	return a.Status.Failed != nil && *a.Status.Failed
+}
+
 func (a *APIManagerRestore) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+// ConvertTo converts this Tenant to the Hub version (v1beta1)
+func (src *Tenant) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Tenant)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.Username = src.Spec.Username
+	dst.Spec.Email = src.Spec.Email
+	dst.Spec.OrganizationName = src.Spec.OrganizationName
+	dst.Spec.SystemMasterUrl = src.Spec.SystemMasterUrl
+	dst.Spec.TenantSecretRef = src.Spec.TenantSecretRef
+	dst.Spec.PasswordCredentialsRef = src.Spec.PasswordCredentialsRef
+	dst.Spec.MasterCredentialsRef = src.Spec.MasterCredentialsRef
+	dst.Spec.State = v1beta1.TenantState(src.Spec.State)
+	dst.Spec.DeletionPolicy = v1beta1.DeletionPolicy(src.Spec.DeletionPolicy)
+
+	dst.Status.TenantId = src.Status.TenantId
+	dst.Status.AdminId = src.Status.AdminId
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+// v1beta1 status fields other than the tenant and admin IDs are not available in v1alpha1
+func (dst *Tenant) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Tenant)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec.Username = src.Spec.Username
+	dst.Spec.Email = src.Spec.Email
+	dst.Spec.OrganizationName = src.Spec.OrganizationName
+	dst.Spec.SystemMasterUrl = src.Spec.SystemMasterUrl
+	dst.Spec.TenantSecretRef = src.Spec.TenantSecretRef
+	dst.Spec.PasswordCredentialsRef = src.Spec.PasswordCredentialsRef
+	dst.Spec.MasterCredentialsRef = src.Spec.MasterCredentialsRef
+	dst.Spec.State = string(src.Spec.State)
+	dst.Spec.DeletionPolicy = string(src.Spec.DeletionPolicy)
+
+	dst.Status.TenantId = src.Status.TenantId
+	dst.Status.AdminId = src.Status.AdminId
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+func testTenantSpec() TenantSpec {
+	return TenantSpec{
+		Username:               "admin",
+		Email:                  "admin@example.com",
+		OrganizationName:       "org",
+		SystemMasterUrl:        "https://master.example.com",
+		TenantSecretRef:        v1.SecretReference{Name: "tenant-secret", Namespace: "ns"},
+		PasswordCredentialsRef: v1.SecretReference{Name: "password-secret"},
+		MasterCredentialsRef:   v1.SecretReference{Name: "master-secret"},
+		State:                  "suspended",
+		DeletionPolicy:         "Delete",
+	}
+}
+
+func TestTenantConvertToHub(t *testing.T) {
+	src := &Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "ns"},
+		Spec:       testTenantSpec(),
+		Status:     TenantStatus{TenantId: 2, AdminId: 3},
+	}
+
+	dst := &v1beta1.Tenant{}
+	if err := src.ConvertTo(dst); err != nil {
+		t.Fatal(err)
+	}
+
+	if dst.Name != "tenant" || dst.Namespace != "ns" {
+		t.Errorf("unexpected metadata: %v", dst.ObjectMeta)
+	}
+	if dst.Spec.OrganizationName != "org" || dst.Spec.MasterCredentialsRef.Name != "master-secret" {
+		t.Errorf("unexpected spec: %v", dst.Spec)
+	}
+	if dst.Spec.State != v1beta1.TenantStateSuspended || dst.Spec.DeletionPolicy != v1beta1.DeletionPolicyDelete {
+		t.Errorf("unexpected state or deletion policy: %v", dst.Spec)
+	}
+	if dst.Status.TenantId != 2 || dst.Status.AdminId != 3 {
+		t.Errorf("unexpected status: %v", dst.Status)
+	}
+}
+
+func TestTenantRoundTripFromHub(t *testing.T) {
+	hub := &v1beta1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tenant",
+			Namespace:   "ns",
+			Annotations: map[string]string{"a": "b"},
+		},
+		Spec: v1beta1.TenantSpec{
+			Username:               "admin",
+			Email:                  "admin@example.com",
+			OrganizationName:       "org",
+			SystemMasterUrl:        "https://master.example.com",
+			TenantSecretRef:        v1.SecretReference{Name: "tenant-secret", Namespace: "ns"},
+			PasswordCredentialsRef: v1.SecretReference{Name: "password-secret"},
+			MasterCredentialsRef:   v1.SecretReference{Name: "master-secret"},
+			State:                  v1beta1.TenantStateSuspended,
+			DeletionPolicy:         v1beta1.DeletionPolicyDelete,
+		},
+		Status: v1beta1.TenantStatus{TenantId: 2, AdminId: 3},
+	}
+
+	spoke := &Tenant{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(spoke.Spec, testTenantSpec()) {
+		t.Errorf("unexpected spec: %s", cmp.Diff(spoke.Spec, testTenantSpec()))
+	}
+
+	result := &v1beta1.Tenant{}
+	if err := spoke.ConvertTo(result); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(hub.ObjectMeta, result.ObjectMeta) {
+		t.Errorf("unexpected metadata: %s", cmp.Diff(hub.ObjectMeta, result.ObjectMeta))
+	}
+	if !reflect.DeepEqual(hub.Spec, result.Spec) {
+		t.Errorf("unexpected spec: %s", cmp.Diff(hub.Spec, result.Spec))
+	}
+	if !reflect.DeepEqual(hub.Status, result.Status) {
+		t.Errorf("unexpected status: %s", cmp.Diff(hub.Status, result.Status))
+	}
+}
+
+func TestTenantIsConvertible(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := conversion.IsConvertible(s, &v1beta1.Tenant{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("expected Tenant to be convertible")
+	}
+}
@@ -39,6 +39,19 @@ type TenantSpec struct {
 	TenantSecretRef        v1.SecretReference `json:"tenantSecretRef"`
 	PasswordCredentialsRef v1.SecretReference `json:"passwordCredentialsRef"`
 	MasterCredentialsRef   v1.SecretReference `json:"masterCredentialsRef"`
+
+	// State defines the desired state of the 3scale tenant account.
+	// Defaults to active
+	// +kubebuilder:validation:Enum=active;suspended
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// DeletionPolicy defines what happens to the 3scale tenant when the custom resource is deleted.
+	// Delete: the 3scale tenant is scheduled for deletion. Orphan: the 3scale tenant is left untouched.
+	// Defaults to Orphan
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 // TenantStatus defines the observed state of Tenant
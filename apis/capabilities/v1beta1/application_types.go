@@ -0,0 +1,233 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ApplicationKind = "Application"
+
+	// ApplicationInvalidConditionType represents that the combination of configuration
+	// in the ApplicationSpec is not supported. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	// Example: the referenced product and developer account belong to different provider accounts
+	ApplicationInvalidConditionType common.ConditionType = "Invalid"
+
+	// ApplicationOrphanConditionType represents that the configuration in the ApplicationSpec
+	// contains reference to non existing resource.
+	// This is (should be) a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	// Example: the referenced product has not been synchronized yet
+	ApplicationOrphanConditionType common.ConditionType = "Orphan"
+
+	// ApplicationSyncedConditionType indicates the application has been successfully synchronized.
+	// Steady state
+	ApplicationSyncedConditionType common.ConditionType = "Synced"
+
+	// ApplicationFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	ApplicationFailedConditionType common.ConditionType = "Failed"
+
+	// ApplicationFinalizer is the finalizer that guards the removal of the 3scale application
+	// when the custom resource is deleted
+	ApplicationFinalizer = "application.capabilities.3scale.net/finalizer"
+
+	// ApplicationUserKeySecretField is the credentials secret field name for the user key
+	// of applications of products with userkey authentication mode
+	ApplicationUserKeySecretField = "user_key"
+
+	// ApplicationAppIDSecretField is the credentials secret field name for the application ID
+	// of applications of products with appKeyAppID or oidc authentication modes
+	ApplicationAppIDSecretField = "app_id"
+
+	// ApplicationAppKeySecretField is the credentials secret field name for the application key
+	// of applications of products with appKeyAppID or oidc authentication modes
+	ApplicationAppKeySecretField = "app_key"
+)
+
+// ApplicationSpec defines the desired state of Application
+type ApplicationSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// AccountCR references the DeveloperAccount custom resource owning the application
+	AccountCR corev1.LocalObjectReference `json:"accountCR"`
+
+	// ProductCR references the Product custom resource of the application
+	ProductCR corev1.LocalObjectReference `json:"productCR"`
+
+	// ApplicationPlanName is the system name of the product application plan
+	ApplicationPlanName string `json:"applicationPlanName"`
+
+	// Name is human readable name for the application
+	Name string `json:"name"`
+
+	// Description is a human readable text of the application.
+	// Defaults to the application name
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// CredentialsSecretRef references the secret where the application credentials are written.
+	// Defaults to <custom resource name>-credentials
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+
+	// DeletionPolicy defines what happens to the 3scale application when the custom resource is deleted.
+	// Delete: the 3scale application is removed. Orphan: the 3scale application is left untouched.
+	// Defaults to Orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// ApplicationStatus defines the observed state of Application
+type ApplicationStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// +optional
+	ID *int64 `json:"applicationId,omitempty"`
+
+	// AccountID is the 3scale ID of the developer account owning the application
+	// +optional
+	AccountID *int64 `json:"accountId,omitempty"`
+
+	// State of the 3scale application
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// 3scale control plane host
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Application Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the 3scale application.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (a *ApplicationStatus) Equals(other *ApplicationStatus, logger logr.Logger) bool {
+	if !reflect.DeepEqual(a.ID, other.ID) {
+		diff := cmp.Diff(a.ID, other.ID)
+		logger.V(1).Info("ID not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(a.AccountID, other.AccountID) {
+		diff := cmp.Diff(a.AccountID, other.AccountID)
+		logger.V(1).Info("AccountID not equal", "difference", diff)
+		return false
+	}
+
+	if a.State != other.State {
+		diff := cmp.Diff(a.State, other.State)
+		logger.V(1).Info("State not equal", "difference", diff)
+		return false
+	}
+
+	if a.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(a.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if a.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(a.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := a.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Application is the Schema for the applications API
+// +kubebuilder:resource:path=applications,scope=Namespaced
+// +operator-sdk:csv:customresourcedefinitions:displayName="3scale Application"
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationSpec   `json:"spec,omitempty"`
+	Status ApplicationStatus `json:"status,omitempty"`
+}
+
+// IsRemoteDeletionEnabled returns true when the 3scale application has to be removed
+// on custom resource deletion. Only an explicit Delete policy removes it
+func (a *Application) IsRemoteDeletionEnabled() bool {
+	return a.Spec.DeletionPolicy == DeletionPolicyDelete
+}
+
+// CredentialsSecretName returns the name of the secret where the application credentials are written
+func (a *Application) CredentialsSecretName() string {
+	if a.Spec.CredentialsSecretRef != nil && a.Spec.CredentialsSecretRef.Name != "" {
+		return a.Spec.CredentialsSecretRef.Name
+	}
+
+	return fmt.Sprintf("%s-credentials", a.Name)
+}
+
+// Description returns the 3scale application description
+func (a *Application) Description() string {
+	if a.Spec.Description != "" {
+		return a.Spec.Description
+	}
+
+	return a.Spec.Name
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationList contains a list of Application
+type ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Application `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Application{}, &ApplicationList{})
+}
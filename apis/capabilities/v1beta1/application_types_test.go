@@ -0,0 +1,26 @@
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestApplicationIsRemoteDeletionEnabled(t *testing.T) {
+	cases := []struct {
+		testName       string
+		deletionPolicy DeletionPolicy
+		expected       bool
+	}{
+		{"default", "", false},
+		{"delete", DeletionPolicyDelete, true},
+		{"orphan", DeletionPolicyOrphan, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			application := &Application{Spec: ApplicationSpec{DeletionPolicy: tc.deletionPolicy}}
+			if application.IsRemoteDeletionEnabled() != tc.expected {
+				subT.Errorf("expected %t, got %t", tc.expected, application.IsRemoteDeletionEnabled())
+			}
+		})
+	}
+}
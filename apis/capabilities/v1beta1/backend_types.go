@@ -48,6 +48,25 @@ const (
 	// BackendFailedConditionType indicates that an error occurred during synchronization.
 	// The operator will retry.
 	BackendFailedConditionType common.ConditionType = "Failed"
+
+	// BackendDriftedConditionType indicates that 3scale differs from the BackendSpec.
+	// Only reported when the custom resource is reconciled in observe mode.
+	// The message lists the changes that would be applied in sync mode.
+	BackendDriftedConditionType common.ConditionType = "Drifted"
+
+	// BackendThrottledConditionType indicates that the admin API of the provider account
+	// is rate limiting the requests or the requests are rejected after too many consecutive failures.
+	// The operator will retry.
+	BackendThrottledConditionType common.ConditionType = "Throttled"
+
+	// BackendDeletionBlockedConditionType indicates that the 3scale backend cannot be removed
+	// on custom resource deletion. Example: the backend is still used by some product.
+	// The operator will retry.
+	BackendDeletionBlockedConditionType common.ConditionType = "DeletionBlocked"
+
+	// BackendFinalizer is the finalizer that guards the removal of the 3scale backend
+	// when the custom resource is deleted
+	BackendFinalizer = "backend.capabilities.3scale.net/finalizer"
 )
 
 var (
@@ -102,6 +121,17 @@ type BackendSpec struct {
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+
+	// ProviderAccountCRRef references a ProviderAccount custom resource, possibly in another namespace.
+	// Mutually exclusive with providerAccountRef
+	// +optional
+	ProviderAccountCRRef *ProviderAccountCRReference `json:"providerAccountCRRef,omitempty"`
+
+	// DeletionPolicy defines what happens to the 3scale backend when the custom resource is deleted.
+	// Delete: the 3scale backend is removed. Orphan: the 3scale backend is left untouched.
+	// Defaults to Orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // BackendStatus defines the observed state of Backend
@@ -264,6 +294,10 @@ func (backend *Backend) Validate() field.ErrorList {
 			errors = append(errors, field.Invalid(mappingRulesIdxFldPath, spec.MetricMethodRef, "mappingrule does not have valid metric or method reference."))
 		}
 	}
+
+	errors = append(errors, validateProviderAccountReferences(specFldPath, backend.Spec.ProviderAccountRef, backend.Spec.ProviderAccountCRRef)...)
+	errors = append(errors, validateResyncPeriodAnnotation(backend)...)
+
 	return errors
 }
 
@@ -271,6 +305,14 @@ func (backend *Backend) IsSynced() bool {
 	return backend.Status.Conditions.IsTrueFor(BackendSyncedConditionType)
 }
 
+// IsRemoteDeletionEnabled returns true when the 3scale backend has to be removed
+// on custom resource deletion. 3scale is never changed in observe mode.
+// Custom resources created before the deletion policy was introduced never removed the 3scale backend,
+// so only an explicit Delete policy removes it
+func (backend *Backend) IsRemoteDeletionEnabled() bool {
+	return backend.Spec.DeletionPolicy == DeletionPolicyDelete && !IsObserveMode(backend)
+}
+
 func (backend *Backend) FindMetricOrMethod(ref string) bool {
 	if len(backend.Spec.Metrics) > 0 {
 		if _, ok := backend.Spec.Metrics[ref]; ok {
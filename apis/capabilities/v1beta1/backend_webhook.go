@@ -0,0 +1,84 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var backendlog = logf.Log.WithName("backend-resource")
+
+// SetupWebhookWithManager registers the Backend admission webhooks in the manager
+func (backend *Backend) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(backend).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-capabilities-3scale-net-v1beta1-backend,mutating=true,failurePolicy=fail,groups=capabilities.3scale.net,resources=backends,verbs=create;update,versions=v1beta1,name=mbackend.kb.io
+
+var _ webhook.Defaulter = &Backend{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (backend *Backend) Default() {
+	backendlog.V(1).Info("default", "name", backend.Name)
+
+	backend.SetDefaults(backendlog)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-capabilities-3scale-net-v1beta1-backend,mutating=false,failurePolicy=fail,groups=capabilities.3scale.net,resources=backends,versions=v1beta1,name=vbackend.kb.io
+
+var _ webhook.Validator = &Backend{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (backend *Backend) ValidateCreate() error {
+	backendlog.V(1).Info("validate create", "name", backend.Name)
+
+	return invalidError(BackendKind, backend.Name, backend.Validate())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (backend *Backend) ValidateUpdate(old runtime.Object) error {
+	backendlog.V(1).Info("validate update", "name", backend.Name)
+
+	oldBackend := old.(*Backend)
+	// Metadata only updates, i.e. finalizer removals, and updates of resources being deleted are not validated
+	if backend.DeletionTimestamp != nil || reflect.DeepEqual(oldBackend.Spec, backend.Spec) {
+		return nil
+	}
+
+	errors := backend.Validate()
+
+	// The 3scale backend is looked up by system name
+	if oldBackend.Spec.SystemName != "" {
+		errors = append(errors, apivalidation.ValidateImmutableField(backend.Spec.SystemName, oldBackend.Spec.SystemName, field.NewPath("spec").Child("systemName"))...)
+	}
+
+	return invalidError(BackendKind, backend.Name, errors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (backend *Backend) ValidateDelete() error {
+	return nil
+}
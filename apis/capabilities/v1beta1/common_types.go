@@ -0,0 +1,106 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DeletionPolicy defines what happens to the 3scale entity when the custom resource is deleted
+// +kubebuilder:validation:Enum=Delete;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete removes the 3scale entity when the custom resource is deleted
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyOrphan keeps the 3scale entity when the custom resource is deleted
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+const (
+	// ReconciliationModeAnnotation selects how the custom resource is reconciled against 3scale.
+	// When the annotation is missing, ReconciliationModeSync is used.
+	ReconciliationModeAnnotation = "capabilities.3scale.net/reconciliation-mode"
+
+	// ReconciliationModeSync makes 3scale match the custom resource
+	ReconciliationModeSync = "sync"
+
+	// ReconciliationModeObserve only computes the differences between the custom resource and 3scale.
+	// 3scale is not changed.
+	ReconciliationModeObserve = "observe"
+)
+
+// IsObserveMode returns true when the object is annotated with the observe reconciliation mode
+func IsObserveMode(obj metav1.Object) bool {
+	return obj.GetAnnotations()[ReconciliationModeAnnotation] == ReconciliationModeObserve
+}
+
+const (
+	// ResyncPeriodAnnotation overrides the period of the resync against 3scale of the custom resource.
+	// The value is a duration, i.e. "30m". Zero disables the resync.
+	// When the annotation is missing, the period set in the operator is used.
+	ResyncPeriodAnnotation = "capabilities.3scale.net/resync-period"
+)
+
+// ResyncPeriod returns the period of the resync against 3scale of the object.
+// defaultPeriod is returned when the object is not annotated or the annotation is not valid
+func ResyncPeriod(obj metav1.Object, defaultPeriod time.Duration) time.Duration {
+	value, ok := obj.GetAnnotations()[ResyncPeriodAnnotation]
+	if !ok {
+		return defaultPeriod
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil || period < 0 {
+		return defaultPeriod
+	}
+
+	return period
+}
+
+// validateResyncPeriodAnnotation checks the resync period annotation is a non negative duration
+func validateResyncPeriodAnnotation(obj metav1.Object) field.ErrorList {
+	value, ok := obj.GetAnnotations()[ResyncPeriodAnnotation]
+	if !ok {
+		return nil
+	}
+
+	fldPath := field.NewPath("metadata").Child("annotations").Key(ResyncPeriodAnnotation)
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	if period < 0 {
+		return field.ErrorList{field.Invalid(fldPath, value, "must not be negative")}
+	}
+
+	return nil
+}
+
+// invalidError returns the API error rejecting the resource
+// with the given field errors. Nil when there are no errors
+func invalidError(kind, name string, errors field.ErrorList) error {
+	if len(errors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind(kind).GroupKind(), name, errors)
+}
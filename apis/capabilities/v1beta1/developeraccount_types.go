@@ -0,0 +1,182 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DeveloperAccountKind = "DeveloperAccount"
+
+	// DeveloperAccountSyncedConditionType indicates the developer account has been successfully synchronized.
+	// Steady state
+	DeveloperAccountSyncedConditionType common.ConditionType = "Synced"
+
+	// DeveloperAccountFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	DeveloperAccountFailedConditionType common.ConditionType = "Failed"
+
+	// DeveloperAccountFinalizer is the finalizer that guards the removal of the 3scale developer account
+	// when the custom resource is deleted
+	DeveloperAccountFinalizer = "developeraccount.capabilities.3scale.net/finalizer"
+
+	// DeveloperAccountPasswordSecretField is the field name of the secret
+	// where the developer account admin user password can be found
+	DeveloperAccountPasswordSecretField = "password"
+)
+
+// DeveloperAccountSpec defines the desired state of DeveloperAccount
+type DeveloperAccountSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// OrgName is the organization name of the developer account
+	OrgName string `json:"orgName"`
+
+	// Username of the developer account admin user.
+	// Only used when the developer account is created
+	Username string `json:"username"`
+
+	// Email of the developer account admin user.
+	// Only used when the developer account is created
+	Email string `json:"email"`
+
+	// PasswordCredentialsRef references the secret with the developer account admin user password.
+	// Only used when the developer account is created
+	// +optional
+	PasswordCredentialsRef *corev1.LocalObjectReference `json:"passwordCredentialsRef,omitempty"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+
+	// DeletionPolicy defines what happens to the 3scale developer account when the custom resource is deleted.
+	// Delete: the 3scale developer account and its applications are removed. Orphan: the 3scale developer account is left untouched.
+	// Defaults to Orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DeveloperAccountStatus defines the observed state of DeveloperAccount
+type DeveloperAccountStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// +optional
+	ID *int64 `json:"accountId,omitempty"`
+
+	// State of the 3scale developer account
+	// +optional
+	AccountState string `json:"accountState,omitempty"`
+
+	// 3scale control plane host
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed DeveloperAccount Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the 3scale developer account.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (d *DeveloperAccountStatus) Equals(other *DeveloperAccountStatus, logger logr.Logger) bool {
+	if !reflect.DeepEqual(d.ID, other.ID) {
+		diff := cmp.Diff(d.ID, other.ID)
+		logger.V(1).Info("ID not equal", "difference", diff)
+		return false
+	}
+
+	if d.AccountState != other.AccountState {
+		diff := cmp.Diff(d.AccountState, other.AccountState)
+		logger.V(1).Info("AccountState not equal", "difference", diff)
+		return false
+	}
+
+	if d.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(d.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if d.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(d.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := d.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// DeveloperAccount is the Schema for the developeraccounts API
+// +kubebuilder:resource:path=developeraccounts,scope=Namespaced
+// +operator-sdk:csv:customresourcedefinitions:displayName="3scale Developer Account"
+type DeveloperAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeveloperAccountSpec   `json:"spec,omitempty"`
+	Status DeveloperAccountStatus `json:"status,omitempty"`
+}
+
+// IsRemoteDeletionEnabled returns true when the 3scale developer account has to be removed
+// on custom resource deletion. Only an explicit Delete policy removes it
+func (d *DeveloperAccount) IsRemoteDeletionEnabled() bool {
+	return d.Spec.DeletionPolicy == DeletionPolicyDelete
+}
+
+// IsSynced returns true when the developer account has been synchronized with 3scale
+func (d *DeveloperAccount) IsSynced() bool {
+	return d.Status.Conditions.IsTrueFor(DeveloperAccountSyncedConditionType)
+}
+
+// +kubebuilder:object:root=true
+
+// DeveloperAccountList contains a list of DeveloperAccount
+type DeveloperAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeveloperAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeveloperAccount{}, &DeveloperAccountList{})
+}
@@ -72,6 +72,11 @@ type OpenAPISpec struct {
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
 
+	// ProviderAccountCRRef references a ProviderAccount custom resource, possibly in another namespace.
+	// Mutually exclusive with providerAccountRef
+	// +optional
+	ProviderAccountCRRef *ProviderAccountCRReference `json:"providerAccountCRRef,omitempty"`
+
 	// ProductionPublicBaseURL Custom public production URL
 	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
 	// +optional
@@ -101,6 +106,17 @@ type OpenAPISpec struct {
 	// PrivateAPISecretToken Custom secret token sent by the API gateway to the private API
 	// +optional
 	PrivateAPISecretToken *string `json:"privateAPISecretToken,omitempty"`
+
+	// OIDCIssuerEndpointRef references the secret holding the location of the OpenID Provider.
+	// Required when the OpenAPI security scheme is openIdConnect or oauth2.
+	// The secret must have the `issuerEndpoint` field.
+	// +optional
+	OIDCIssuerEndpointRef *corev1.LocalObjectReference `json:"oidcIssuerEndpointRef,omitempty"`
+
+	// OIDCIssuerType is the type of the OpenID Provider. Defaults to keycloak.
+	// +kubebuilder:validation:Enum=keycloak;rest
+	// +optional
+	OIDCIssuerType *string `json:"oidcIssuerType,omitempty"`
 }
 
 // OpenAPIStatus defines the observed state of OpenAPI
@@ -197,6 +213,7 @@ func (o *OpenAPI) SetDefaults(logger logr.Logger) bool {
 
 func (o *OpenAPI) Validate() field.ErrorList {
 	errors := field.ErrorList{}
+	errors = append(errors, validateProviderAccountReferences(field.NewPath("spec"), o.Spec.ProviderAccountRef, o.Spec.ProviderAccountCRRef)...)
 	return errors
 }
 
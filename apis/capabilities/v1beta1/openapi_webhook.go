@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var openapilog = logf.Log.WithName("openapi-resource")
+
+// SetupWebhookWithManager registers the OpenAPI admission webhooks in the manager
+func (o *OpenAPI) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(o).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-capabilities-3scale-net-v1beta1-openapi,mutating=true,failurePolicy=fail,groups=capabilities.3scale.net,resources=openapis,verbs=create;update,versions=v1beta1,name=mopenapi.kb.io
+
+var _ webhook.Defaulter = &OpenAPI{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (o *OpenAPI) Default() {
+	openapilog.V(1).Info("default", "name", o.Name)
+
+	o.SetDefaults(openapilog)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-capabilities-3scale-net-v1beta1-openapi,mutating=false,failurePolicy=fail,groups=capabilities.3scale.net,resources=openapis,versions=v1beta1,name=vopenapi.kb.io
+
+var _ webhook.Validator = &OpenAPI{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (o *OpenAPI) ValidateCreate() error {
+	openapilog.V(1).Info("validate create", "name", o.Name)
+
+	return invalidError(OpenAPIKind, o.Name, o.Validate())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (o *OpenAPI) ValidateUpdate(old runtime.Object) error {
+	openapilog.V(1).Info("validate update", "name", o.Name)
+
+	oldOpenAPI := old.(*OpenAPI)
+	// Metadata only updates, i.e. finalizer removals, and updates of resources being deleted are not validated
+	if o.DeletionTimestamp != nil || reflect.DeepEqual(oldOpenAPI.Spec, o.Spec) {
+		return nil
+	}
+
+	return invalidError(OpenAPIKind, o.Name, o.Validate())
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (o *OpenAPI) ValidateDelete() error {
+	return nil
+}
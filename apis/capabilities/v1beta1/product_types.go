@@ -58,6 +58,24 @@ const (
 	// ProductFailedConditionType indicates that an error occurred during synchronization.
 	// The operator will retry.
 	ProductFailedConditionType common.ConditionType = "Failed"
+
+	// ProductDriftedConditionType indicates that 3scale differs from the ProductSpec.
+	// Only reported when the custom resource is reconciled in observe mode.
+	// The message lists the changes that would be applied in sync mode.
+	ProductDriftedConditionType common.ConditionType = "Drifted"
+
+	// ProductThrottledConditionType indicates that the admin API of the provider account
+	// is rate limiting the requests or the requests are rejected after too many consecutive failures.
+	// The operator will retry.
+	ProductThrottledConditionType common.ConditionType = "Throttled"
+
+	// OIDCIssuerEndpointSecretField is the field name of the secret
+	// referenced by the OpenID Connect authentication where the issuer endpoint can be found
+	OIDCIssuerEndpointSecretField = "issuerEndpoint"
+
+	// ProductFinalizer is the finalizer that guards the removal of the 3scale product
+	// when the custom resource is deleted
+	ProductFinalizer = "product.capabilities.3scale.net/finalizer"
 )
 
 var (
@@ -322,14 +340,99 @@ func (u *UserKeyAuthenticationSpec) GatewayResponseSpec() *GatewayResponseSpec {
 	return u.GatewayResponse
 }
 
+// OIDCAuthenticationFlowSpec defines the desired OAuth2.0 authorization grant types
+type OIDCAuthenticationFlowSpec struct {
+	// StandardFlowEnabled enables the Authorization Code Flow
+	// +optional
+	StandardFlowEnabled bool `json:"standardFlowEnabled,omitempty"`
+
+	// ImplicitFlowEnabled enables the Implicit Flow
+	// +optional
+	ImplicitFlowEnabled bool `json:"implicitFlowEnabled,omitempty"`
+
+	// ServiceAccountsEnabled enables the Client Credentials Flow
+	// +optional
+	ServiceAccountsEnabled bool `json:"serviceAccountsEnabled,omitempty"`
+
+	// DirectAccessGrantsEnabled enables the Resource Owner Password Credentials Flow
+	// +optional
+	DirectAccessGrantsEnabled bool `json:"directAccessGrantsEnabled,omitempty"`
+}
+
+// OIDCSpec defines the desired state of OpenID Connect Authentication
+type OIDCSpec struct {
+	// IssuerType is the type of the OpenID Provider
+	// keycloak: Red Hat Single Sign-On or Keycloak
+	// rest: any OpenID Provider integrated through the 3scale REST API
+	// +kubebuilder:validation:Enum=keycloak;rest
+	IssuerType string `json:"issuerType"`
+
+	// IssuerEndpointRef references the secret holding the location of the OpenID Provider.
+	// The secret must have the `issuerEndpoint` field, including the credentials of the client:
+	// https://<CLIENT_ID>:<CLIENT_SECRET>@<HOST>:<PORT>/auth/realms/<REALM_NAME>
+	IssuerEndpointRef corev1.LocalObjectReference `json:"issuerEndpointRef"`
+
+	// AuthenticationFlow specifies the OAuth2.0 authorization grant types
+	// +optional
+	AuthenticationFlow *OIDCAuthenticationFlowSpec `json:"authenticationFlow,omitempty"`
+
+	// JwtClaimWithClientID is the JSON Web Token (JWT) Claim with ClientID that contains the clientID.
+	// Defaults to 'azp'.
+	// +optional
+	JwtClaimWithClientID *string `json:"jwtClaimWithClientID,omitempty"`
+
+	// JwtClaimWithClientIDType sets to process the ClientID Token Claim value as a string or as a liquid template.
+	// +kubebuilder:validation:Enum=plain;liquid
+	// +optional
+	JwtClaimWithClientIDType *string `json:"jwtClaimWithClientIDType,omitempty"`
+
+	// Credentials Location available options:
+	// headers: As HTTP Headers
+	// query: As query parameters (GET) or body parameters (POST/PUT/DELETE)
+	// authorization: As HTTP Basic Authentication
+	// +optional
+	// +kubebuilder:validation:Enum=headers;query;authorization
+	CredentialsLoc *string `json:"credentials,omitempty"`
+
+	// +optional
+	Security *SecuritySpec `json:"security,omitempty"`
+
+	// +optional
+	GatewayResponse *GatewayResponseSpec `json:"gatewayResponse,omitempty"`
+}
+
+func (o *OIDCSpec) SecuritySecretToken() *string {
+	if o.Security == nil {
+		return nil
+	}
+
+	return o.Security.SecuritySecretToken()
+}
+
+func (o *OIDCSpec) HostRewrite() *string {
+	if o.Security == nil {
+		return nil
+	}
+
+	return o.Security.HostRewrite()
+}
+
+func (o *OIDCSpec) CredentialsLocation() *string {
+	return o.CredentialsLoc
+}
+
+func (o *OIDCSpec) GatewayResponseSpec() *GatewayResponseSpec {
+	return o.GatewayResponse
+}
+
 // AuthenticationSpec defines the desired state of Product Authentication
 type AuthenticationSpec struct {
 	// +optional
 	UserKeyAuthentication *UserKeyAuthenticationSpec `json:"userkey,omitempty"`
 	// +optional
 	AppKeyAppIDAuthentication *AppKeyAppIDAuthenticationSpec `json:"appKeyAppID,omitempty"`
-
-	// TODO OpenID
+	// +optional
+	OIDC *OIDCSpec `json:"oidc,omitempty"`
 }
 
 func (a *AuthenticationSpec) AuthenticationMode() string {
@@ -338,6 +441,10 @@ func (a *AuthenticationSpec) AuthenticationMode() string {
 		return "1"
 	}
 
+	if a.OIDC != nil {
+		return "oidc"
+	}
+
 	// must be appKey&appID
 	return "2"
 }
@@ -348,8 +455,12 @@ func (a *AuthenticationSpec) SecuritySecretToken() *string {
 		return a.UserKeyAuthentication.SecuritySecretToken()
 	}
 
+	if a.OIDC != nil {
+		return a.OIDC.SecuritySecretToken()
+	}
+
 	if a.AppKeyAppIDAuthentication == nil {
-		panic("product authenticationspec: both userkey and appid_appkeyare nil")
+		panic("product authenticationspec: userkey, appid_appkey and oidc are nil")
 	}
 
 	return a.AppKeyAppIDAuthentication.SecuritySecretToken()
@@ -361,8 +472,12 @@ func (a *AuthenticationSpec) HostRewrite() *string {
 		return a.UserKeyAuthentication.HostRewrite()
 	}
 
+	if a.OIDC != nil {
+		return a.OIDC.HostRewrite()
+	}
+
 	if a.AppKeyAppIDAuthentication == nil {
-		panic("product authenticationspec: both userkey and appid_appkeyare nil")
+		panic("product authenticationspec: userkey, appid_appkey and oidc are nil")
 	}
 
 	return a.AppKeyAppIDAuthentication.HostRewrite()
@@ -374,8 +489,12 @@ func (a *AuthenticationSpec) CredentialsLocation() *string {
 		return a.UserKeyAuthentication.CredentialsLocation()
 	}
 
+	if a.OIDC != nil {
+		return a.OIDC.CredentialsLocation()
+	}
+
 	if a.AppKeyAppIDAuthentication == nil {
-		panic("product authenticationspec: both userkey and appid_appkeyare nil")
+		panic("product authenticationspec: userkey, appid_appkey and oidc are nil")
 	}
 
 	return a.AppKeyAppIDAuthentication.CredentialsLocation()
@@ -408,19 +527,46 @@ func (a *AuthenticationSpec) AuthAppKey() *string {
 	return nil
 }
 
+func (a *AuthenticationSpec) OIDCSpec() *OIDCSpec {
+	return a.OIDC
+}
+
 func (a *AuthenticationSpec) GatewayResponse() *GatewayResponseSpec {
 	// authentication is oneOf by CRD openapiV3 validation
 	if a.UserKeyAuthentication != nil {
 		return a.UserKeyAuthentication.GatewayResponseSpec()
 	}
 
+	if a.OIDC != nil {
+		return a.OIDC.GatewayResponseSpec()
+	}
+
 	if a.AppKeyAppIDAuthentication == nil {
-		panic("product authenticationspec: both userkey and appid_appkeyare nil")
+		panic("product authenticationspec: userkey, appid_appkey and oidc are nil")
 	}
 
 	return a.AppKeyAppIDAuthentication.GatewayResponseSpec()
 }
 
+func (a *AuthenticationSpec) validate(fldPath *field.Path) field.ErrorList {
+	errors := field.ErrorList{}
+	if a == nil {
+		return errors
+	}
+
+	authenticationModes := 0
+	for _, isSet := range []bool{a.UserKeyAuthentication != nil, a.AppKeyAppIDAuthentication != nil, a.OIDC != nil} {
+		if isSet {
+			authenticationModes++
+		}
+	}
+	if authenticationModes != 1 {
+		errors = append(errors, field.Invalid(fldPath, nil, "exactly one of userkey, appKeyAppID or oidc must be set."))
+	}
+
+	return errors
+}
+
 // ApicastHostedSpec defines the desired state of Product Apicast Hosted
 type ApicastHostedSpec struct {
 	// +optional
@@ -477,6 +623,13 @@ func (a *ApicastHostedSpec) AuthAppKey() *string {
 	return a.Authentication.AuthAppKey()
 }
 
+func (a *ApicastHostedSpec) OIDCSpec() *OIDCSpec {
+	if a.Authentication == nil {
+		return nil
+	}
+	return a.Authentication.OIDCSpec()
+}
+
 func (a *ApicastHostedSpec) GatewayResponse() *GatewayResponseSpec {
 	if a.Authentication == nil {
 		return nil
@@ -554,6 +707,13 @@ func (a *ApicastSelfManagedSpec) AuthAppKey() *string {
 	return a.Authentication.AuthAppKey()
 }
 
+func (a *ApicastSelfManagedSpec) OIDCSpec() *OIDCSpec {
+	if a.Authentication == nil {
+		return nil
+	}
+	return a.Authentication.OIDCSpec()
+}
+
 func (a *ApicastSelfManagedSpec) GatewayResponse() *GatewayResponseSpec {
 	if a.Authentication == nil {
 		return nil
@@ -699,6 +859,37 @@ func (d *ProductDeploymentSpec) AuthAppKey() *string {
 	return d.ApicastSelfManaged.AuthAppKey()
 }
 
+func (d *ProductDeploymentSpec) OIDCSpec() *OIDCSpec {
+	// spec.deployment is oneOf by CRD openapiV3 validation
+	if d.ApicastHosted != nil {
+		return d.ApicastHosted.OIDCSpec()
+	}
+
+	if d.ApicastSelfManaged == nil {
+		panic("product spec.deployment apicasthosted and selfmanaged are nil")
+	}
+
+	return d.ApicastSelfManaged.OIDCSpec()
+}
+
+func (d *ProductDeploymentSpec) validate(fldPath *field.Path) field.ErrorList {
+	errors := field.ErrorList{}
+	if d == nil {
+		return errors
+	}
+
+	if (d.ApicastHosted == nil) == (d.ApicastSelfManaged == nil) {
+		errors = append(errors, field.Invalid(fldPath, nil, "exactly one of apicastHosted or apicastSelfManaged must be set."))
+		return errors
+	}
+
+	if d.ApicastHosted != nil {
+		return d.ApicastHosted.Authentication.validate(fldPath.Child("apicastHosted", "authentication"))
+	}
+
+	return d.ApicastSelfManaged.Authentication.validate(fldPath.Child("apicastSelfManaged", "authentication"))
+}
+
 func (d *ProductDeploymentSpec) GatewayResponse() *GatewayResponseSpec {
 	// spec.deployment is oneOf by CRD openapiV3 validation
 	if d.ApicastHosted != nil {
@@ -783,9 +974,75 @@ type ProductSpec struct {
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
 
+	// ProviderAccountCRRef references a ProviderAccount custom resource, possibly in another namespace.
+	// Mutually exclusive with providerAccountRef
+	// +optional
+	ProviderAccountCRRef *ProviderAccountCRReference `json:"providerAccountCRRef,omitempty"`
+
 	// Policies holds the product's policy chain
 	// +optional
 	Policies []PolicyConfig `json:"policies,omitempty"`
+
+	// DeletionPolicy defines what happens to the 3scale product when the custom resource is deleted.
+	// Delete: the 3scale product is removed. Orphan: the 3scale product is left untouched.
+	// Defaults to Orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// ProxyConfigPromotion promotes the staging proxy configuration to production.
+	// When not set, the production proxy configuration is not managed by the operator
+	// +optional
+	ProxyConfigPromotion *ProxyConfigPromotionSpec `json:"proxyConfigPromotion,omitempty"`
+}
+
+// ProxyConfigPromotionSpec defines the promotion of the staging proxy configuration to production
+type ProxyConfigPromotionSpec struct {
+	// StagingVersion is the staging proxy configuration version to promote to production.
+	// When not set, the latest staging proxy configuration version is promoted
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StagingVersion *int64 `json:"stagingVersion,omitempty"`
+
+	// SmokeCheck is a request sent to the staging gateway before promoting.
+	// The staging proxy configuration is only promoted when the check passes.
+	// When not set, the staging proxy configuration is promoted right away
+	// +optional
+	SmokeCheck *ProxyConfigSmokeCheckSpec `json:"smokeCheck,omitempty"`
+}
+
+// ProxyConfigSmokeCheckSpec defines the request checking the staging gateway before promotion
+type ProxyConfigSmokeCheckSpec struct {
+	// URL of the staging gateway, i.e. the APIcast staging health endpoint http://apicast-staging:8090/status/ready.
+	// When not set, the staging public base URL of the product is used
+	// +kubebuilder:validation:Pattern=`^https?:\/\/.*$`
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Path of the smoke test request, appended to the URL. Defaults to "/"
+	// +kubebuilder:validation:Pattern=`^\/.*$`
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// ExpectedStatusCode is the response status code for the check to pass. Defaults to 200
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	// +optional
+	ExpectedStatusCode *int32 `json:"expectedStatusCode,omitempty"`
+
+	// TimeoutSeconds of the smoke test request. Defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// HeadersSecretRef references a secret in the product namespace
+	// whose keys and values are sent as request headers, i.e. credentials
+	// +optional
+	HeadersSecretRef *corev1.LocalObjectReference `json:"headersSecretRef,omitempty"`
+
+	// InsecureSkipVerify skips the verification of the staging gateway certificate,
+	// i.e. gateways exposed with self-signed certificates. Defaults to false
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 func (s *ProductSpec) DeploymentOption() *string {
@@ -859,6 +1116,13 @@ func (s *ProductSpec) AuthAppKey() *string {
 	return s.Deployment.AuthAppKey()
 }
 
+func (s *ProductSpec) OIDCSpec() *OIDCSpec {
+	if s.Deployment == nil {
+		return nil
+	}
+	return s.Deployment.OIDCSpec()
+}
+
 func (s *ProductSpec) GatewayResponse() *GatewayResponseSpec {
 	if s.Deployment == nil {
 		return nil
@@ -935,6 +1199,14 @@ type ProductStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// ProxyConfigPromotion reports the last staging proxy configuration promoted to production
+	// +optional
+	ProxyConfigPromotion *ProxyConfigPromotionStatus `json:"proxyConfigPromotion,omitempty"`
+
+	// ProxyConfigSmokeCheck reports the last smoke check of the staging gateway
+	// +optional
+	ProxyConfigSmokeCheck *ProxyConfigSmokeCheckStatus `json:"proxyConfigSmokeCheck,omitempty"`
+
 	// Current state of the 3scale product.
 	// Conditions represent the latest available observations of an object's state
 	// +optional
@@ -943,6 +1215,35 @@ type ProductStatus struct {
 	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
 }
 
+// ProxyConfigPromotionStatus defines the observed state of the proxy configuration promotion
+type ProxyConfigPromotionStatus struct {
+	// StagingVersion is the staging proxy configuration version promoted to production
+	StagingVersion int64 `json:"stagingVersion"`
+
+	// ProductionVersion is the production proxy configuration version created by the promotion
+	ProductionVersion int64 `json:"productionVersion"`
+}
+
+// ProxyConfigSmokeCheckStatus defines the observed result of the staging gateway smoke check
+type ProxyConfigSmokeCheckStatus struct {
+	// StagingVersion is the staging proxy configuration version checked
+	StagingVersion int64 `json:"stagingVersion"`
+
+	// Passed is true when the staging gateway responded with the expected status code
+	Passed bool `json:"passed"`
+
+	// StatusCode is the response status code of the staging gateway
+	// +optional
+	StatusCode int32 `json:"statusCode,omitempty"`
+
+	// Message describes why the check did not pass
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastCheckTime is the last time the check was run
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+}
+
 func (p *ProductStatus) Equals(other *ProductStatus, logger logr.Logger) bool {
 	if !reflect.DeepEqual(p.ID, other.ID) {
 		diff := cmp.Diff(p.ID, other.ID)
@@ -968,6 +1269,18 @@ func (p *ProductStatus) Equals(other *ProductStatus, logger logr.Logger) bool {
 		return false
 	}
 
+	if !reflect.DeepEqual(p.ProxyConfigPromotion, other.ProxyConfigPromotion) {
+		diff := cmp.Diff(p.ProxyConfigPromotion, other.ProxyConfigPromotion)
+		logger.V(1).Info("ProxyConfigPromotion not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(p.ProxyConfigSmokeCheck, other.ProxyConfigSmokeCheck) {
+		diff := cmp.Diff(p.ProxyConfigSmokeCheck, other.ProxyConfigSmokeCheck)
+		logger.V(1).Info("ProxyConfigSmokeCheck not equal", "difference", diff)
+		return false
+	}
+
 	// Marshalling sorts by condition type
 	currentMarshaledJSON, _ := p.Conditions.MarshalJSON()
 	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
@@ -1058,6 +1371,7 @@ func (product *Product) Validate() field.ErrorList {
 	mappingRulesFldPath := specFldPath.Child("mappingRules")
 	applicationPlansFldPath := specFldPath.Child("applicationPlans")
 	methodsFldPath := specFldPath.Child("methods")
+	deploymentFldPath := specFldPath.Child("deployment")
 
 	// check hits metric exists
 	if len(product.Spec.Metrics) == 0 {
@@ -1108,6 +1422,23 @@ func (product *Product) Validate() field.ErrorList {
 		}
 	}
 
+	// Check deployment and authentication are oneOf.
+	// Deployment accessors cannot be used when the deployment is not valid
+	deploymentErrors := product.Spec.Deployment.validate(deploymentFldPath)
+	errors = append(errors, deploymentErrors...)
+
+	// Check OpenID Connect issuer endpoint secret reference
+	if len(deploymentErrors) == 0 {
+		if oidcSpec := product.Spec.OIDCSpec(); oidcSpec != nil && oidcSpec.IssuerEndpointRef.Name == "" {
+			apicastFldPath := deploymentFldPath.Child("apicastHosted")
+			if product.Spec.Deployment.ApicastSelfManaged != nil {
+				apicastFldPath = deploymentFldPath.Child("apicastSelfManaged")
+			}
+			issuerEndpointRefFldPath := apicastFldPath.Child("authentication", "oidc", "issuerEndpointRef", "name")
+			errors = append(errors, field.Required(issuerEndpointRefFldPath, "OpenID Connect authentication requires issuerEndpointRef secret name."))
+		}
+	}
+
 	// Check mapping rules metrics and method refs exists
 	for idx, spec := range product.Spec.MappingRules {
 		if !product.FindMetricOrMethod(spec.MetricMethodRef) {
@@ -1185,6 +1516,9 @@ func (product *Product) Validate() field.ErrorList {
 		}
 	}
 
+	errors = append(errors, validateProviderAccountReferences(specFldPath, product.Spec.ProviderAccountRef, product.Spec.ProviderAccountCRRef)...)
+	errors = append(errors, validateResyncPeriodAnnotation(product)...)
+
 	return errors
 }
 
@@ -1192,6 +1526,14 @@ func (product *Product) IsSynced() bool {
 	return product.Status.Conditions.IsTrueFor(ProductSyncedConditionType)
 }
 
+// IsRemoteDeletionEnabled returns true when the 3scale product has to be removed
+// on custom resource deletion. 3scale is never changed in observe mode.
+// Custom resources created before the deletion policy was introduced never removed the 3scale product,
+// so only an explicit Delete policy removes it
+func (product *Product) IsRemoteDeletionEnabled() bool {
+	return product.Spec.DeletionPolicy == DeletionPolicyDelete && !IsObserveMode(product)
+}
+
 func (product *Product) FindMetricOrMethod(ref string) bool {
 	if len(product.Spec.Metrics) > 0 {
 		if _, ok := product.Spec.Metrics[ref]; ok {
@@ -3,6 +3,7 @@ package v1beta1
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -260,3 +261,131 @@ func TestValidateProductHappyPath(t *testing.T) {
 		t.Errorf("product validation fails: %s", errors.ToAggregate().Error())
 	}
 }
+
+func TestProductIsRemoteDeletionEnabled(t *testing.T) {
+	cases := []struct {
+		testName       string
+		deletionPolicy DeletionPolicy
+		expected       bool
+	}{
+		{"default", "", false},
+		{"delete", DeletionPolicyDelete, true},
+		{"orphan", DeletionPolicyOrphan, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			product := defaultTestingProduct()
+			product.Spec.DeletionPolicy = tc.deletionPolicy
+			if product.IsRemoteDeletionEnabled() != tc.expected {
+				subT.Errorf("expected %t, got %t", tc.expected, product.IsRemoteDeletionEnabled())
+			}
+		})
+	}
+}
+
+func TestProductResyncPeriod(t *testing.T) {
+	cases := []struct {
+		testName      string
+		annotations   map[string]string
+		expected      time.Duration
+		expectedValid bool
+	}{
+		{"default", nil, time.Hour, true},
+		{"override", map[string]string{ResyncPeriodAnnotation: "10m"}, 10 * time.Minute, true},
+		{"disabled", map[string]string{ResyncPeriodAnnotation: "0"}, 0, true},
+		{"invalid", map[string]string{ResyncPeriodAnnotation: "often"}, time.Hour, false},
+		{"negative", map[string]string{ResyncPeriodAnnotation: "-1m"}, time.Hour, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			product := defaultTestingProduct()
+			product.Annotations = tc.annotations
+			if period := ResyncPeriod(&product, time.Hour); period != tc.expected {
+				subT.Errorf("expected %s, got %s", tc.expected, period)
+			}
+			if errors := product.Validate(); (len(errors) == 0) != tc.expectedValid {
+				subT.Errorf("expected valid %t, got %v", tc.expectedValid, errors)
+			}
+		})
+	}
+}
+
+func TestProductOIDCAuthentication(t *testing.T) {
+	product := defaultTestingProduct()
+	product.Spec.Deployment = &ProductDeploymentSpec{
+		ApicastHosted: &ApicastHostedSpec{
+			Authentication: &AuthenticationSpec{
+				OIDC: &OIDCSpec{
+					IssuerType: "keycloak",
+				},
+			},
+		},
+	}
+
+	authMode := product.Spec.AuthenticationMode()
+	if authMode == nil || *authMode != "oidc" {
+		t.Errorf("product authentication mode does not match. Expected oidc, got %v", authMode)
+	}
+
+	errors := product.Validate()
+	if len(errors) != 1 || errors[0].Field != "spec.deployment.apicastHosted.authentication.oidc.issuerEndpointRef.name" {
+		t.Errorf("product oidc validation fails when issuer endpoint ref is missing: %v", errors)
+	}
+
+	product.Spec.Deployment.ApicastHosted.Authentication.OIDC.IssuerEndpointRef.Name = "myissuer"
+	errors = product.Validate()
+	if len(errors) > 0 {
+		t.Errorf("product with oidc authentication is invalid: %s", errors.ToAggregate().Error())
+	}
+
+	product.Spec.Deployment = &ProductDeploymentSpec{
+		ApicastSelfManaged: &ApicastSelfManagedSpec{
+			Authentication: &AuthenticationSpec{
+				OIDC: &OIDCSpec{
+					IssuerType: "keycloak",
+				},
+			},
+		},
+	}
+	errors = product.Validate()
+	if len(errors) != 1 || errors[0].Field != "spec.deployment.apicastSelfManaged.authentication.oidc.issuerEndpointRef.name" {
+		t.Errorf("product oidc validation fails when self managed issuer endpoint ref is missing: %v", errors)
+	}
+}
+
+func TestValidateProductDeployment(t *testing.T) {
+	cases := []struct {
+		testName   string
+		deployment *ProductDeploymentSpec
+		errorMsg   string
+	}{
+		{"EmptyDeployment", &ProductDeploymentSpec{}, "exactly one of apicastHosted or apicastSelfManaged"},
+		{"BothDeploymentOptions", &ProductDeploymentSpec{
+			ApicastHosted:      &ApicastHostedSpec{},
+			ApicastSelfManaged: &ApicastSelfManagedSpec{},
+		}, "exactly one of apicastHosted or apicastSelfManaged"},
+		{"EmptyAuthentication", &ProductDeploymentSpec{
+			ApicastSelfManaged: &ApicastSelfManagedSpec{Authentication: &AuthenticationSpec{}},
+		}, "exactly one of userkey, appKeyAppID or oidc"},
+		{"SeveralAuthentications", &ProductDeploymentSpec{
+			ApicastHosted: &ApicastHostedSpec{Authentication: &AuthenticationSpec{
+				UserKeyAuthentication:     &UserKeyAuthenticationSpec{},
+				AppKeyAppIDAuthentication: &AppKeyAppIDAuthenticationSpec{},
+			}},
+		}, "exactly one of userkey, appKeyAppID or oidc"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			product := defaultTestingProduct()
+			product.Spec.Deployment = tc.deployment
+
+			errors := product.Validate()
+			if len(errors) == 0 || !strings.Contains(errors.ToAggregate().Error(), tc.errorMsg) {
+				subT.Errorf("expected error '%s', got %v", tc.errorMsg, errors)
+			}
+		})
+	}
+}
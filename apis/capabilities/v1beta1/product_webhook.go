@@ -0,0 +1,84 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var productlog = logf.Log.WithName("product-resource")
+
+// SetupWebhookWithManager registers the Product admission webhooks in the manager
+func (product *Product) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(product).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-capabilities-3scale-net-v1beta1-product,mutating=true,failurePolicy=fail,groups=capabilities.3scale.net,resources=products,verbs=create;update,versions=v1beta1,name=mproduct.kb.io
+
+var _ webhook.Defaulter = &Product{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (product *Product) Default() {
+	productlog.V(1).Info("default", "name", product.Name)
+
+	product.SetDefaults(productlog)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-capabilities-3scale-net-v1beta1-product,mutating=false,failurePolicy=fail,groups=capabilities.3scale.net,resources=products,versions=v1beta1,name=vproduct.kb.io
+
+var _ webhook.Validator = &Product{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (product *Product) ValidateCreate() error {
+	productlog.V(1).Info("validate create", "name", product.Name)
+
+	return invalidError(ProductKind, product.Name, product.Validate())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (product *Product) ValidateUpdate(old runtime.Object) error {
+	productlog.V(1).Info("validate update", "name", product.Name)
+
+	oldProduct := old.(*Product)
+	// Metadata only updates, i.e. finalizer removals, and updates of resources being deleted are not validated
+	if product.DeletionTimestamp != nil || reflect.DeepEqual(oldProduct.Spec, product.Spec) {
+		return nil
+	}
+
+	errors := product.Validate()
+
+	// The 3scale product is looked up by system name
+	if oldProduct.Spec.SystemName != "" {
+		errors = append(errors, apivalidation.ValidateImmutableField(product.Spec.SystemName, oldProduct.Spec.SystemName, field.NewPath("spec").Child("systemName"))...)
+	}
+
+	return invalidError(ProductKind, product.Name, errors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (product *Product) ValidateDelete() error {
+	return nil
+}
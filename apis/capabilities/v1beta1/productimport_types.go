@@ -0,0 +1,207 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ProductImportKind = "ProductImport"
+
+	// ProductImportInvalidConditionType represents that the combination of configuration
+	// in the ProductImportSpec is not supported. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	// Example: the spec references non existing 3scale product
+	ProductImportInvalidConditionType common.ConditionType = "Invalid"
+
+	// ProductImportCompletedConditionType indicates the import has been completed.
+	// Steady state. The import is not run again unless the spec changes
+	ProductImportCompletedConditionType common.ConditionType = "Completed"
+
+	// ProductImportFailedConditionType indicates that an error occurred during the import.
+	// The operator will retry.
+	ProductImportFailedConditionType common.ConditionType = "Failed"
+
+	// ProductImportProductManifestKey is the key of the Product manifest
+	// in the config map generated for each imported product
+	ProductImportProductManifestKey = "product.yaml"
+
+	// ProductImportOIDCIssuerEndpointSecretSuffix is appended to the name of the Product custom resource
+	// to name the secret with the OpenID Connect issuer endpoint created on adoption
+	ProductImportOIDCIssuerEndpointSecretSuffix = "-oidc-issuer"
+)
+
+// ProductImportSpec defines the desired state of ProductImport
+type ProductImportSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ProductSystemNames selects the 3scale products to import.
+	// All the 3scale products are imported when empty
+	// +optional
+	ProductSystemNames []string `json:"productSystemNames,omitempty"`
+
+	// Adopt creates the Product and Backend custom resources in the namespace.
+	// From then on, the imported 3scale products and backends are managed by the operator
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+}
+
+// ImportedProductStatus defines the outcome of the import of one 3scale product
+type ImportedProductStatus struct {
+	// ID is the 3scale product ID
+	ID int64 `json:"productId"`
+
+	// SystemName is the 3scale product system name
+	SystemName string `json:"systemName"`
+
+	// ResourceName is the name of the generated Product custom resource
+	ResourceName string `json:"resourceName"`
+
+	// ManifestsConfigMap is the name of the config map with the generated Product and Backend manifests
+	ManifestsConfigMap string `json:"manifestsConfigMap"`
+
+	// Adopted is true when the Product custom resource has been created by the import
+	// +optional
+	Adopted bool `json:"adopted,omitempty"`
+}
+
+// ImportedBackendStatus defines the outcome of the import of one 3scale backend
+type ImportedBackendStatus struct {
+	// ID is the 3scale backend ID
+	ID int64 `json:"backendId"`
+
+	// SystemName is the 3scale backend system name
+	SystemName string `json:"systemName"`
+
+	// ResourceName is the name of the generated Backend custom resource
+	ResourceName string `json:"resourceName"`
+
+	// Adopted is true when the Backend custom resource has been created by the import
+	// +optional
+	Adopted bool `json:"adopted,omitempty"`
+}
+
+// ProductImportStatus defines the observed state of ProductImport
+type ProductImportStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Products lists the imported 3scale products
+	// +optional
+	Products []ImportedProductStatus `json:"products,omitempty"`
+
+	// Backends lists the imported 3scale backends
+	// +optional
+	Backends []ImportedBackendStatus `json:"backends,omitempty"`
+
+	// 3scale control plane host
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed ProductImport Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the import.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (p *ProductImportStatus) Equals(other *ProductImportStatus, logger logr.Logger) bool {
+	if !reflect.DeepEqual(p.Products, other.Products) {
+		diff := cmp.Diff(p.Products, other.Products)
+		logger.V(1).Info("Products not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(p.Backends, other.Backends) {
+		diff := cmp.Diff(p.Backends, other.Backends)
+		logger.V(1).Info("Backends not equal", "difference", diff)
+		return false
+	}
+
+	if p.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(p.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if p.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(p.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := p.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ProductImport is the Schema for the productimports API
+// +kubebuilder:resource:path=productimports,scope=Namespaced
+// +operator-sdk:csv:customresourcedefinitions:displayName="3scale Product Import"
+type ProductImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProductImportSpec   `json:"spec,omitempty"`
+	Status ProductImportStatus `json:"status,omitempty"`
+}
+
+// IsCompleted returns true when the current spec has already been imported
+func (p *ProductImport) IsCompleted() bool {
+	return p.Status.ObservedGeneration == p.Generation &&
+		p.Status.Conditions.IsTrueFor(ProductImportCompletedConditionType)
+}
+
+// +kubebuilder:object:root=true
+
+// ProductImportList contains a list of ProductImport
+type ProductImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProductImport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProductImport{}, &ProductImportList{})
+}
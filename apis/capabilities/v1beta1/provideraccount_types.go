@@ -0,0 +1,162 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	ProviderAccountKind = "ProviderAccount"
+
+	// ProviderAccountReadyConditionType indicates the credentials secret is valid
+	ProviderAccountReadyConditionType common.ConditionType = "Ready"
+
+	// ProviderAccountAllNamespaces in the allowed namespaces list allows any namespace
+	ProviderAccountAllNamespaces = "*"
+)
+
+// ProviderAccountSpec defines the desired state of ProviderAccount
+type ProviderAccountSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// CredentialsRef references the secret, in the same namespace, with the provider account credentials.
+	// Same fields as the providerAccountRef secret: adminURL, token and optional TLS settings
+	CredentialsRef corev1.LocalObjectReference `json:"credentialsRef"`
+
+	// AllowedNamespaces lists the namespaces whose custom resources may reference the provider account.
+	// "*" allows every namespace. The namespace of the provider account is always allowed
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// ProviderAccountStatus defines the observed state of ProviderAccount
+type ProviderAccountStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// 3scale control plane host
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed ProviderAccount Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the provider account.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (p *ProviderAccountStatus) Equals(other *ProviderAccountStatus, logger logr.Logger) bool {
+	if p.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(p.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if p.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(p.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := p.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ProviderAccount is the Schema for the provideraccounts API
+// +kubebuilder:resource:path=provideraccounts,scope=Namespaced
+// +operator-sdk:csv:customresourcedefinitions:displayName="3scale Provider Account"
+type ProviderAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderAccountSpec   `json:"spec,omitempty"`
+	Status ProviderAccountStatus `json:"status,omitempty"`
+}
+
+// IsNamespaceAllowed returns true when custom resources in the namespace may reference the provider account
+func (p *ProviderAccount) IsNamespaceAllowed(namespace string) bool {
+	if namespace == p.Namespace {
+		return true
+	}
+
+	for _, allowed := range p.Spec.AllowedNamespaces {
+		if allowed == ProviderAccountAllNamespaces || allowed == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderAccountList contains a list of ProviderAccount
+type ProviderAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderAccount{}, &ProviderAccountList{})
+}
+
+// ProviderAccountCRReference references a ProviderAccount custom resource, possibly in another namespace
+type ProviderAccountCRReference struct {
+	// Name of the ProviderAccount custom resource
+	Name string `json:"name"`
+
+	// Namespace of the ProviderAccount custom resource.
+	// Defaults to the namespace of the referencing custom resource
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// validateProviderAccountReferences checks that, at most, one provider account reference is set
+func validateProviderAccountReferences(specFldPath *field.Path, providerAccountRef *corev1.LocalObjectReference, providerAccountCRRef *ProviderAccountCRReference) field.ErrorList {
+	errors := field.ErrorList{}
+
+	if providerAccountRef != nil && providerAccountCRRef != nil {
+		errors = append(errors, field.Invalid(specFldPath.Child("providerAccountCRRef"), providerAccountCRRef.Name,
+			"providerAccountRef and providerAccountCRRef are mutually exclusive"))
+	}
+
+	return errors
+}
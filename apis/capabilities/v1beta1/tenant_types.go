@@ -0,0 +1,241 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	TenantKind = "Tenant"
+
+	// TenantSyncedConditionType indicates the tenant has been successfully synchronized.
+	// Steady state
+	TenantSyncedConditionType common.ConditionType = "Synced"
+
+	// TenantFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	TenantFailedConditionType common.ConditionType = "Failed"
+
+	// TenantSuspendedConditionType indicates the 3scale tenant account is suspended
+	TenantSuspendedConditionType common.ConditionType = "Suspended"
+
+	// TenantFinalizer is the finalizer that guards the removal of the 3scale tenant
+	// when the custom resource is deleted
+	TenantFinalizer = "tenant.capabilities.3scale.net/finalizer"
+)
+
+// TenantState defines the desired state of the 3scale tenant account
+// +kubebuilder:validation:Enum=active;suspended
+type TenantState string
+
+const (
+	// TenantStateActive keeps the 3scale tenant account operative
+	TenantStateActive TenantState = "active"
+
+	// TenantStateSuspended suspends the 3scale tenant account
+	TenantStateSuspended TenantState = "suspended"
+)
+
+// TenantSpec defines the desired state of Tenant
+type TenantSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Username of the tenant admin user
+	Username string `json:"username"`
+
+	// Email of the tenant admin user
+	Email string `json:"email"`
+
+	// OrganizationName of the tenant
+	OrganizationName string `json:"organizationName"`
+
+	// SystemMasterUrl is the 3scale master account admin URL
+	SystemMasterUrl string `json:"systemMasterUrl"`
+
+	// TenantSecretRef references the secret where the tenant provider account credentials are written
+	TenantSecretRef corev1.SecretReference `json:"tenantSecretRef"`
+
+	// PasswordCredentialsRef references the secret with the tenant admin user password
+	PasswordCredentialsRef corev1.SecretReference `json:"passwordCredentialsRef"`
+
+	// MasterCredentialsRef references the secret with the 3scale master account access token
+	MasterCredentialsRef corev1.SecretReference `json:"masterCredentialsRef"`
+
+	// State defines the desired state of the 3scale tenant account.
+	// Defaults to active
+	// +optional
+	State TenantState `json:"state,omitempty"`
+
+	// DeletionPolicy defines what happens to the 3scale tenant when the custom resource is deleted.
+	// Delete: the 3scale tenant is scheduled for deletion. Orphan: the 3scale tenant is left untouched.
+	// Defaults to Orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// TenantStatus defines the observed state of Tenant
+type TenantStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	TenantId int64 `json:"tenantId"`
+	AdminId  int64 `json:"adminId"`
+
+	// State of the 3scale tenant account
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Tenant Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the 3scale tenant.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (t *TenantStatus) Equals(other *TenantStatus, logger logr.Logger) bool {
+	if t.TenantId != other.TenantId {
+		diff := cmp.Diff(t.TenantId, other.TenantId)
+		logger.V(1).Info("TenantId not equal", "difference", diff)
+		return false
+	}
+
+	if t.AdminId != other.AdminId {
+		diff := cmp.Diff(t.AdminId, other.AdminId)
+		logger.V(1).Info("AdminId not equal", "difference", diff)
+		return false
+	}
+
+	if t.State != other.State {
+		diff := cmp.Diff(t.State, other.State)
+		logger.V(1).Info("State not equal", "difference", diff)
+		return false
+	}
+
+	if t.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(t.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := t.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Tenant is the Schema for the tenants API
+// +kubebuilder:resource:path=tenants,scope=Namespaced
+// +operator-sdk:csv:customresourcedefinitions:displayName="Tenant"
+type Tenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantSpec   `json:"spec,omitempty"`
+	Status TenantStatus `json:"status,omitempty"`
+}
+
+// SetDefaults sets the default vaules for the tenant spec and returns true if the spec was changed
+func (t *Tenant) SetDefaults() bool {
+	changed := false
+	ts := &t.Spec
+	if ts.TenantSecretRef.Name == "" {
+		ts.TenantSecretRef.Name = fmt.Sprintf("%s-%s", strings.ToLower(t.Name), strings.ToLower(t.Spec.OrganizationName))
+		changed = true
+	}
+	if ts.TenantSecretRef.Namespace == "" {
+		ts.TenantSecretRef.Namespace = t.Namespace
+		changed = true
+	}
+	if ts.State == "" {
+		ts.State = TenantStateActive
+		changed = true
+	}
+	return changed
+}
+
+// Validate checks the tenant spec references
+func (t *Tenant) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+
+	masterURL, err := url.Parse(t.Spec.SystemMasterUrl)
+	if err != nil || (masterURL.Scheme != "http" && masterURL.Scheme != "https") || masterURL.Host == "" {
+		errors = append(errors, field.Invalid(specFldPath.Child("systemMasterUrl"), t.Spec.SystemMasterUrl, "systemMasterUrl must be an http or https URL."))
+	}
+
+	if t.Spec.MasterCredentialsRef.Name == "" {
+		errors = append(errors, field.Required(specFldPath.Child("masterCredentialsRef", "name"), "master credentials secret name is required."))
+	}
+
+	if t.Spec.PasswordCredentialsRef.Name == "" {
+		errors = append(errors, field.Required(specFldPath.Child("passwordCredentialsRef", "name"), "admin password secret name is required."))
+	}
+
+	return errors
+}
+
+// IsRemoteDeletionEnabled returns true when the 3scale tenant has to be removed
+// on custom resource deletion.
+// Tenants created before the deletion policy was introduced were never removed from 3scale,
+// so only an explicit Delete policy removes the 3scale tenant
+func (t *Tenant) IsRemoteDeletionEnabled() bool {
+	return t.Spec.DeletionPolicy == DeletionPolicyDelete
+}
+
+// IsSuspended returns true when the 3scale tenant account is required to be suspended
+func (t *Tenant) IsSuspended() bool {
+	return t.Spec.State == TenantStateSuspended
+}
+
+// +kubebuilder:object:root=true
+
+// TenantList contains a list of Tenant
+type TenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tenant{}, &TenantList{})
+}
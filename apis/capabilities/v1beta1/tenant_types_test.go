@@ -0,0 +1,27 @@
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestTenantIsRemoteDeletionEnabled(t *testing.T) {
+	cases := []struct {
+		testName       string
+		deletionPolicy DeletionPolicy
+		expected       bool
+	}{
+		{"default", "", false},
+		{"delete", DeletionPolicyDelete, true},
+		{"orphan", DeletionPolicyOrphan, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			tenant := &Tenant{Spec: TenantSpec{DeletionPolicy: tc.deletionPolicy}}
+			tenant.SetDefaults()
+			if tenant.IsRemoteDeletionEnabled() != tc.expected {
+				subT.Errorf("expected %t, got %t", tc.expected, tenant.IsRemoteDeletionEnabled())
+			}
+		})
+	}
+}
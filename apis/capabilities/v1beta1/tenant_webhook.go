@@ -0,0 +1,82 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var tenantlog = logf.Log.WithName("tenant-resource")
+
+// SetupWebhookWithManager registers the Tenant conversion and admission webhooks in the manager
+func (t *Tenant) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(t).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-capabilities-3scale-net-v1beta1-tenant,mutating=true,failurePolicy=fail,groups=capabilities.3scale.net,resources=tenants,verbs=create;update,versions=v1beta1,name=mtenant.kb.io
+
+var _ webhook.Defaulter = &Tenant{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (t *Tenant) Default() {
+	tenantlog.V(1).Info("default", "name", t.Name)
+
+	t.SetDefaults()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-capabilities-3scale-net-v1beta1-tenant,mutating=false,failurePolicy=fail,groups=capabilities.3scale.net,resources=tenants,versions=v1beta1,name=vtenant.kb.io
+
+var _ webhook.Validator = &Tenant{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (t *Tenant) ValidateCreate() error {
+	tenantlog.V(1).Info("validate create", "name", t.Name)
+
+	return invalidError(TenantKind, t.Name, t.Validate())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (t *Tenant) ValidateUpdate(old runtime.Object) error {
+	tenantlog.V(1).Info("validate update", "name", t.Name)
+
+	oldTenant := old.(*Tenant)
+	// Metadata only updates, i.e. finalizer removals, and updates of resources being deleted are not validated
+	if t.DeletionTimestamp != nil || reflect.DeepEqual(oldTenant.Spec, t.Spec) {
+		return nil
+	}
+
+	errors := t.Validate()
+
+	// The tenant account is looked up by id in the master account
+	errors = append(errors, apivalidation.ValidateImmutableField(t.Spec.SystemMasterUrl, oldTenant.Spec.SystemMasterUrl, field.NewPath("spec").Child("systemMasterUrl"))...)
+
+	return invalidError(TenantKind, t.Name, errors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (t *Tenant) ValidateDelete() error {
+	return nil
+}
@@ -0,0 +1,116 @@
+package v1beta1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProductValidateUpdate(t *testing.T) {
+	oldProduct := defaultTestingProduct()
+	product := defaultTestingProduct()
+
+	product.Spec.Description = "new description"
+	if err := product.ValidateUpdate(&oldProduct); err != nil {
+		t.Errorf("unexpected error updating product: %v", err)
+	}
+
+	product.Spec.SystemName = "productb"
+	if err := product.ValidateUpdate(&oldProduct); !apierrors.IsInvalid(err) {
+		t.Errorf("expected invalid error updating product system name, got %v", err)
+	}
+}
+
+func TestBackendValidateUpdate(t *testing.T) {
+	oldBackend := Backend{Spec: BackendSpec{Name: "backendA", PrivateBaseURL: "https://example.com"}}
+	oldBackend.SetDefaults(getv1beta1TestLogger())
+	backend := oldBackend.DeepCopy()
+
+	backend.Spec.PrivateBaseURL = "https://other.example.com"
+	if err := backend.ValidateUpdate(&oldBackend); err != nil {
+		t.Errorf("unexpected error updating backend: %v", err)
+	}
+
+	backend.Spec.SystemName = "backendb"
+	if err := backend.ValidateUpdate(&oldBackend); !apierrors.IsInvalid(err) {
+		t.Errorf("expected invalid error updating backend system name, got %v", err)
+	}
+}
+
+func TestValidateUpdateSkipsUnchangedSpec(t *testing.T) {
+	now := metav1.Now()
+
+	// Specs stored before the validation rules existed, missing the hits metric
+	oldProduct := defaultTestingProduct()
+	oldProduct.Spec.Metrics = nil
+	oldBackend := Backend{Spec: BackendSpec{Name: "backendA", PrivateBaseURL: "https://example.com"}}
+
+	product := oldProduct.DeepCopy()
+	product.Finalizers = []string{}
+	if err := product.ValidateUpdate(&oldProduct); err != nil {
+		t.Errorf("unexpected error updating product metadata: %v", err)
+	}
+
+	product.Spec.SystemName = "productb"
+	product.DeletionTimestamp = &now
+	if err := product.ValidateUpdate(&oldProduct); err != nil {
+		t.Errorf("unexpected error updating product being deleted: %v", err)
+	}
+
+	backend := oldBackend.DeepCopy()
+	backend.Finalizers = []string{}
+	if err := backend.ValidateUpdate(&oldBackend); err != nil {
+		t.Errorf("unexpected error updating backend metadata: %v", err)
+	}
+
+	backend.Spec.Description = "new description"
+	if err := backend.ValidateUpdate(&oldBackend); !apierrors.IsInvalid(err) {
+		t.Errorf("expected invalid error updating backend spec, got %v", err)
+	}
+
+	backend.DeletionTimestamp = &now
+	if err := backend.ValidateUpdate(&oldBackend); err != nil {
+		t.Errorf("unexpected error updating backend being deleted: %v", err)
+	}
+}
+
+func TestTenantValidate(t *testing.T) {
+	tenant := &Tenant{
+		Spec: TenantSpec{
+			Username:               "admin",
+			Email:                  "admin@example.com",
+			OrganizationName:       "org",
+			SystemMasterUrl:        "https://master.example.com",
+			PasswordCredentialsRef: corev1.SecretReference{Name: "password"},
+			MasterCredentialsRef:   corev1.SecretReference{Name: "master"},
+		},
+	}
+
+	if err := tenant.ValidateCreate(); err != nil {
+		t.Errorf("unexpected error creating tenant: %v", err)
+	}
+
+	invalidTenant := tenant.DeepCopy()
+	invalidTenant.Spec.SystemMasterUrl = "master.example.com"
+	invalidTenant.Spec.MasterCredentialsRef.Name = ""
+	err := invalidTenant.ValidateCreate()
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("expected invalid error creating tenant, got %v", err)
+	}
+	if causes := err.(*apierrors.StatusError).Status().Details.Causes; len(causes) != 2 {
+		t.Errorf("expected 2 causes, got %v", causes)
+	}
+
+	updatedTenant := tenant.DeepCopy()
+	updatedTenant.Spec.OrganizationName = "neworg"
+	if err := updatedTenant.ValidateUpdate(tenant); err != nil {
+		t.Errorf("unexpected error updating tenant: %v", err)
+	}
+
+	updatedTenant.Spec.SystemMasterUrl = "https://othermaster.example.com"
+	if err := updatedTenant.ValidateUpdate(tenant); !apierrors.IsInvalid(err) {
+		t.Errorf("expected invalid error updating tenant master url, got %v", err)
+	}
+}
@@ -19,22 +19,23 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"reflect"
-	"sort"
 
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
-	"github.com/RHsyseng/operator-utils/pkg/olm"
 	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -52,14 +53,19 @@ var _ reconcile.Reconciler = &APIManagerReconciler{}
 // +kubebuilder:rbac:groups=core,namespace=placeholder,resources=pods;services;services/finalizers;replicationcontrollers;endpoints;persistentvolumeclaims;events;configmaps;secrets;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,namespace=placeholder,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,namespace=placeholder,resources=deployments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,namespace=placeholder,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=image.openshift.io,namespace=placeholder,resources=imagestreams;imagestreams/layers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=image.openshift.io,namespace=placeholder,resources=imagestreamtags,verbs=get;list;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/custom-host,verbs=create
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/status,verbs=get
+// +kubebuilder:rbac:groups=networking.k8s.io,namespace=placeholder,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,namespace=placeholder,resources=gateways,verbs=get
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,namespace=placeholder,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.openshift.io,namespace=placeholder,resources=deploymentconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,namespace=placeholder,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,namespace=placeholder,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=placeholder,resources=podmonitors;servicemonitors;prometheusrules,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=integreatly.org,namespace=placeholder,resources=grafanadashboards,verbs=get;list;watch;create;update;delete
 
@@ -94,6 +100,17 @@ func (r *APIManagerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		logger.Info(fmt.Sprintf("Upgrade %s -> %s", instance.Annotations[appsv1alpha1.OperatorVersionAnnotation], version.Version))
 		// TODO add logic to check that only immediate consecutive installs
 		// are possible?
+
+		// The UpgradeInProgress condition is set before upgrading
+		statusResult, err := r.reconcileAPIManagerStatus(instance, nil)
+		if err != nil {
+			logger.Error(err, "Error updating status")
+			return ctrl.Result{}, err
+		}
+		if statusResult.Requeue {
+			return statusResult, nil
+		}
+
 		res, err := r.upgradeAPIManager(instance)
 		if err != nil {
 			logger.Error(err, "Error upgrading APIManager")
@@ -115,6 +132,11 @@ func (r *APIManagerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 	result, err := r.reconcileAPIManagerLogic(instance)
 	if err != nil {
 		logger.Error(err, "Error during reconciliation")
+		// The error is reported in the Degraded condition
+		_, statusErr := r.reconcileAPIManagerStatus(instance, err)
+		if statusErr != nil {
+			logger.Error(statusErr, "Error updating status")
+		}
 		return result, err
 	}
 	if result.Requeue {
@@ -122,7 +144,7 @@ func (r *APIManagerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		return result, nil
 	}
 
-	statusResult, err := r.reconcileAPIManagerStatus(instance)
+	statusResult, err := r.reconcileAPIManagerStatus(instance, nil)
 	if err != nil {
 		logger.Error(err, "Error updating status")
 		return ctrl.Result{}, err
@@ -133,15 +155,46 @@ func (r *APIManagerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 		return statusResult, nil
 	}
 
-	return ctrl.Result{}, nil
+	// Exposed tenant and product hosts are polled from 3scale
+	return ctrl.Result{RequeueAfter: result.RequeueAfter}, nil
 }
 
 func (r *APIManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1alpha1.APIManager{}).
-		Owns(&appsv1.DeploymentConfig{}).
-		Owns(&policyv1beta1.PodDisruptionBudget{}).
-		Complete(r)
+		Owns(&k8sappsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
+		Owns(&policyv1beta1.PodDisruptionBudget{})
+
+	// DeploymentConfigs are only available in OpenShift
+	deploymentConfigsAvailable, err := r.HasDeploymentConfigs()
+	if err != nil {
+		return err
+	}
+	if deploymentConfigsAvailable {
+		builder = builder.Owns(&appsv1.DeploymentConfig{})
+	}
+
+	// Ingresses and HTTPRoutes are watched when supported by the cluster
+	for _, exposure := range []struct {
+		gvk          schema.GroupVersionKind
+		kindExistsFn func() (bool, error)
+	}{
+		{component.IngressGVK, r.HasIngresses},
+		{component.HTTPRouteGVK, r.HasHTTPRoutes},
+	} {
+		kindExists, err := exposure.kindExistsFn()
+		if err != nil {
+			return err
+		}
+		if kindExists {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(exposure.gvk)
+			builder = builder.Owns(obj)
+		}
+	}
+
+	return builder.Complete(r)
 }
 
 func (r *APIManagerReconciler) updateVersionAnnotations(cr *appsv1alpha1.APIManager) error {
@@ -254,7 +307,8 @@ func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIMana
 		return result, err
 	}
 
-	return ctrl.Result{}, nil
+	exposureReconciler := operator.NewExposureReconciler(baseAPIManagerLogicReconciler)
+	return exposureReconciler.Reconcile()
 }
 
 func (r *APIManagerReconciler) reconcileSystemDatabaseLogic(cr *appsv1alpha1.APIManager, baseAPIManagerLogicReconciler *operator.BaseAPIManagerLogicReconciler) (reconcile.Result, error) {
@@ -290,56 +344,7 @@ func (r *APIManagerReconciler) reconcileSystemMySQLLogic(cr *appsv1alpha1.APIMan
 	return result, err
 }
 
-func (r *APIManagerReconciler) reconcileAPIManagerStatus(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
-	updated, err := r.setDeploymentStatus(cr)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	if updated {
-		err = r.Client().Status().Update(context.TODO(), cr)
-		if err != nil {
-			// Ignore conflicts, resource might just be outdated.
-			if errors.IsConflict(err) {
-				r.Logger().Info("Failed to update status: resource might just be outdated")
-				return ctrl.Result{Requeue: true}, nil
-			}
-
-			return ctrl.Result{}, fmt.Errorf("Failed to update API Manager deployment status: %w", err)
-		}
-	}
-
-	return ctrl.Result{}, nil
-}
-
-func (r *APIManagerReconciler) setDeploymentStatus(instance *appsv1alpha1.APIManager) (bool, error) {
-	updated := false
-
-	listOps := []client.ListOption{
-		client.InNamespace(instance.Namespace),
-	}
-	dcList := &appsv1.DeploymentConfigList{}
-	err := r.Client().List(context.TODO(), dcList, listOps...)
-	if err != nil {
-		return false, fmt.Errorf("Failed to list deployment configs: %w", err)
-	}
-	var dcs []appsv1.DeploymentConfig
-	for _, dc := range dcList.Items {
-		for _, ownerRef := range dc.GetOwnerReferences() {
-			if ownerRef.UID == instance.UID {
-				dcs = append(dcs, dc)
-				break
-			}
-		}
-	}
-	sort.Slice(dcs, func(i, j int) bool { return dcs[i].Name < dcs[j].Name })
-
-	deploymentStatus := olm.GetDeploymentConfigStatus(dcs)
-	if !reflect.DeepEqual(instance.Status.Deployments, deploymentStatus) {
-		r.Logger().Info("Deployment status will be updated")
-		instance.Status.Deployments = deploymentStatus
-		updated = true
-	}
-
-	return updated, nil
+func (r *APIManagerReconciler) reconcileAPIManagerStatus(cr *appsv1alpha1.APIManager, reconcileError error) (reconcile.Result, error) {
+	statusReconciler := NewAPIManagerStatusReconciler(r.BaseReconciler, cr, reconcileError)
+	return statusReconciler.Reconcile()
 }
@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+
+	"github.com/RHsyseng/operator-utils/pkg/olm"
+	"github.com/go-logr/logr"
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reasons of the APIManager conditions
+const (
+	apimanagerDeployingReason             common.ConditionReason = "Deploying"
+	apimanagerComponentsHealthyReason     common.ConditionReason = "ComponentsHealthy"
+	apimanagerComponentsNotHealthyReason  common.ConditionReason = "ComponentsNotHealthy"
+	apimanagerComponentsProgressingReason common.ConditionReason = "ComponentsProgressing"
+	apimanagerComponentsRolledOutReason   common.ConditionReason = "ComponentsRolledOut"
+	apimanagerComponentsUnhealthyReason   common.ConditionReason = "ComponentsUnhealthy"
+	apimanagerReconcileErrorReason        common.ConditionReason = "ReconcileError"
+	apimanagerUpgradingReason             common.ConditionReason = "Upgrading"
+	apimanagerUpgradedReason              common.ConditionReason = "Upgraded"
+)
+
+type APIManagerStatusReconciler struct {
+	*reconcilers.BaseReconciler
+	resource       *appsv1alpha1.APIManager
+	reconcileError error
+	logger         logr.Logger
+}
+
+func NewAPIManagerStatusReconciler(b *reconcilers.BaseReconciler, resource *appsv1alpha1.APIManager, reconcileError error) *APIManagerStatusReconciler {
+	return &APIManagerStatusReconciler{
+		BaseReconciler: b,
+		resource:       resource,
+		reconcileError: reconcileError,
+		logger:         b.Logger().WithValues("Status Reconciler", resource.Name),
+	}
+}
+
+func (s *APIManagerStatusReconciler) Reconcile() (reconcile.Result, error) {
+	s.logger.V(1).Info("START")
+
+	newStatus, err := s.calculateStatus()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	s.emitComponentEvents(newStatus.Components)
+
+	equalStatus := s.resource.Status.Equals(newStatus, s.logger)
+	s.logger.V(1).Info("Status", "status is different", !equalStatus)
+	s.logger.V(1).Info("Status", "generation is different", s.resource.Generation != s.resource.Status.ObservedGeneration)
+	if equalStatus && s.resource.Generation == s.resource.Status.ObservedGeneration {
+		// Steady state
+		s.logger.V(1).Info("Status was not updated")
+		return reconcile.Result{}, nil
+	}
+
+	// Save the generation number we acted on, otherwise we might wrongfully indicate
+	// that we've seen a spec update when we retry.
+	newStatus.ObservedGeneration = s.resource.Generation
+
+	s.logger.V(1).Info("Updating Status", "sequence no:", fmt.Sprintf("sequence No: %v->%v", s.resource.Status.ObservedGeneration, newStatus.ObservedGeneration))
+
+	s.resource.Status = *newStatus
+	updateErr := s.Client().Status().Update(context.TODO(), s.resource)
+	if updateErr != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(updateErr) {
+			s.logger.Info("Failed to update status: resource might just be outdated")
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("Failed to update API Manager status: %w", updateErr)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (s *APIManagerStatusReconciler) calculateStatus() (*appsv1alpha1.APIManagerStatus, error) {
+	newStatus := &appsv1alpha1.APIManagerStatus{
+		ObservedGeneration: s.resource.Status.ObservedGeneration,
+	}
+
+	if s.resource.UsesDeployments() {
+		deployments, err := s.deployments()
+		if err != nil {
+			return nil, err
+		}
+		newStatus.Deployments = olm.GetDeploymentStatus(deployments)
+		for idx := range deployments {
+			newStatus.Components = append(newStatus.Components, operator.DeploymentHealth(&deployments[idx]))
+		}
+	} else {
+		dcs, err := s.deploymentConfigs()
+		if err != nil {
+			return nil, err
+		}
+		newStatus.Deployments = olm.GetDeploymentConfigStatus(dcs)
+		for idx := range dcs {
+			newStatus.Components = append(newStatus.Components, operator.DeploymentConfigHealth(&dcs[idx]))
+		}
+	}
+
+	newStatus.Conditions = s.resource.Status.Conditions.Copy()
+	newStatus.Conditions.SetCondition(s.availableCondition(newStatus.Components))
+	newStatus.Conditions.SetCondition(s.progressingCondition(newStatus.Components))
+	newStatus.Conditions.SetCondition(s.degradedCondition(newStatus.Components))
+	newStatus.Conditions.SetCondition(s.upgradeInProgressCondition())
+
+	return newStatus, nil
+}
+
+func (s *APIManagerStatusReconciler) availableCondition(components []appsv1alpha1.APIManagerComponentStatus) common.Condition {
+	condition := common.Condition{
+		Type:   appsv1alpha1.APIManagerAvailableConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if len(components) == 0 {
+		condition.Reason = apimanagerDeployingReason
+		return condition
+	}
+
+	notHealthy := componentNames(components, func(health appsv1alpha1.ComponentHealth) bool {
+		return health != appsv1alpha1.ComponentHealthy
+	})
+	if len(notHealthy) > 0 {
+		condition.Reason = apimanagerComponentsNotHealthyReason
+		condition.Message = fmt.Sprintf("components not healthy: %s", strings.Join(notHealthy, ", "))
+		return condition
+	}
+
+	condition.Status = corev1.ConditionTrue
+	condition.Reason = apimanagerComponentsHealthyReason
+	return condition
+}
+
+func (s *APIManagerStatusReconciler) progressingCondition(components []appsv1alpha1.APIManagerComponentStatus) common.Condition {
+	condition := common.Condition{
+		Type:   appsv1alpha1.APIManagerProgressingConditionType,
+		Status: corev1.ConditionTrue,
+	}
+
+	if len(components) == 0 {
+		condition.Reason = apimanagerDeployingReason
+		return condition
+	}
+
+	progressing := componentNames(components, func(health appsv1alpha1.ComponentHealth) bool {
+		return health == appsv1alpha1.ComponentProgressing
+	})
+	if len(progressing) > 0 {
+		condition.Reason = apimanagerComponentsProgressingReason
+		condition.Message = fmt.Sprintf("components progressing: %s", strings.Join(progressing, ", "))
+		return condition
+	}
+
+	condition.Status = corev1.ConditionFalse
+	condition.Reason = apimanagerComponentsRolledOutReason
+	return condition
+}
+
+func (s *APIManagerStatusReconciler) degradedCondition(components []appsv1alpha1.APIManagerComponentStatus) common.Condition {
+	condition := common.Condition{
+		Type:   appsv1alpha1.APIManagerDegradedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	// This condition could be activated together with other conditions
+	if s.reconcileError != nil {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = apimanagerReconcileErrorReason
+		condition.Message = s.reconcileError.Error()
+		return condition
+	}
+
+	messages := []string{}
+	for _, component := range components {
+		if component.Health == appsv1alpha1.ComponentUnhealthy {
+			messages = append(messages, fmt.Sprintf("%s: %s", component.Name, component.Message))
+		}
+	}
+	if len(messages) > 0 {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = apimanagerComponentsUnhealthyReason
+		condition.Message = strings.Join(messages, "; ")
+	}
+
+	return condition
+}
+
+func (s *APIManagerStatusReconciler) upgradeInProgressCondition() common.Condition {
+	condition := common.Condition{
+		Type:   appsv1alpha1.APIManagerUpgradeInProgressConditionType,
+		Status: corev1.ConditionFalse,
+		Reason: apimanagerUpgradedReason,
+	}
+
+	// The operator version annotation is updated once the upgrade procedures finish
+	currentVersion := s.resource.Annotations[appsv1alpha1.OperatorVersionAnnotation]
+	if currentVersion != version.Version {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = apimanagerUpgradingReason
+		condition.Message = fmt.Sprintf("upgrading from operator version '%s' to '%s'", currentVersion, version.Version)
+	}
+
+	return condition
+}
+
+// emitComponentEvents emits an event when a component becomes unhealthy
+// and when it recovers
+func (s *APIManagerStatusReconciler) emitComponentEvents(components []appsv1alpha1.APIManagerComponentStatus) {
+	previousHealth := map[string]appsv1alpha1.ComponentHealth{}
+	for _, component := range s.resource.Status.Components {
+		previousHealth[component.Name] = component.Health
+	}
+
+	for _, component := range components {
+		previous := previousHealth[component.Name]
+		switch {
+		case component.Health == appsv1alpha1.ComponentUnhealthy && previous != appsv1alpha1.ComponentUnhealthy:
+			s.EventRecorder().Eventf(s.resource, corev1.EventTypeWarning, "ComponentUnhealthy", "%s: %s", component.Name, component.Message)
+		case component.Health == appsv1alpha1.ComponentHealthy && previous == appsv1alpha1.ComponentUnhealthy:
+			s.EventRecorder().Eventf(s.resource, corev1.EventTypeNormal, "ComponentHealthy", "%s: %s", component.Name, component.Message)
+		}
+	}
+}
+
+func (s *APIManagerStatusReconciler) deploymentConfigs() ([]appsv1.DeploymentConfig, error) {
+	listOps := []client.ListOption{
+		client.InNamespace(s.resource.Namespace),
+	}
+	dcList := &appsv1.DeploymentConfigList{}
+	err := s.Client().List(context.TODO(), dcList, listOps...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list deployment configs: %w", err)
+	}
+	var dcs []appsv1.DeploymentConfig
+	for _, dc := range dcList.Items {
+		for _, ownerRef := range dc.GetOwnerReferences() {
+			if ownerRef.UID == s.resource.UID {
+				dcs = append(dcs, dc)
+				break
+			}
+		}
+	}
+	sort.Slice(dcs, func(i, j int) bool { return dcs[i].Name < dcs[j].Name })
+
+	return dcs, nil
+}
+
+func (s *APIManagerStatusReconciler) deployments() ([]k8sappsv1.Deployment, error) {
+	listOps := []client.ListOption{
+		client.InNamespace(s.resource.Namespace),
+	}
+	deploymentList := &k8sappsv1.DeploymentList{}
+	err := s.Client().List(context.TODO(), deploymentList, listOps...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list deployments: %w", err)
+	}
+	var deployments []k8sappsv1.Deployment
+	for _, deployment := range deploymentList.Items {
+		for _, ownerRef := range deployment.GetOwnerReferences() {
+			if ownerRef.UID == s.resource.UID {
+				deployments = append(deployments, deployment)
+				break
+			}
+		}
+	}
+	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Name < deployments[j].Name })
+
+	return deployments, nil
+}
+
+func componentNames(components []appsv1alpha1.APIManagerComponentStatus, filter func(appsv1alpha1.ComponentHealth) bool) []string {
+	names := []string{}
+	for _, component := range components {
+		if filter(component.Health) {
+			names = append(names, component.Name)
+		}
+	}
+	return names
+}
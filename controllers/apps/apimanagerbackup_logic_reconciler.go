@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"fmt"
 	"time"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
@@ -34,7 +36,7 @@ func NewAPIManagerBackupLogicReconciler(b *reconcilers.BaseReconciler, cr *appsv
 		cr:             cr,
 	}
 
-	if cr.BackupCompleted() {
+	if cr.BackupCompleted() || cr.BackupFailed() {
 		return res, nil
 	}
 
@@ -68,6 +70,11 @@ func (r *APIManagerBackupLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.BackupFailed() {
+		r.Logger().Info("Backup failed. End of reconciliation", "reason", *r.cr.Status.FailureMessage)
+		return reconcile.Result{}, nil
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling backup steps")
 		result, err := r.reconcileMainSteps()
@@ -97,7 +104,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileMainSteps() (reconcile.Result
 		return result, err
 	}
 
-	result, err = r.reconcileBackupInPVCDestination()
+	result, err = r.reconcileBackupInDestination()
 	if result.Requeue || err != nil {
 		return result, err
 	}
@@ -138,7 +145,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileSetMainStepsCompleted() (reco
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (reconcile.Result, error) {
+func (r *APIManagerBackupLogicReconciler) reconcileBackupInDestination() (reconcile.Result, error) {
 	var res reconcile.Result
 	var err error
 
@@ -152,6 +159,11 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (rec
 		return res, err
 	}
 
+	res, err = r.reconcileBackupDestinationS3Status()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileBackupSecretsAndConfigMapsToPVCJob()
 	if res.Requeue || err != nil {
 		return res, err
@@ -167,6 +179,11 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (rec
 		return res, err
 	}
 
+	res, err = r.reconcileBackupDatabasesJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	return res, err
 }
 
@@ -216,6 +233,11 @@ func (r *APIManagerBackupLogicReconciler) reconcileJob(desired *batchv1.Job) (re
 	// Jobs ownerReference or labels nor annotations not reconciled
 	// Jobs are one-shot so there's not much point on making updates to them
 
+	if failedCondition := jobFailedCondition(existing); failedCondition != nil {
+		r.Logger().Info("Job failed", "Job Name", desired.Name, "Reason", failedCondition.Reason)
+		return r.reconcileBackupFailure(fmt.Sprintf("Job '%s' failed: %s", desired.Name, failedCondition.Message))
+	}
+
 	if existing.Status.Succeeded != *desired.Spec.Completions {
 		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
@@ -252,6 +274,15 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStoragePVCToP
 	return r.reconcileJob(desired)
 }
 
+func (r *APIManagerBackupLogicReconciler) reconcileBackupDatabasesJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupDatabasesJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
 func (r *APIManagerBackupLogicReconciler) reconcileBackupCompletion() (reconcile.Result, error) {
 	if !r.cr.BackupCompleted() {
 		// TODO make this more robust only setting it in case all substeps have been completed?
@@ -264,10 +295,40 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupCompletion() (reconcile
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		r.observeBackup(metrics.BackupResultSucceeded, completionTimeUTC.Time)
 	}
 	return reconcile.Result{}, nil
 }
 
+// reconcileBackupFailure marks the backup as failed. Failed backups are not retried
+func (r *APIManagerBackupLogicReconciler) reconcileBackupFailure(message string) (reconcile.Result, error) {
+	backupFailed := true
+	r.cr.Status.Failed = &backupFailed
+	r.cr.Status.FailureMessage = &message
+	err := r.UpdateResourceStatus(r.cr)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.observeBackup(metrics.BackupResultFailed, apimanagerbackupClock.Now().UTC())
+	return reconcile.Result{Requeue: true}, nil
+}
+
+func (r *APIManagerBackupLogicReconciler) observeBackup(result string, completion time.Time) {
+	if r.cr.Status.StartTime != nil {
+		metrics.ObserveBackup(metrics.BackupKindBackup, result, r.cr.Status.StartTime.Time, completion)
+	}
+}
+
+func jobFailedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for idx := range job.Status.Conditions {
+		condition := &job.Status.Conditions[idx]
+		if condition.Type == batchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
 func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerSourceStatusField() (reconcile.Result, error) {
 	apiManager := r.apiManagerBackup.APIManager()
 
@@ -315,6 +376,20 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationPVCStatus()
 	return reconcile.Result{}, nil
 }
 
+func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationS3Status() (reconcile.Result, error) {
+	if r.cr.Spec.BackupDestination.S3 == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if r.cr.Status.BackupS3Location == nil {
+		s3Location := r.apiManagerBackup.BackupDestinationS3Location()
+		r.cr.Status.BackupS3Location = &s3Location
+		err := r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true}, err
+	}
+	return reconcile.Result{}, nil
+}
+
 // Delete all K8s jobs created during the backup. The reason for this is that
 // some PVCs are referenced in the K8s Jobs and those PVCs cannot be deleted
 // while some pods reference them, even if in state Completed. By deleting the
@@ -324,10 +399,14 @@ func (r *APIManagerBackupLogicReconciler) reconcileJobsCleanup() (reconcile.Resu
 		r.apiManagerBackup.BackupSecretsAndConfigMapsToPVCJob(),
 		r.apiManagerBackup.BackupAPIManagerCustomResourceToPVCJob(),
 		r.apiManagerBackup.BackupSystemFileStoragePVCToPVCJob(),
+		r.apiManagerBackup.BackupDatabasesJob(),
 	}
 
 	existingJobFound := false
 	for _, job := range jobsToDelete {
+		if job == nil {
+			continue
+		}
 		existingJob := &batchv1.Job{}
 		err := r.GetResource(types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existingJob)
 		if err != nil && !errors.IsNotFound(err) {
@@ -0,0 +1,369 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclock "k8s.io/apimachinery/pkg/util/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+var apimanagerbackupscheduleClock kubeclock.Clock = &kubeclock.RealClock{}
+
+// APIManagerBackupScheduleReconciler reconciles a APIManagerBackupSchedule object
+type APIManagerBackupScheduleReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that APIManagerBackupScheduleReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &APIManagerBackupScheduleReconciler{}
+
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *APIManagerBackupScheduleReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("apimanagerbackupschedule", req.NamespacedName)
+	logger.Info("Reconciling APIManagerBackupSchedule")
+
+	instance := &appsv1alpha1.APIManagerBackupSchedule{}
+	err := r.Client().Get(r.Context(), req.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("APIManagerBackupSchedule not found")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error getting APIManagerBackupSchedule")
+		return ctrl.Result{}, err
+	}
+
+	backups, err := r.scheduledBackups(instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := apimanagerbackupscheduleClock.Now()
+
+	backups, err = r.reconcileRetention(instance, backups, now, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	newStatus := calculateBackupScheduleStatus(instance, backups)
+
+	result, err := r.reconcileSchedule(instance, newStatus, now, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !reflect.DeepEqual(instance.Status, *newStatus) {
+		instance.Status = *newStatus
+		err = r.UpdateResourceStatus(instance)
+		if err != nil {
+			if errors.IsConflict(err) {
+				logger.Info("Failed to update status: resource might just be outdated")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("Failed to update status: %w", err)
+		}
+	}
+
+	logger.Info("Reconciliation finished", "requeueAfter", result.RequeueAfter)
+	return result, nil
+}
+
+func (r *APIManagerBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.APIManagerBackupSchedule{}).
+		Owns(&appsv1alpha1.APIManagerBackup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// scheduledBackups returns the APIManagerBackups created by the schedule
+func (r *APIManagerBackupScheduleReconciler) scheduledBackups(schedule *appsv1alpha1.APIManagerBackupSchedule) ([]appsv1alpha1.APIManagerBackup, error) {
+	backupList := &appsv1alpha1.APIManagerBackupList{}
+	err := r.Client().List(r.Context(), backupList,
+		client.InNamespace(schedule.Namespace),
+		client.MatchingLabels{appsv1alpha1.APIManagerBackupScheduleLabelKey: schedule.Name},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []appsv1alpha1.APIManagerBackup{}
+	for _, item := range backupList.Items {
+		if metav1.IsControlledBy(&item, schedule) {
+			backups = append(backups, item)
+		}
+	}
+
+	return backups, nil
+}
+
+// reconcileRetention deletes the backups exceeding the retention policy along with their
+// backup data, either the PersistentVolumeClaims or the data in the S3 backup destination.
+// Backups stored in S3 are deleted once their data prune job succeeds. Returns the remaining backups
+func (r *APIManagerBackupScheduleReconciler) reconcileRetention(schedule *appsv1alpha1.APIManagerBackupSchedule, backups []appsv1alpha1.APIManagerBackup, now time.Time, logger logr.Logger) ([]appsv1alpha1.APIManagerBackup, error) {
+	prune := backup.BackupsToPrune(backups, schedule.Spec.Retention, now)
+	if len(prune) == 0 {
+		return backups, nil
+	}
+
+	pruned := map[string]bool{}
+	for idx := range prune {
+		apiManagerBackup := &prune[idx]
+
+		dataPruned, err := r.reconcileS3BackupDataPrune(schedule, apiManagerBackup, logger)
+		if err != nil {
+			return nil, err
+		}
+		if !dataPruned {
+			continue
+		}
+
+		if apiManagerBackup.Status.BackupPersistentVolumeClaimName != nil {
+			pvc := &v1.PersistentVolumeClaim{}
+			err := r.GetResource(types.NamespacedName{Name: *apiManagerBackup.Status.BackupPersistentVolumeClaimName, Namespace: apiManagerBackup.Namespace}, pvc)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+			if err == nil {
+				logger.Info("Deleting backup data PersistentVolumeClaim", "backup", apiManagerBackup.Name, "pvc", pvc.Name)
+				err = r.DeleteResource(pvc)
+				if err != nil && !errors.IsNotFound(err) {
+					return nil, err
+				}
+			}
+		}
+
+		logger.Info("Deleting backup", "backup", apiManagerBackup.Name)
+		err = r.DeleteResource(apiManagerBackup, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		pruned[apiManagerBackup.Name] = true
+	}
+
+	if len(pruned) > 0 {
+		r.EventRecorder().Eventf(schedule, v1.EventTypeNormal, "BackupsPruned", "%d backup(s) pruned by the retention policy", len(pruned))
+	}
+
+	remaining := []appsv1alpha1.APIManagerBackup{}
+	for _, item := range backups {
+		if !pruned[item.Name] {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return remaining, nil
+}
+
+// reconcileS3BackupDataPrune runs the job removing the backup data from the S3 backup destination.
+// Returns true when there is no backup data in S3 or it has been removed.
+// Failed jobs are not retried, the backup is kept until the failed job is deleted
+func (r *APIManagerBackupScheduleReconciler) reconcileS3BackupDataPrune(schedule *appsv1alpha1.APIManagerBackupSchedule, apiManagerBackup *appsv1alpha1.APIManagerBackup, logger logr.Logger) (bool, error) {
+	desired, err := backup.S3BackupDataPruneJob(apiManagerBackup, helper.GetEnvVar("S3_CLI_IMAGE", component.S3CLIImageURL()))
+	if err != nil {
+		return false, err
+	}
+	if desired == nil {
+		return true, nil
+	}
+
+	existing := &batchv1.Job{}
+	err = r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if errors.IsNotFound(err) {
+		err = controllerutil.SetControllerReference(schedule, desired, r.Scheme())
+		if err != nil {
+			return false, err
+		}
+
+		logger.Info("Creating backup data prune job", "backup", apiManagerBackup.Name, "job", desired.Name)
+		err = r.CreateResource(desired)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	if failedCondition := jobFailedCondition(existing); failedCondition != nil {
+		logger.Info("Backup data prune job failed, keeping the backup", "backup", apiManagerBackup.Name, "job", existing.Name, "reason", failedCondition.Reason)
+		return false, nil
+	}
+
+	if existing.Status.Succeeded == 0 {
+		logger.Info("Backup data prune job has still not finished", "backup", apiManagerBackup.Name, "job", existing.Name)
+		return false, nil
+	}
+
+	logger.Info("Deleting backup data prune job", "backup", apiManagerBackup.Name, "job", existing.Name)
+	err = r.DeleteResource(existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// reconcileSchedule creates the backup for the most recent missed schedule time, unless
+// a backup is still in progress. Returns when the next schedule time has to be checked
+func (r *APIManagerBackupScheduleReconciler) reconcileSchedule(schedule *appsv1alpha1.APIManagerBackupSchedule, newStatus *appsv1alpha1.APIManagerBackupScheduleStatus, now time.Time, logger logr.Logger) (ctrl.Result, error) {
+	cronSchedule, err := helper.ParseCronSchedule(schedule.Spec.Schedule)
+	if err != nil {
+		scheduleError := fmt.Sprintf("Invalid schedule: %s", err)
+		if schedule.Status.ScheduleError == nil || *schedule.Status.ScheduleError != scheduleError {
+			r.EventRecorder().Eventf(schedule, v1.EventTypeWarning, "InvalidSchedule", "%s", scheduleError)
+		}
+		newStatus.ScheduleError = &scheduleError
+		// Spec has to be changed
+		return ctrl.Result{}, nil
+	}
+	newStatus.ScheduleError = nil
+
+	if schedule.IsSuspended() {
+		logger.Info("Schedule suspended")
+		return ctrl.Result{}, nil
+	}
+
+	// Schedule times are evaluated in UTC
+	now = now.UTC()
+	lastScheduleTime := schedule.CreationTimestamp.Time.UTC()
+	if schedule.Status.LastScheduleTime != nil {
+		lastScheduleTime = schedule.Status.LastScheduleTime.Time.UTC()
+	}
+
+	// Only the most recent missed schedule time is run
+	var missedTime time.Time
+	for t := cronSchedule.Next(lastScheduleTime); !t.IsZero() && !t.After(now); t = cronSchedule.Next(t) {
+		missedTime = t
+	}
+
+	result := ctrl.Result{}
+	if nextTime := cronSchedule.Next(now); !nextTime.IsZero() {
+		result.RequeueAfter = nextTime.Sub(now)
+	}
+
+	if missedTime.IsZero() {
+		return result, nil
+	}
+
+	newStatus.LastScheduleTime = &metav1.Time{Time: missedTime}
+
+	apiManagerBackup := scheduledBackup(schedule, missedTime)
+
+	if newStatus.ActiveBackup != nil && *newStatus.ActiveBackup == apiManagerBackup.Name {
+		// Created, but the schedule time was not recorded in status
+		return result, nil
+	}
+
+	if newStatus.ActiveBackup != nil {
+		logger.Info("Backup still in progress, skipping scheduled backup", "active", *newStatus.ActiveBackup, "scheduleTime", missedTime)
+		r.EventRecorder().Eventf(schedule, v1.EventTypeWarning, "BackupSkipped",
+			"Backup scheduled at %s skipped: backup %s is still in progress", missedTime.Format(time.RFC3339), *newStatus.ActiveBackup)
+		return result, nil
+	}
+
+	err = controllerutil.SetControllerReference(schedule, apiManagerBackup, r.Scheme())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Creating scheduled backup", "backup", apiManagerBackup.Name, "scheduleTime", missedTime)
+	err = r.CreateResource(apiManagerBackup)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return ctrl.Result{}, err
+	}
+	r.EventRecorder().Eventf(schedule, v1.EventTypeNormal, "BackupCreated", "Created backup %s", apiManagerBackup.Name)
+
+	newStatus.ActiveBackup = &apiManagerBackup.Name
+
+	return result, nil
+}
+
+// scheduledBackup returns the backup of the given schedule time.
+// The name is deterministic so the same schedule time is never backed up twice
+func scheduledBackup(schedule *appsv1alpha1.APIManagerBackupSchedule, scheduleTime time.Time) *appsv1alpha1.APIManagerBackup {
+	return &appsv1alpha1.APIManagerBackup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "APIManagerBackup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", schedule.Name, scheduleTime.UTC().Format("200601021504")),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				appsv1alpha1.APIManagerBackupScheduleLabelKey: schedule.Name,
+			},
+		},
+		Spec: *schedule.Spec.BackupTemplate.DeepCopy(),
+	}
+}
+
+// calculateBackupScheduleStatus reports the backup in progress and
+// the last completed and failed backups
+func calculateBackupScheduleStatus(schedule *appsv1alpha1.APIManagerBackupSchedule, backups []appsv1alpha1.APIManagerBackup) *appsv1alpha1.APIManagerBackupScheduleStatus {
+	newStatus := schedule.Status.DeepCopy()
+	newStatus.ActiveBackup = nil
+
+	// Oldest first, so the most recent backups are reported
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreationTimestamp.Before(&backups[j].CreationTimestamp)
+	})
+
+	for idx := range backups {
+		item := &backups[idx]
+		switch {
+		case item.BackupCompleted():
+			name := item.Name
+			newStatus.LastSuccessfulBackup = &name
+			newStatus.LastSuccessfulTime = item.Status.CompletionTime
+		case item.BackupFailed():
+			name := item.Name
+			newStatus.LastFailedBackup = &name
+			newStatus.LastFailureMessage = item.Status.FailureMessage
+		default:
+			name := item.Name
+			newStatus.ActiveBackup = &name
+		}
+	}
+
+	return newStatus
+}
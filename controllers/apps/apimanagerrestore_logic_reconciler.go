@@ -10,6 +10,7 @@ import (
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/pkg/restore"
 	"github.com/go-logr/logr"
@@ -53,6 +54,11 @@ func (r *APIManagerRestoreLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.RestoreFailed() {
+		r.Logger().Info("Restore failed. End of reconciliation", "reason", *r.cr.Status.FailureMessage)
+		return reconcile.Result{}, nil
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling restore steps")
 		result, err := r.reconcileMainSteps()
@@ -78,7 +84,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileMainSteps() (reconcile.Resul
 		return result, err
 	}
 
-	result, err = r.reconcileRestoreFromPVCSource()
+	result, err = r.reconcileRestoreFromSource()
 	if result.Requeue || err != nil {
 		return result, err
 	}
@@ -129,7 +135,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileStartTimeField() (reconcile.
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reconcile.Result, error) {
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromSource() (reconcile.Result, error) {
 	var res reconcile.Result
 	var err error
 
@@ -153,6 +159,16 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
+	res, err = r.reconcileRestoreDatabases()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileScaleUpDatabaseClients()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileWaitForAPIManagerReady()
 	if res.Requeue || err != nil {
 		return res, err
@@ -205,6 +221,11 @@ func (r *APIManagerRestoreLogicReconciler) reconcileJob(desired *batchv1.Job) (r
 	// Jobs ownerReference or labels nor annotations not reconciled
 	// Jobs are one-shot so there's not much point on making updates to them
 
+	if failedCondition := jobFailedCondition(existing); failedCondition != nil {
+		r.Logger().Info("Job failed", "Job Name", desired.Name, "Reason", failedCondition.Reason)
+		return r.reconcileRestoreFailure(fmt.Sprintf("Job '%s' failed: %s", desired.Name, failedCondition.Message))
+	}
+
 	if existing.Status.Succeeded != *desired.Spec.Completions {
 		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
@@ -298,10 +319,30 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreCompletion() (reconci
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		r.observeRestore(metrics.BackupResultSucceeded, completionTimeUTC.Time)
 	}
 	return reconcile.Result{}, nil
 }
 
+// reconcileRestoreFailure marks the restore as failed. Failed restores are not retried
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFailure(message string) (reconcile.Result, error) {
+	restoreFailed := true
+	r.cr.Status.Failed = &restoreFailed
+	r.cr.Status.FailureMessage = &message
+	err := r.UpdateResourceStatus(r.cr)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.observeRestore(metrics.BackupResultFailed, apimanagerbackupClock.Now().UTC())
+	return reconcile.Result{Requeue: true}, nil
+}
+
+func (r *APIManagerRestoreLogicReconciler) observeRestore(result string, completion time.Time) {
+	if r.cr.Status.StartTime != nil {
+		metrics.ObserveBackup(metrics.BackupKindRestore, result, r.cr.Status.StartTime.Time, completion)
+	}
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileRestoreAPIManagerInSharedSecret() (reconcile.Result, error) {
 	desired := r.apiManagerRestore.CreateAPIManagerSharedSecretJob()
 	if desired == nil {
@@ -409,11 +450,87 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreAPIManager() (reconci
 		return reconcile.Result{}, err
 	}
 
+	// Internal databases are loaded before the components
+	// using them are started
+	if !apimanager.IsExternalDatabaseEnabled() {
+		err = restore.ScaleDownDatabaseClients(apimanager)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	existing := &appsv1alpha1.APIManager{}
 	err = r.ReconcileResource(existing, apimanager, reconcilers.CreateOnlyMutator)
 	return reconcile.Result{}, err
 }
 
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreDatabases() (reconcile.Result, error) {
+	desired := r.apiManagerRestore.RestoreDatabasesJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	existingAPIManager := &appsv1alpha1.APIManager{}
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, existingAPIManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	databases := backup.InternalDatabases(existingAPIManager)
+	if len(databases) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	readyDeployments := map[string]bool{}
+	for _, name := range existingAPIManager.Status.Deployments.Ready {
+		readyDeployments[name] = true
+	}
+	for _, database := range databases {
+		if !readyDeployments[database.Name] {
+			r.Logger().Info("APIManager database not ready. Waiting", "APIManager", existingAPIManager.Name, "database", database.Name)
+			return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+		}
+	}
+
+	return r.reconcileJob(desired)
+}
+
+func (r *APIManagerRestoreLogicReconciler) reconcileScaleUpDatabaseClients() (reconcile.Result, error) {
+	existingAPIManager := &appsv1alpha1.APIManager{}
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, existingAPIManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if existingAPIManager.IsExternalDatabaseEnabled() {
+		return reconcile.Result{}, nil
+	}
+
+	// The shared secret is deleted at the end of the restore
+	// steps, when the components have already been scaled up
+	secret, err := r.sharedBackupSecret()
+	if err != nil || secret == nil {
+		return reconcile.Result{}, err
+	}
+
+	backedUpAPIManager, err := r.apiManagerFromSharedBackupSecret()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	changed, err := restore.ScaleUpDatabaseClients(existingAPIManager, backedUpAPIManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if changed {
+		r.Logger().Info("Scaling up APIManager components using the restored databases", "APIManager", existingAPIManager.Name)
+		err = r.UpdateResource(existingAPIManager)
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileAPIManagerBackupSharedInSecretCleanup() (reconcile.Result, error) {
 	desiredSecret, err := r.sharedBackupSecret()
 	existingSecret := &v1.Secret{}
@@ -452,7 +569,6 @@ func (r *APIManagerRestoreLogicReconciler) reconcileWaitForAPIManagerReady() (re
 		return reconcile.Result{}, err
 	}
 
-	// External databases scenario assumed
 	expectedDeploymentNames := []string{
 		"apicast-production",
 		"apicast-staging",
@@ -461,12 +577,17 @@ func (r *APIManagerRestoreLogicReconciler) reconcileWaitForAPIManagerReady() (re
 		"backend-cron",
 		"zync",
 		"zync-que",
-		"zync-database",
 		"system-app",
 		"system-sphinx",
 		"system-sidekiq",
 		"system-memcache",
 	}
+	if existingAPIManager.IsExternalDatabaseEnabled() && !existingAPIManager.IsZyncExternalDatabaseEnabled() {
+		expectedDeploymentNames = append(expectedDeploymentNames, "zync-database")
+	}
+	for _, database := range backup.InternalDatabases(existingAPIManager) {
+		expectedDeploymentNames = append(expectedDeploymentNames, database.Name)
+	}
 
 	existingReadyDeployments := existingAPIManager.Status.Deployments.Ready
 	sort.Slice(expectedDeploymentNames, func(i, j int) bool { return expectedDeploymentNames[i] < expectedDeploymentNames[j] })
@@ -499,6 +620,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileJobsCleanup() (reconcile.Res
 		r.apiManagerRestore.RestoreSystemFileStoragePVCFromPVCJob(),
 		r.apiManagerRestore.CreateAPIManagerSharedSecretJob(),
 		r.apiManagerRestore.ZyncResyncDomainsJob(),
+		r.apiManagerRestore.RestoreDatabasesJob(),
 	}
 
 	existingJobFound := false
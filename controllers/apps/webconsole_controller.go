@@ -75,6 +75,17 @@ func (r *WebConsoleReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error)
 }
 
 func (r *WebConsoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// The master console link points to the master Route.
+	// Without Routes, like in non OpenShift clusters, there is nothing to watch
+	routesAvailable, err := r.HasRoutes()
+	if err != nil {
+		return err
+	}
+	if !routesAvailable {
+		r.Logger().Info("Route API not supported in the cluster. Console links are not reconciled")
+		return nil
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&routev1.Route{}).
 		Complete(r)
@@ -0,0 +1,505 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// ApplicationReconciler reconciles an Application object
+type ApplicationReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that ApplicationReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ApplicationReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=applications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=applications/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=applications/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,namespace=placeholder,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *ApplicationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	_ = context.Background()
+	reqLogger := r.Logger().WithValues("application", req.NamespacedName)
+	reqLogger.Info("Reconcile Application", "Operator version", version.Version)
+
+	// Fetch the Application instance
+	application := &capabilitiesv1beta1.Application{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, application)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(application, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	if application.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(application, capabilitiesv1beta1.ApplicationFinalizer) {
+			return r.finalize(application)
+		}
+
+		// Ignore deleted Applications, this can happen when foregroundDeletion is enabled
+		// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(application, capabilitiesv1beta1.ApplicationFinalizer) {
+		controllerutil.AddFinalizer(application, capabilitiesv1beta1.ApplicationFinalizer)
+		err := r.UpdateResource(application)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed adding application finalizer: %w", err)
+		}
+
+		reqLogger.Info("resource finalizer added. Requeueing.")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcile(application)
+	metrics.ObserveReconcile(capabilitiesv1beta1.ApplicationKind, reconcileErr, false)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed to sync application: %v. Failed to update application status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("Failed to update application status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			// On Validation error, no need to retry as spec is not valid and needs to be changed
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(application, corev1.EventTypeWarning, "Invalid Application Spec", "%v", reconcileErr)
+			return ctrl.Result{}, nil
+		}
+
+		if helper.IsOrphanSpecError(reconcileErr) {
+			// On Orphan spec error, retry
+			reqLogger.Info("ERROR", "spec orphan error", reconcileErr)
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(application, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+	}
+
+	reqLogger.Info("END", "error", reconcileErr)
+	return ctrl.Result{}, reconcileErr
+}
+
+func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.Application{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
+
+func (r *ApplicationReconciler) reconcile(resource *capabilitiesv1beta1.Application) (*ApplicationStatusReconciler, error) {
+	logger := r.Logger().WithValues("application", resource.Name)
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), resource.Namespace, resource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return NewApplicationStatusReconciler(r.BaseReconciler, resource, nil, nil, "", err), err
+	}
+
+	accountID, productID, err := r.checkExternalRefs(resource, providerAccount)
+	if err != nil {
+		return NewApplicationStatusReconciler(r.BaseReconciler, resource, nil, nil, providerAccount.AdminURLStr, err), err
+	}
+
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount)
+	if err != nil {
+		return NewApplicationStatusReconciler(r.BaseReconciler, resource, nil, nil, providerAccount.AdminURLStr, err), err
+	}
+
+	planID, err := r.findApplicationPlan(resource, productID, threescaleAPIClient)
+	if err != nil {
+		return NewApplicationStatusReconciler(r.BaseReconciler, resource, nil, nil, providerAccount.AdminURLStr, err), err
+	}
+
+	developerAPIClient, err := controllerhelper.NewDeveloperAPIClient(providerAccount)
+	if err != nil {
+		return NewApplicationStatusReconciler(r.BaseReconciler, resource, nil, nil, providerAccount.AdminURLStr, err), err
+	}
+
+	applicationItem, err := r.syncApplication(resource, accountID, productID, planID, developerAPIClient)
+	if err != nil {
+		return NewApplicationStatusReconciler(r.BaseReconciler, resource, applicationItem, &accountID, providerAccount.AdminURLStr, err), err
+	}
+
+	err = r.reconcileCredentialsSecret(resource, accountID, applicationItem, developerAPIClient)
+	return NewApplicationStatusReconciler(r.BaseReconciler, resource, applicationItem, &accountID, providerAccount.AdminURLStr, err), err
+}
+
+// checkExternalRefs makes sure the referenced developer account and product
+// are synchronized with the same 3scale provider account the application belongs to.
+// Returns the 3scale developer account ID and product ID
+func (r *ApplicationReconciler) checkExternalRefs(resource *capabilitiesv1beta1.Application, providerAccount *controllerhelper.ProviderAccount) (int64, int64, error) {
+	specFldPath := field.NewPath("spec")
+
+	accountFldPath := specFldPath.Child("accountCR")
+	developerAccount := &capabilitiesv1beta1.DeveloperAccount{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: resource.Spec.AccountCR.Name, Namespace: resource.Namespace}, developerAccount)
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, 0, err
+	}
+	if errors.IsNotFound(err) {
+		return 0, 0, &helper.SpecFieldError{
+			ErrorType:      helper.OrphanError,
+			FieldErrorList: field.ErrorList{field.NotFound(accountFldPath, resource.Spec.AccountCR.Name)},
+		}
+	}
+
+	if developerAccount.Status.ID == nil {
+		return 0, 0, &helper.SpecFieldError{
+			ErrorType:      helper.OrphanError,
+			FieldErrorList: field.ErrorList{field.Invalid(accountFldPath, resource.Spec.AccountCR.Name, "developer account not synchronized")},
+		}
+	}
+
+	if developerAccount.Status.ProviderAccountHost != providerAccount.AdminURLStr {
+		return 0, 0, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: field.ErrorList{field.Invalid(accountFldPath, resource.Spec.AccountCR.Name, "developer account belongs to a different provider account")},
+		}
+	}
+
+	productFldPath := specFldPath.Child("productCR")
+	product := &capabilitiesv1beta1.Product{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: resource.Spec.ProductCR.Name, Namespace: resource.Namespace}, product)
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, 0, err
+	}
+	if errors.IsNotFound(err) {
+		return 0, 0, &helper.SpecFieldError{
+			ErrorType:      helper.OrphanError,
+			FieldErrorList: field.ErrorList{field.NotFound(productFldPath, resource.Spec.ProductCR.Name)},
+		}
+	}
+
+	if product.Status.ID == nil {
+		return 0, 0, &helper.SpecFieldError{
+			ErrorType:      helper.OrphanError,
+			FieldErrorList: field.ErrorList{field.Invalid(productFldPath, resource.Spec.ProductCR.Name, "product not synchronized")},
+		}
+	}
+
+	if product.Status.ProviderAccountHost != providerAccount.AdminURLStr {
+		return 0, 0, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: field.ErrorList{field.Invalid(productFldPath, resource.Spec.ProductCR.Name, "product belongs to a different provider account")},
+		}
+	}
+
+	return *developerAccount.Status.ID, *product.Status.ID, nil
+}
+
+func (r *ApplicationReconciler) findApplicationPlan(resource *capabilitiesv1beta1.Application, productID int64, threescaleAPIClient *threescaleapi.ThreeScaleClient) (int64, error) {
+	planList, err := threescaleAPIClient.ListApplicationPlansByProduct(productID)
+	if err != nil {
+		return 0, fmt.Errorf("product [%d] get plans: %w", productID, err)
+	}
+
+	for _, plan := range planList.Plans {
+		if plan.Element.SystemName == resource.Spec.ApplicationPlanName {
+			return plan.Element.ID, nil
+		}
+	}
+
+	// The plan may still be pending to be created by the product controller
+	return 0, &helper.SpecFieldError{
+		ErrorType: helper.OrphanError,
+		FieldErrorList: field.ErrorList{
+			field.NotFound(field.NewPath("spec").Child("applicationPlanName"), resource.Spec.ApplicationPlanName),
+		},
+	}
+}
+
+// syncApplication makes sure the 3scale application exists, otherwise it will be created,
+// and that its attributes match the spec
+func (r *ApplicationReconciler) syncApplication(resource *capabilitiesv1beta1.Application, accountID, productID, planID int64, developerAPIClient *controllerhelper.DeveloperAPIClient) (*controllerhelper.ApplicationItem, error) {
+	logger := r.Logger().WithValues("application", resource.Name)
+
+	var applicationItem *controllerhelper.ApplicationItem
+	if resource.Status.ID != nil && resource.Status.AccountID != nil {
+		item, err := developerAPIClient.Application(*resource.Status.AccountID, *resource.Status.ID)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return nil, fmt.Errorf("application [%s] read request: %w", resource.Spec.Name, err)
+		}
+		applicationItem = item
+	}
+
+	// Applications cannot be moved to another developer account or product.
+	// Replace the existing application with a new one.
+	if applicationItem != nil && (*resource.Status.AccountID != accountID || applicationItem.ServiceID != productID) {
+		logger.Info("Replacing application", "ApplicationID", applicationItem.ID)
+		err := developerAPIClient.DeleteApplication(*resource.Status.AccountID, applicationItem.ID)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return nil, fmt.Errorf("application [%s] delete request: %w", resource.Spec.Name, err)
+		}
+		applicationItem = nil
+	}
+
+	// The application may have been created by a previous reconcile that failed to store its ID in the status.
+	// Reuse it instead of creating a duplicate.
+	if applicationItem == nil {
+		item, err := findApplication(resource.Spec.Name, accountID, productID, developerAPIClient)
+		if err != nil {
+			return nil, fmt.Errorf("application [%s] list request: %w", resource.Spec.Name, err)
+		}
+		if item != nil {
+			logger.Info("Found existing application", "ApplicationID", item.ID)
+		}
+		applicationItem = item
+	}
+
+	if applicationItem == nil {
+		params := threescaleapi.Params{
+			"plan_id":     strconv.FormatInt(planID, 10),
+			"name":        resource.Spec.Name,
+			"description": resource.Description(),
+		}
+
+		logger.Info("Creating a new application", "Name", resource.Spec.Name, "AccountID", accountID)
+		item, err := developerAPIClient.CreateApplication(accountID, params)
+		if err != nil {
+			return nil, fmt.Errorf("application [%s] create request: %w", resource.Spec.Name, err)
+		}
+
+		return item, nil
+	}
+
+	if applicationItem.Name != resource.Spec.Name || applicationItem.Description != resource.Description() {
+		logger.Info("Syncing application", "ApplicationID", applicationItem.ID)
+		params := threescaleapi.Params{
+			"name":        resource.Spec.Name,
+			"description": resource.Description(),
+		}
+		item, err := developerAPIClient.UpdateApplication(accountID, applicationItem.ID, params)
+		if err != nil {
+			return applicationItem, fmt.Errorf("application [%s] update request: %w", resource.Spec.Name, err)
+		}
+		applicationItem = item
+	}
+
+	if applicationItem.PlanID != planID {
+		logger.Info("Changing application plan", "ApplicationID", applicationItem.ID, "PlanID", planID)
+		item, err := developerAPIClient.ChangeApplicationPlan(accountID, applicationItem.ID, planID)
+		if err != nil {
+			return applicationItem, fmt.Errorf("application [%s] change plan request: %w", resource.Spec.Name, err)
+		}
+		applicationItem = item
+	}
+
+	return applicationItem, nil
+}
+
+// findApplication returns the application of the developer account with the given name and product, if any
+func findApplication(name string, accountID, productID int64, developerAPIClient *controllerhelper.DeveloperAPIClient) (*controllerhelper.ApplicationItem, error) {
+	items, err := developerAPIClient.ListApplications(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range items {
+		if items[idx].Name == name && items[idx].ServiceID == productID {
+			return &items[idx], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// reconcileCredentialsSecret writes the application credentials to the credentials secret.
+// user_key for products with userkey authentication mode, app_id and app_key otherwise
+func (r *ApplicationReconciler) reconcileCredentialsSecret(resource *capabilitiesv1beta1.Application, accountID int64, applicationItem *controllerhelper.ApplicationItem, developerAPIClient *controllerhelper.DeveloperAPIClient) error {
+	stringData := map[string]string{}
+
+	if applicationItem.UserKey != "" {
+		stringData[capabilitiesv1beta1.ApplicationUserKeySecretField] = applicationItem.UserKey
+	} else {
+		keys, err := developerAPIClient.ApplicationKeys(accountID, applicationItem.ID)
+		if err != nil {
+			return fmt.Errorf("application [%s] get keys: %w", resource.Spec.Name, err)
+		}
+
+		stringData[capabilitiesv1beta1.ApplicationAppIDSecretField] = applicationItem.ApplicationID
+		if len(keys) > 0 {
+			stringData[capabilitiesv1beta1.ApplicationAppKeySecretField] = keys[0]
+		}
+	}
+
+	desired := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resource.CredentialsSecretName(),
+			Namespace: resource.Namespace,
+			Labels: map[string]string{
+				"app": "3scale-api-management",
+			},
+		},
+		StringData: stringData,
+		Type:       corev1.SecretTypeOpaque,
+	}
+
+	err := r.SetOwnerReference(resource, desired)
+	if err != nil {
+		return err
+	}
+
+	return r.ReconcileResource(&corev1.Secret{}, desired, applicationCredentialsSecretMutator)
+}
+
+func applicationCredentialsSecretMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*corev1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", existingObj)
+	}
+	desired, ok := desiredObj.(*corev1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", desiredObj)
+	}
+
+	updated := false
+	for field := range desired.StringData {
+		fieldUpdated := reconcilers.SecretReconcileField(desired, existing, field)
+		updated = updated || fieldUpdated
+	}
+
+	// Remove the credentials of the previous authentication mode
+	credentialFields := []string{
+		capabilitiesv1beta1.ApplicationUserKeySecretField,
+		capabilitiesv1beta1.ApplicationAppIDSecretField,
+		capabilitiesv1beta1.ApplicationAppKeySecretField,
+	}
+	for _, field := range credentialFields {
+		if _, ok := desired.StringData[field]; ok {
+			continue
+		}
+		if _, ok := existing.Data[field]; ok {
+			delete(existing.Data, field)
+			updated = true
+		}
+		if _, ok := existing.StringData[field]; ok {
+			delete(existing.StringData, field)
+			updated = true
+		}
+	}
+
+	return updated, nil
+}
+
+// finalize removes the 3scale application, when required by the deletion policy,
+// and then releases the custom resource by removing the finalizer
+func (r *ApplicationReconciler) finalize(resource *capabilitiesv1beta1.Application) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("application", resource.Name)
+
+	if resource.IsRemoteDeletionEnabled() && resource.Status.ID != nil && resource.Status.AccountID != nil {
+		err := r.deleteRemoteApplication(resource)
+		if err != nil {
+			logger.Error(err, "Failed to delete 3scale application")
+			r.EventRecorder().Eventf(resource, corev1.EventTypeWarning, "DeletionError", "%v", err)
+
+			resource.Status.Conditions.SetCondition(common.Condition{
+				Type:    capabilitiesv1beta1.ApplicationFailedConditionType,
+				Status:  corev1.ConditionTrue,
+				Message: err.Error(),
+			})
+			statusUpdateErr := r.UpdateResourceStatus(resource)
+			if statusUpdateErr != nil {
+				return ctrl.Result{}, fmt.Errorf("Failed to delete application: %v. Failed to update application status: %w", err, statusUpdateErr)
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		r.EventRecorder().Eventf(resource, corev1.EventTypeNormal, "Deleted", "3scale application [%d] deleted", *resource.Status.ID)
+	}
+
+	controllerutil.RemoveFinalizer(resource, capabilitiesv1beta1.ApplicationFinalizer)
+	err := r.UpdateResource(resource)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("Failed removing application finalizer: %w", err)
+	}
+
+	logger.Info("resource finalizer removed")
+	return ctrl.Result{}, nil
+}
+
+func (r *ApplicationReconciler) deleteRemoteApplication(resource *capabilitiesv1beta1.Application) error {
+	logger := r.Logger().WithValues("application", resource.Name)
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), resource.Namespace, resource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return err
+	}
+
+	developerAPIClient, err := controllerhelper.NewDeveloperAPIClient(providerAccount)
+	if err != nil {
+		return err
+	}
+
+	err = developerAPIClient.DeleteApplication(*resource.Status.AccountID, *resource.Status.ID)
+	if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+		return fmt.Errorf("application [%s] delete request: %w", resource.Spec.Name, err)
+	}
+
+	return nil
+}
@@ -51,6 +51,7 @@ func (t *ProductThreescaleReconciler) syncApplicationPlans(_ interface{}) error
 	for _, systemName := range matchedKeys {
 		// interface to remote entity
 		planEntity := controllerhelper.NewApplicationPlanEntity(t.productEntity.ID(), existingMap[systemName], t.threescaleAPIClient, t.logger)
+		planEntity.SetDriftRecorder(t.drift)
 		// desired spec
 		planSpec := t.resource.Spec.ApplicationPlans[systemName]
 		reconciler := newApplicationPlanReconciler(t.BaseReconciler, systemName, planSpec, t.threescaleAPIClient, t.productEntity, t.backendRemoteIndex, planEntity, t.logger)
@@ -78,6 +79,10 @@ func (t *ProductThreescaleReconciler) syncApplicationPlans(_ interface{}) error
 		if err != nil {
 			return fmt.Errorf("Error sync product [%s] plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
 		}
+		if obj == nil {
+			// observe mode: the plan does not exist
+			continue
+		}
 		// interface to remote entity
 		planEntity := controllerhelper.NewApplicationPlanEntity(t.productEntity.ID(), obj.Element, t.threescaleAPIClient, t.logger)
 
@@ -20,23 +20,39 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// backendDeletionBlockedRequeueDelay is the delay between removal attempts of a 3scale backend
+// still used by some product. Each attempt lists the backend usages of every product
+const backendDeletionBlockedRequeueDelay = time.Minute
+
 // BackendReconciler reconciles a Backend object
 type BackendReconciler struct {
 	*reconcilers.BaseReconciler
+
+	// ResyncPeriod is the period of the resync against 3scale of the backends
+	// not annotated with the resync period. Zero disables the resync
+	ResyncPeriod time.Duration
 }
 
 // blank assignment to verify that BackendReconciler implements reconcile.Reconciler
@@ -74,12 +90,27 @@ func (r *BackendReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		reqLogger.V(1).Info(string(jsonData))
 	}
 
-	// Ignore deleted Backends, this can happen when foregroundDeletion is enabled
-	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if backend.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(backend, capabilitiesv1beta1.BackendFinalizer) {
+			return r.finalize(backend)
+		}
+
+		// Ignore deleted Backends, this can happen when foregroundDeletion is enabled
+		// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 		return ctrl.Result{}, nil
 	}
 
+	if !controllerutil.ContainsFinalizer(backend, capabilitiesv1beta1.BackendFinalizer) {
+		controllerutil.AddFinalizer(backend, capabilitiesv1beta1.BackendFinalizer)
+		err := r.UpdateResource(backend)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed adding backend finalizer: %w", err)
+		}
+
+		reqLogger.Info("resource finalizer added. Requeueing.")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	if backend.SetDefaults(reqLogger) {
 		err := r.Client().Update(r.Context(), backend)
 		if err != nil {
@@ -90,7 +121,10 @@ func (r *BackendReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	statusReconciler, reconcileErr := r.reconcile(backend)
+	resync := isResync(backend, backend.Status.ObservedGeneration, backend.IsSynced())
+	remoteChanges := &controllerhelper.RemoteChangesCounter{}
+	statusReconciler, reconcileErr := r.reconcile(backend, remoteChanges)
+	metrics.ObserveReconcile(capabilitiesv1beta1.BackendKind, reconcileErr, statusReconciler.drift.HasDrift())
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
 		if reconcileErr != nil {
@@ -116,17 +150,48 @@ func (r *BackendReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		r.EventRecorder().Eventf(backend, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
 	}
 
+	if reconcileErr == nil && statusReconciler.drift.HasDrift() {
+		r.EventRecorder().Eventf(backend, corev1.EventTypeWarning, "DriftDetected", "%s", statusReconciler.drift.Summary())
+	}
+
+	if reconcileErr == nil && resync && remoteChanges.Count() > 0 {
+		reqLogger.Info("3scale changes done out of the operator corrected", "changes", remoteChanges.Count())
+		r.EventRecorder().Eventf(backend, corev1.EventTypeNormal, "RemoteChangesCorrected", "%d 3scale changes done out of the operator corrected", remoteChanges.Count())
+	}
+
+	if throttling := controllerhelper.AdminAPIThrottlingFor(statusReconciler.providerAccountHost); throttling != nil {
+		// Retry once the provider account is not throttled, clearing the throttled condition
+		reqLogger.Info("END", "error", reconcileErr, "throttled", throttling.Reason, "retry after", throttling.RetryAfter)
+		return ctrl.Result{RequeueAfter: throttling.RetryAfter}, nil
+	}
+
 	reqLogger.Info("END", "error", reconcileErr)
-	return ctrl.Result{}, nil
+	return resyncResult(backend, r.ResyncPeriod), nil
 }
 
 func (r *BackendReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &capabilitiesv1beta1.Backend{}, controllerhelper.SecretRefsIndexKey, controllerhelper.BackendSecretRefs)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &capabilitiesv1beta1.Backend{}, controllerhelper.ProviderAccountCRRefIndexKey, controllerhelper.BackendProviderAccountCRRef)
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.Backend{}).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: controllerhelper.SecretToRequestsMapper(mgr.GetClient(), func() runtime.Object { return &capabilitiesv1beta1.BackendList{} }, r.Logger()),
+			},
+		).
 		Complete(r)
 }
 
-func (r *BackendReconciler) reconcile(backendResource *capabilitiesv1beta1.Backend) (*BackendStatusReconciler, error) {
+func (r *BackendReconciler) reconcile(backendResource *capabilitiesv1beta1.Backend, remoteChanges *controllerhelper.RemoteChangesCounter) (*BackendStatusReconciler, error) {
 	logger := r.Logger().WithValues("backend", backendResource.Name)
 
 	err := r.validateSpec(backendResource)
@@ -135,13 +200,13 @@ func (r *BackendReconciler) reconcile(backendResource *capabilitiesv1beta1.Backe
 		return statusReconciler, err
 	}
 
-	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), backendResource.Namespace, backendResource.Spec.ProviderAccountRef, logger)
+	providerAccount, err := controllerhelper.LookupProviderAccountWithCRRef(r.Client(), backendResource.Namespace, backendResource.Spec.ProviderAccountCRRef, backendResource.Spec.ProviderAccountRef, logger)
 	if err != nil {
 		statusReconciler := NewBackendStatusReconciler(r.BaseReconciler, backendResource, nil, "", err)
 		return statusReconciler, err
 	}
 
-	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount)
+	threescaleAPIClient, err := controllerhelper.PortaClientWithChangesCounter(providerAccount, remoteChanges)
 	if err != nil {
 		statusReconciler := NewBackendStatusReconciler(r.BaseReconciler, backendResource, nil, providerAccount.AdminURLStr, err)
 		return statusReconciler, err
@@ -153,12 +218,123 @@ func (r *BackendReconciler) reconcile(backendResource *capabilitiesv1beta1.Backe
 		return statusReconciler, err
 	}
 
+	var drift *controllerhelper.DriftRecorder
+	if capabilitiesv1beta1.IsObserveMode(backendResource) {
+		drift = controllerhelper.NewDriftRecorder()
+	}
+
 	reconciler := NewThreescaleReconciler(r.BaseReconciler, backendResource, threescaleAPIClient, backendRemoteIndex, providerAccount)
+	reconciler.drift = drift
 	backendAPIEntity, err := reconciler.Reconcile()
 	statusReconciler := NewBackendStatusReconciler(r.BaseReconciler, backendResource, backendAPIEntity, providerAccount.AdminURLStr, err)
+	statusReconciler.drift = drift
 	return statusReconciler, err
 }
 
+// finalize removes the 3scale backend, when required by the deletion policy,
+// and then releases the custom resource by removing the finalizer.
+// Backends still used by some product are not removed and the deletion is retried.
+func (r *BackendReconciler) finalize(backendResource *capabilitiesv1beta1.Backend) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("backend", backendResource.Name)
+
+	if backendResource.IsRemoteDeletionEnabled() && backendResource.Status.ID != nil {
+		blockedMsg, err := r.deleteRemoteBackend(backendResource)
+		switch {
+		case controllerhelper.IsProviderAccountNotFound(err):
+			// Without provider account the 3scale backend cannot be deleted, it is orphaned
+			logger.Info("3scale backend not deleted, provider account not found", "error", err.Error())
+			r.EventRecorder().Eventf(backendResource, corev1.EventTypeWarning, "Orphaned", "3scale backend [%d] not deleted: %v", *backendResource.Status.ID, err)
+		case err != nil || blockedMsg != "":
+			condition := common.Condition{
+				Type:    capabilitiesv1beta1.BackendDeletionBlockedConditionType,
+				Status:  corev1.ConditionTrue,
+				Message: blockedMsg,
+			}
+			if err != nil {
+				logger.Error(err, "Failed to delete 3scale backend")
+				r.EventRecorder().Eventf(backendResource, corev1.EventTypeWarning, "DeletionError", "%v", err)
+				condition.Type = capabilitiesv1beta1.BackendFailedConditionType
+				condition.Message = err.Error()
+			} else {
+				logger.Info("3scale backend deletion blocked", "reason", blockedMsg)
+				r.EventRecorder().Eventf(backendResource, corev1.EventTypeWarning, "DeletionBlocked", "%s", blockedMsg)
+			}
+
+			backendResource.Status.Conditions.SetCondition(condition)
+			statusUpdateErr := r.UpdateResourceStatus(backendResource)
+			if statusUpdateErr != nil {
+				return ctrl.Result{}, fmt.Errorf("Failed to update backend status: %w", statusUpdateErr)
+			}
+
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{RequeueAfter: backendDeletionBlockedRequeueDelay}, nil
+		default:
+			r.EventRecorder().Eventf(backendResource, corev1.EventTypeNormal, "Deleted", "3scale backend [%d] deleted", *backendResource.Status.ID)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(backendResource, capabilitiesv1beta1.BackendFinalizer)
+	err := r.UpdateResource(backendResource)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("Failed removing backend finalizer: %w", err)
+	}
+
+	logger.Info("resource finalizer removed")
+	return ctrl.Result{}, nil
+}
+
+// deleteRemoteBackend removes the 3scale backend.
+// When the backend is still used by some product, it is not removed
+// and a message describing the blocking products is returned.
+func (r *BackendReconciler) deleteRemoteBackend(backendResource *capabilitiesv1beta1.Backend) (string, error) {
+	logger := r.Logger().WithValues("backend", backendResource.Name)
+
+	providerAccount, err := controllerhelper.LookupProviderAccountWithCRRef(r.Client(), backendResource.Namespace, backendResource.Spec.ProviderAccountCRRef, backendResource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return "", err
+	}
+
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount)
+	if err != nil {
+		return "", err
+	}
+
+	backendRemoteIndex, err := controllerhelper.NewBackendAPIRemoteIndex(threescaleAPIClient, logger)
+	if err != nil {
+		return "", err
+	}
+
+	backendAPIEntity, ok := backendRemoteIndex.FindByID(*backendResource.Status.ID)
+	if !ok {
+		// Already deleted
+		return "", nil
+	}
+
+	productUsages, err := backendAPIEntity.ProductUsages()
+	if err != nil {
+		return "", err
+	}
+
+	if len(productUsages) > 0 {
+		productNames := make([]string, 0, len(productUsages))
+		for _, product := range productUsages {
+			productNames = append(productNames, product.SystemName)
+		}
+
+		return fmt.Sprintf("backend is used by products %v", productNames), nil
+	}
+
+	err = threescaleAPIClient.DeleteBackendApi(backendAPIEntity.ID())
+	if err != nil && !threescaleapi.IsNotFound(err) {
+		return "", fmt.Errorf("backend [%s] delete request: %w", backendAPIEntity.SystemName(), err)
+	}
+
+	return "", nil
+}
+
 func (r *BackendReconciler) validateSpec(backendResource *capabilitiesv1beta1.Backend) error {
 	errors := field.ErrorList{}
 	// internal validation
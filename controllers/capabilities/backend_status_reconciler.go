@@ -22,6 +22,9 @@ type BackendStatusReconciler struct {
 	providerAccountHost string
 	syncError           error
 	logger              logr.Logger
+	// drift holds the changes computed in observe mode.
+	// nil when the backend is reconciled in sync mode
+	drift *controllerhelper.DriftRecorder
 }
 
 func NewBackendStatusReconciler(b *reconcilers.BaseReconciler, backendResource *capabilitiesv1beta1.Backend, backendAPIEntity *controllerhelper.BackendAPIEntity, providerAccountHost string, syncError error) *BackendStatusReconciler {
@@ -86,6 +89,13 @@ func (s *BackendStatusReconciler) calculateStatus() *capabilitiesv1beta1.Backend
 	newStatus.Conditions.SetCondition(s.syncCondition())
 	newStatus.Conditions.SetCondition(s.invalidCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
+	newStatus.Conditions.SetCondition(s.throttledCondition())
+	if s.drift == nil {
+		newStatus.Conditions.RemoveCondition(capabilitiesv1beta1.BackendDriftedConditionType)
+	} else if s.syncError == nil {
+		// drift is only complete when there are no errors
+		newStatus.Conditions.SetCondition(s.driftedCondition())
+	}
 
 	return newStatus
 }
@@ -96,8 +106,23 @@ func (s *BackendStatusReconciler) syncCondition() common.Condition {
 		Status: corev1.ConditionFalse,
 	}
 
-	if s.syncError == nil {
+	// In observe mode, 3scale is in sync only when there is no drift
+	if s.syncError == nil && !s.drift.HasDrift() {
+		condition.Status = corev1.ConditionTrue
+	}
+
+	return condition
+}
+
+func (s *BackendStatusReconciler) driftedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.BackendDriftedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.drift.HasDrift() {
 		condition.Status = corev1.ConditionTrue
+		condition.Message = s.drift.Summary()
 	}
 
 	return condition
@@ -131,3 +156,18 @@ func (s *BackendStatusReconciler) failedCondition() common.Condition {
 
 	return condition
 }
+
+func (s *BackendStatusReconciler) throttledCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.BackendThrottledConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if throttling := controllerhelper.AdminAPIThrottlingFor(s.providerAccountHost); throttling != nil {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = common.ConditionReason(throttling.Reason)
+		condition.Message = throttling.Message
+	}
+
+	return condition
+}
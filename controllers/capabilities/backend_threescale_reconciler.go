@@ -21,7 +21,9 @@ type BackendThreescaleReconciler struct {
 	backendRemoteIndex  *controllerhelper.BackendAPIRemoteIndex
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
 	providerAccount     *controllerhelper.ProviderAccount
-	logger              logr.Logger
+	// drift enables observe mode when set
+	drift  *controllerhelper.DriftRecorder
+	logger logr.Logger
 }
 
 func NewThreescaleReconciler(b *reconcilers.BaseReconciler,
@@ -42,6 +44,14 @@ func NewThreescaleReconciler(b *reconcilers.BaseReconciler,
 }
 
 func (t *BackendThreescaleReconciler) Reconcile() (*controllerhelper.BackendAPIEntity, error) {
+	if t.drift != nil {
+		if _, exists := t.backendRemoteIndex.FindBySystemName(t.backendResource.Spec.SystemName); !exists {
+			// observe mode: the backend does not exist
+			t.drift.Record("backend [%s] create", t.backendResource.Spec.SystemName)
+			return nil, nil
+		}
+	}
+
 	taskRunner := helper.NewTaskRunner(nil, t.logger)
 	taskRunner.AddTask("SyncBackend", t.syncBackend)
 	// First methods and metrics, then mapping rules.
@@ -84,6 +94,7 @@ func (t *BackendThreescaleReconciler) syncBackend(_ interface{}) error {
 
 	// Will be used by coming steps
 	t.backendAPIEntity = backendAPIEntity
+	t.backendAPIEntity.SetDriftRecorder(t.drift)
 
 	updatedParams := threescaleapi.Params{}
 
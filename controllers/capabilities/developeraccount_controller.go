@@ -0,0 +1,256 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// DeveloperAccountReconciler reconciles a DeveloperAccount object
+type DeveloperAccountReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that DeveloperAccountReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &DeveloperAccountReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developeraccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developeraccounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=developeraccounts/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *DeveloperAccountReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	_ = context.Background()
+	reqLogger := r.Logger().WithValues("developeraccount", req.NamespacedName)
+	reqLogger.Info("Reconcile DeveloperAccount", "Operator version", version.Version)
+
+	// Fetch the DeveloperAccount instance
+	developerAccount := &capabilitiesv1beta1.DeveloperAccount{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, developerAccount)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(developerAccount, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	if developerAccount.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(developerAccount, capabilitiesv1beta1.DeveloperAccountFinalizer) {
+			return r.finalize(developerAccount)
+		}
+
+		// Ignore deleted DeveloperAccounts, this can happen when foregroundDeletion is enabled
+		// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(developerAccount, capabilitiesv1beta1.DeveloperAccountFinalizer) {
+		controllerutil.AddFinalizer(developerAccount, capabilitiesv1beta1.DeveloperAccountFinalizer)
+		err := r.UpdateResource(developerAccount)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed adding developer account finalizer: %w", err)
+		}
+
+		reqLogger.Info("resource finalizer added. Requeueing.")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcile(developerAccount)
+	metrics.ObserveReconcile(capabilitiesv1beta1.DeveloperAccountKind, reconcileErr, false)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed to sync developer account: %v. Failed to update developer account status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("Failed to update developer account status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(developerAccount, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+	}
+
+	reqLogger.Info("END", "error", reconcileErr)
+	return ctrl.Result{}, reconcileErr
+}
+
+func (r *DeveloperAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.DeveloperAccount{}).
+		Complete(r)
+}
+
+func (r *DeveloperAccountReconciler) reconcile(resource *capabilitiesv1beta1.DeveloperAccount) (*DeveloperAccountStatusReconciler, error) {
+	logger := r.Logger().WithValues("developeraccount", resource.Name)
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), resource.Namespace, resource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return NewDeveloperAccountStatusReconciler(r.BaseReconciler, resource, nil, "", err), err
+	}
+
+	developerAPIClient, err := controllerhelper.NewDeveloperAPIClient(providerAccount)
+	if err != nil {
+		return NewDeveloperAccountStatusReconciler(r.BaseReconciler, resource, nil, providerAccount.AdminURLStr, err), err
+	}
+
+	accountItem, err := r.syncDeveloperAccount(resource, developerAPIClient)
+	return NewDeveloperAccountStatusReconciler(r.BaseReconciler, resource, accountItem, providerAccount.AdminURLStr, err), err
+}
+
+// syncDeveloperAccount makes sure the 3scale developer account exists, otherwise it will be created,
+// and that its attributes match the spec
+func (r *DeveloperAccountReconciler) syncDeveloperAccount(resource *capabilitiesv1beta1.DeveloperAccount, developerAPIClient *controllerhelper.DeveloperAPIClient) (*controllerhelper.DeveloperAccountItem, error) {
+	logger := r.Logger().WithValues("developeraccount", resource.Name)
+
+	var accountItem *controllerhelper.DeveloperAccountItem
+	if resource.Status.ID != nil {
+		item, err := developerAPIClient.DeveloperAccount(*resource.Status.ID)
+		if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+			return nil, fmt.Errorf("developer account [%s] read request: %w", resource.Spec.OrgName, err)
+		}
+		accountItem = item
+	}
+
+	if accountItem == nil {
+		params := threescaleapi.Params{
+			"org_name": resource.Spec.OrgName,
+			"username": resource.Spec.Username,
+			"email":    resource.Spec.Email,
+		}
+
+		if resource.Spec.PasswordCredentialsRef != nil {
+			secretSource := helper.NewSecretSource(r.Client(), resource.Namespace)
+			password, err := secretSource.RequiredFieldValueFromRequiredSecret(resource.Spec.PasswordCredentialsRef.Name, capabilitiesv1beta1.DeveloperAccountPasswordSecretField)
+			if err != nil {
+				return nil, err
+			}
+			params["password"] = password
+		}
+
+		logger.Info("Creating a new developer account", "OrgName", resource.Spec.OrgName, "Username", resource.Spec.Username)
+		item, err := developerAPIClient.Signup(params)
+		if err != nil {
+			return nil, fmt.Errorf("developer account [%s] create request: %w", resource.Spec.OrgName, err)
+		}
+
+		return item, nil
+	}
+
+	if accountItem.OrgName != resource.Spec.OrgName {
+		logger.Info("Syncing developer account", "AccountID", accountItem.ID)
+		item, err := developerAPIClient.UpdateDeveloperAccount(accountItem.ID, threescaleapi.Params{"org_name": resource.Spec.OrgName})
+		if err != nil {
+			return accountItem, fmt.Errorf("developer account [%s] update request: %w", resource.Spec.OrgName, err)
+		}
+		accountItem = item
+	}
+
+	return accountItem, nil
+}
+
+// finalize removes the 3scale developer account, when required by the deletion policy,
+// and then releases the custom resource by removing the finalizer
+func (r *DeveloperAccountReconciler) finalize(resource *capabilitiesv1beta1.DeveloperAccount) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("developeraccount", resource.Name)
+
+	if resource.IsRemoteDeletionEnabled() && resource.Status.ID != nil {
+		err := r.deleteRemoteDeveloperAccount(resource)
+		if err != nil {
+			logger.Error(err, "Failed to delete 3scale developer account")
+			r.EventRecorder().Eventf(resource, corev1.EventTypeWarning, "DeletionError", "%v", err)
+
+			resource.Status.Conditions.SetCondition(common.Condition{
+				Type:    capabilitiesv1beta1.DeveloperAccountFailedConditionType,
+				Status:  corev1.ConditionTrue,
+				Message: err.Error(),
+			})
+			statusUpdateErr := r.UpdateResourceStatus(resource)
+			if statusUpdateErr != nil {
+				return ctrl.Result{}, fmt.Errorf("Failed to delete developer account: %v. Failed to update developer account status: %w", err, statusUpdateErr)
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		r.EventRecorder().Eventf(resource, corev1.EventTypeNormal, "Deleted", "3scale developer account [%d] deleted", *resource.Status.ID)
+	}
+
+	controllerutil.RemoveFinalizer(resource, capabilitiesv1beta1.DeveloperAccountFinalizer)
+	err := r.UpdateResource(resource)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("Failed removing developer account finalizer: %w", err)
+	}
+
+	logger.Info("resource finalizer removed")
+	return ctrl.Result{}, nil
+}
+
+func (r *DeveloperAccountReconciler) deleteRemoteDeveloperAccount(resource *capabilitiesv1beta1.DeveloperAccount) error {
+	logger := r.Logger().WithValues("developeraccount", resource.Name)
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), resource.Namespace, resource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return err
+	}
+
+	developerAPIClient, err := controllerhelper.NewDeveloperAPIClient(providerAccount)
+	if err != nil {
+		return err
+	}
+
+	err = developerAPIClient.DeleteDeveloperAccount(*resource.Status.ID)
+	if err != nil && !controllerhelper.IsAdminAPINotFound(err) {
+		return fmt.Errorf("developer account [%s] delete request: %w", resource.Spec.OrgName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+type DeveloperAccountStatusReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.DeveloperAccount
+	accountItem         *controllerhelper.DeveloperAccountItem
+	providerAccountHost string
+	syncError           error
+	logger              logr.Logger
+}
+
+func NewDeveloperAccountStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, accountItem *controllerhelper.DeveloperAccountItem, providerAccountHost string, syncError error) *DeveloperAccountStatusReconciler {
+	return &DeveloperAccountStatusReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		accountItem:         accountItem,
+		providerAccountHost: providerAccountHost,
+		syncError:           syncError,
+		logger:              b.Logger().WithValues("Status Reconciler", resource.Name),
+	}
+}
+
+func (s *DeveloperAccountStatusReconciler) Reconcile() (reconcile.Result, error) {
+	s.logger.V(1).Info("START")
+
+	newStatus := s.calculateStatus()
+
+	equalStatus := s.resource.Status.Equals(newStatus, s.logger)
+	s.logger.V(1).Info("Status", "status is different", !equalStatus)
+	s.logger.V(1).Info("Status", "generation is different", s.resource.Generation != s.resource.Status.ObservedGeneration)
+	if equalStatus && s.resource.Generation == s.resource.Status.ObservedGeneration {
+		// Steady state
+		s.logger.V(1).Info("Status was not updated")
+		return reconcile.Result{}, nil
+	}
+
+	// Save the generation number we acted on, otherwise we might wrongfully indicate
+	// that we've seen a spec update when we retry.
+	newStatus.ObservedGeneration = s.resource.Generation
+
+	s.logger.V(1).Info("Updating Status", "sequence no:", fmt.Sprintf("sequence No: %v->%v", s.resource.Status.ObservedGeneration, newStatus.ObservedGeneration))
+
+	s.resource.Status = *newStatus
+	updateErr := s.Client().Status().Update(s.Context(), s.resource)
+	if updateErr != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(updateErr) {
+			s.logger.Info("Failed to update status: resource might just be outdated")
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("Failed to update status: %w", updateErr)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (s *DeveloperAccountStatusReconciler) calculateStatus() *capabilitiesv1beta1.DeveloperAccountStatus {
+	// Keep previously observed values when the remote account could not be read.
+	// Losing the account ID would create a new account.
+	newStatus := &capabilitiesv1beta1.DeveloperAccountStatus{
+		ID:           s.resource.Status.ID,
+		AccountState: s.resource.Status.AccountState,
+	}
+
+	if s.accountItem != nil {
+		tmp := s.accountItem.ID
+		newStatus.ID = &tmp
+		newStatus.AccountState = s.accountItem.State
+	}
+
+	newStatus.ProviderAccountHost = s.providerAccountHost
+
+	newStatus.ObservedGeneration = s.resource.Status.ObservedGeneration
+
+	newStatus.Conditions = s.resource.Status.Conditions.Copy()
+	newStatus.Conditions.SetCondition(s.syncCondition())
+	newStatus.Conditions.SetCondition(s.failedCondition())
+
+	return newStatus
+}
+
+func (s *DeveloperAccountStatusReconciler) syncCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperAccountSyncedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.syncError == nil {
+		condition.Status = corev1.ConditionTrue
+	}
+
+	return condition
+}
+
+func (s *DeveloperAccountStatusReconciler) failedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperAccountFailedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	// This condition could be activated together with other conditions
+	if s.syncError != nil {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.syncError.Error()
+	}
+
+	return condition
+}
@@ -111,11 +111,12 @@ func (p *OpenAPIBackendReconciler) desired() (*capabilitiesv1beta1.Backend, erro
 			Namespace: p.openapiCR.Namespace,
 		},
 		Spec: capabilitiesv1beta1.BackendSpec{
-			Name:               name,
-			SystemName:         systemName,
-			PrivateBaseURL:     privateBaseURL,
-			Description:        description,
-			ProviderAccountRef: p.openapiCR.Spec.ProviderAccountRef,
+			Name:                 name,
+			SystemName:           systemName,
+			PrivateBaseURL:       privateBaseURL,
+			Description:          description,
+			ProviderAccountRef:   p.openapiCR.Spec.ProviderAccountRef,
+			ProviderAccountCRRef: p.openapiCR.Spec.ProviderAccountCRRef,
 		},
 	}
 
@@ -24,15 +24,19 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -95,6 +99,7 @@ func (r *OpenAPIReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 
 	statusReconciler, reconcileStatus, reconcileErr := r.reconcileSpec(openapiCR)
+	metrics.ObserveReconcile(capabilitiesv1beta1.OpenAPIKind, reconcileErr, false)
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
 		if reconcileErr != nil {
@@ -125,8 +130,24 @@ func (r *OpenAPIReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 }
 
 func (r *OpenAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &capabilitiesv1beta1.OpenAPI{}, controllerhelper.SecretRefsIndexKey, controllerhelper.OpenAPISecretRefs)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &capabilitiesv1beta1.OpenAPI{}, controllerhelper.ProviderAccountCRRefIndexKey, controllerhelper.OpenAPIProviderAccountCRRef)
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.OpenAPI{}).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: controllerhelper.SecretToRequestsMapper(mgr.GetClient(), func() runtime.Object { return &capabilitiesv1beta1.OpenAPIList{} }, r.Logger()),
+			},
+		).
 		Complete(r)
 }
 
@@ -139,7 +160,7 @@ func (r *OpenAPIReconciler) reconcileSpec(openapiCR *capabilitiesv1beta1.OpenAPI
 		return statusReconciler, ctrl.Result{}, err
 	}
 
-	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), openapiCR.Namespace, openapiCR.Spec.ProviderAccountRef, logger)
+	providerAccount, err := controllerhelper.LookupProviderAccountWithCRRef(r.Client(), openapiCR.Namespace, openapiCR.Spec.ProviderAccountCRRef, openapiCR.Spec.ProviderAccountRef, logger)
 	if err != nil {
 		statusReconciler := NewOpenAPIStatusReconciler(r.BaseReconciler, openapiCR, "", err, false)
 		return statusReconciler, ctrl.Result{}, err
@@ -110,15 +110,20 @@ func (p *OpenAPIProductReconciler) desired() (*capabilitiesv1beta1.Product, erro
 			Namespace: p.openapiCR.Namespace,
 		},
 		Spec: capabilitiesv1beta1.ProductSpec{
-			Name:               name,
-			SystemName:         systemName,
-			Description:        description,
-			ProviderAccountRef: p.openapiCR.Spec.ProviderAccountRef,
+			Name:                 name,
+			SystemName:           systemName,
+			Description:          description,
+			ProviderAccountRef:   p.openapiCR.Spec.ProviderAccountRef,
+			ProviderAccountCRRef: p.openapiCR.Spec.ProviderAccountCRRef,
 		},
 	}
 
 	// Deployment
-	product.Spec.Deployment = p.desiredDeployment()
+	deployment, err := p.desiredDeployment()
+	if err != nil {
+		return nil, err
+	}
+	product.Spec.Deployment = deployment
 
 	// Methods
 	product.Spec.Methods = p.desiredMethods()
@@ -208,31 +213,36 @@ func (p *OpenAPIProductReconciler) desiredObjName() string {
 	return fmt.Sprintf("%s-%s", helper.K8sNameFromOpenAPITitle(p.openapiObj), string(p.openapiCR.UID))
 }
 
-func (p *OpenAPIProductReconciler) desiredDeployment() *capabilitiesv1beta1.ProductDeploymentSpec {
+func (p *OpenAPIProductReconciler) desiredDeployment() (*capabilitiesv1beta1.ProductDeploymentSpec, error) {
 	deployment := &capabilitiesv1beta1.ProductDeploymentSpec{}
 
+	authentication, err := p.desiredAuthentication()
+	if err != nil {
+		return nil, err
+	}
+
 	if p.openapiCR.Spec.ProductionPublicBaseURL != nil || p.openapiCR.Spec.StagingPublicBaseURL != nil {
 		// Self managed deployment
 		deployment.ApicastSelfManaged = &capabilitiesv1beta1.ApicastSelfManagedSpec{
 			StagingPublicBaseURL:    p.openapiCR.Spec.StagingPublicBaseURL,
 			ProductionPublicBaseURL: p.openapiCR.Spec.ProductionPublicBaseURL,
-			Authentication:          p.desiredAuthentication(),
+			Authentication:          authentication,
 		}
 	} else {
 		// Hosted deployment
 		deployment.ApicastHosted = &capabilitiesv1beta1.ApicastHostedSpec{
-			Authentication: p.desiredAuthentication(),
+			Authentication: authentication,
 		}
 	}
 
-	return deployment
+	return deployment, nil
 }
 
-func (p *OpenAPIProductReconciler) desiredAuthentication() *capabilitiesv1beta1.AuthenticationSpec {
+func (p *OpenAPIProductReconciler) desiredAuthentication() (*capabilitiesv1beta1.AuthenticationSpec, error) {
 	globalSecRequirements := helper.OpenAPIGlobalSecurityRequirements(p.openapiObj)
 	if len(globalSecRequirements) == 0 {
 		// if no security requirements are found, default to UserKey auth
-		return p.desiredUserKeyAuthentication(nil)
+		return p.desiredUserKeyAuthentication(nil), nil
 	}
 
 	// Only the first one is used
@@ -241,12 +251,61 @@ func (p *OpenAPIProductReconciler) desiredAuthentication() *capabilitiesv1beta1.
 	var authenticationSpec *capabilitiesv1beta1.AuthenticationSpec
 
 	switch secRequirementExtended.Value.Type {
-	// TODO types "oauth2", "openIdConnect"
 	case "apiKey":
 		authenticationSpec = p.desiredUserKeyAuthentication(secRequirementExtended)
+	case "openIdConnect", "oauth2":
+		oidcAuthenticationSpec, err := p.desiredOIDCAuthentication(secRequirementExtended)
+		if err != nil {
+			return nil, err
+		}
+		authenticationSpec = oidcAuthenticationSpec
 	}
 
-	return authenticationSpec
+	return authenticationSpec, nil
+}
+
+func (p *OpenAPIProductReconciler) desiredOIDCAuthentication(secReq *helper.ExtendedSecurityRequirement) (*capabilitiesv1beta1.AuthenticationSpec, error) {
+	if p.openapiCR.Spec.OIDCIssuerEndpointRef == nil {
+		fieldErrors := field.ErrorList{}
+		specFldPath := field.NewPath("spec")
+		issuerEndpointRefFldPath := specFldPath.Child("oidcIssuerEndpointRef")
+		fieldErrors = append(fieldErrors, field.Required(issuerEndpointRefFldPath, fmt.Sprintf("required for %s security scheme", secReq.Value.Type)))
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	issuerType := "keycloak"
+	if p.openapiCR.Spec.OIDCIssuerType != nil {
+		issuerType = *p.openapiCR.Spec.OIDCIssuerType
+	}
+
+	authSpec := &capabilitiesv1beta1.AuthenticationSpec{
+		OIDC: &capabilitiesv1beta1.OIDCSpec{
+			IssuerType:         issuerType,
+			IssuerEndpointRef:  *p.openapiCR.Spec.OIDCIssuerEndpointRef,
+			AuthenticationFlow: p.desiredOIDCAuthenticationFlow(secReq),
+			Security:           p.desiredPrivateAPISecurity(),
+		},
+	}
+
+	return authSpec, nil
+}
+
+func (p *OpenAPIProductReconciler) desiredOIDCAuthenticationFlow(secReq *helper.ExtendedSecurityRequirement) *capabilitiesv1beta1.OIDCAuthenticationFlowSpec {
+	flows := secReq.Value.Flows
+	if secReq.Value.Type != "oauth2" || flows == nil {
+		// openIdConnect security scheme does not declare flows, default to authorization code flow
+		return &capabilitiesv1beta1.OIDCAuthenticationFlowSpec{StandardFlowEnabled: true}
+	}
+
+	return &capabilitiesv1beta1.OIDCAuthenticationFlowSpec{
+		StandardFlowEnabled:       flows.AuthorizationCode != nil,
+		ImplicitFlowEnabled:       flows.Implicit != nil,
+		ServiceAccountsEnabled:    flows.ClientCredentials != nil,
+		DirectAccessGrantsEnabled: flows.Password != nil,
+	}
 }
 
 func (p *OpenAPIProductReconciler) desiredUserKeyAuthentication(secReq *helper.ExtendedSecurityRequirement) *capabilitiesv1beta1.AuthenticationSpec {
@@ -20,23 +20,37 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 )
 
 // ProductReconciler reconciles a Product object
 type ProductReconciler struct {
 	*reconcilers.BaseReconciler
+
+	// ResyncPeriod is the period of the resync against 3scale of the products
+	// not annotated with the resync period. Zero disables the resync
+	ResyncPeriod time.Duration
 }
 
 // blank assignment to verify that ProductReconciler implements reconcile.Reconciler
@@ -74,12 +88,27 @@ func (r *ProductReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		reqLogger.V(1).Info(string(jsonData))
 	}
 
-	// Ignore deleted Products, this can happen when foregroundDeletion is enabled
-	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if product.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(product, capabilitiesv1beta1.ProductFinalizer) {
+			return r.finalize(product)
+		}
+
+		// Ignore deleted Products, this can happen when foregroundDeletion is enabled
+		// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 		return ctrl.Result{}, nil
 	}
 
+	if !controllerutil.ContainsFinalizer(product, capabilitiesv1beta1.ProductFinalizer) {
+		controllerutil.AddFinalizer(product, capabilitiesv1beta1.ProductFinalizer)
+		err := r.UpdateResource(product)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed adding product finalizer: %w", err)
+		}
+
+		reqLogger.Info("resource finalizer added. Requeueing.")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	if product.SetDefaults(reqLogger) {
 		err := r.Client().Update(r.Context(), product)
 		if err != nil {
@@ -90,7 +119,10 @@ func (r *ProductReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	statusReconciler, reconcileErr := r.reconcile(product)
+	resync := isResync(product, product.Status.ObservedGeneration, product.IsSynced())
+	remoteChanges := &controllerhelper.RemoteChangesCounter{}
+	statusReconciler, reconcileErr := r.reconcile(product, remoteChanges)
+	metrics.ObserveReconcile(capabilitiesv1beta1.ProductKind, reconcileErr, statusReconciler.drift.HasDrift())
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
 		if reconcileErr != nil {
@@ -122,17 +154,72 @@ func (r *ProductReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		r.EventRecorder().Eventf(product, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
 	}
 
+	if reconcileErr == nil && statusReconciler.drift.HasDrift() {
+		r.EventRecorder().Eventf(product, corev1.EventTypeWarning, "DriftDetected", "%s", statusReconciler.drift.Summary())
+	}
+
+	if reconcileErr == nil && resync && remoteChanges.Count() > 0 {
+		reqLogger.Info("3scale changes done out of the operator corrected", "changes", remoteChanges.Count())
+		r.EventRecorder().Eventf(product, corev1.EventTypeNormal, "RemoteChangesCorrected", "%d 3scale changes done out of the operator corrected", remoteChanges.Count())
+	}
+
+	if reconcileErr == nil {
+		if retryAfter := proxyConfigSmokeCheckRetryAfter(product); retryAfter > 0 {
+			// The staging proxy config is promoted once the staging gateway passes the smoke check
+			reqLogger.Info("END", "smoke check retry after", retryAfter)
+			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		}
+	}
+
+	if throttling := controllerhelper.AdminAPIThrottlingFor(statusReconciler.providerAccountHost); throttling != nil {
+		// Retry once the provider account is not throttled, clearing the throttled condition
+		reqLogger.Info("END", "error", reconcileErr, "throttled", throttling.Reason, "retry after", throttling.RetryAfter)
+		return ctrl.Result{RequeueAfter: throttling.RetryAfter}, nil
+	}
+
 	reqLogger.Info("END", "error", reconcileErr)
-	return ctrl.Result{}, reconcileErr
+	if reconcileErr != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	return resyncResult(product, r.ResyncPeriod), nil
 }
 
 func (r *ProductReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &capabilitiesv1beta1.Product{}, controllerhelper.SecretRefsIndexKey, controllerhelper.ProductSecretRefs)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &capabilitiesv1beta1.Product{}, controllerhelper.ProviderAccountCRRefIndexKey, controllerhelper.ProductProviderAccountCRRef)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.TODO(), &capabilitiesv1beta1.Product{}, controllerhelper.ProductBackendUsagesIndexKey, controllerhelper.ProductBackendUsages)
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.Product{}).
+		Watches(
+			&source.Kind{Type: &capabilitiesv1beta1.Backend{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: controllerhelper.BackendToProductRequestsMapper(mgr.GetClient(), r.Logger()),
+			},
+			builder.WithPredicates(controllerhelper.BackendUsageChangedPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: controllerhelper.SecretToRequestsMapper(mgr.GetClient(), func() runtime.Object { return &capabilitiesv1beta1.ProductList{} }, r.Logger()),
+			},
+		).
 		Complete(r)
 }
 
-func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Product) (*ProductStatusReconciler, error) {
+func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Product, remoteChanges *controllerhelper.RemoteChangesCounter) (*ProductStatusReconciler, error) {
 	logger := r.Logger().WithValues("product", productResource.Name)
 
 	err := r.validateSpec(productResource)
@@ -141,7 +228,7 @@ func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Produ
 		return statusReconciler, err
 	}
 
-	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), productResource.Namespace, productResource.Spec.ProviderAccountRef, logger)
+	providerAccount, err := controllerhelper.LookupProviderAccountWithCRRef(r.Client(), productResource.Namespace, productResource.Spec.ProviderAccountCRRef, productResource.Spec.ProviderAccountRef, logger)
 	if err != nil {
 		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, "", err)
 		return statusReconciler, err
@@ -154,7 +241,13 @@ func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Produ
 		return statusReconciler, err
 	}
 
-	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount)
+	threescaleAPIClient, err := controllerhelper.PortaClientWithChangesCounter(providerAccount, remoteChanges)
+	if err != nil {
+		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
+		return statusReconciler, err
+	}
+
+	oidcAPIClient, err := controllerhelper.NewOIDCAPIClient(providerAccount)
 	if err != nil {
 		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
 		return statusReconciler, err
@@ -166,12 +259,92 @@ func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Produ
 		return statusReconciler, err
 	}
 
-	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, productResource, threescaleAPIClient, backendRemoteIndex)
+	var drift *controllerhelper.DriftRecorder
+	if capabilitiesv1beta1.IsObserveMode(productResource) {
+		drift = controllerhelper.NewDriftRecorder()
+	}
+
+	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, productResource, threescaleAPIClient, oidcAPIClient, backendRemoteIndex)
+	reconciler.drift = drift
 	productEntity, err := reconciler.Reconcile()
+	if err != nil || drift != nil {
+		// The staging proxy configuration is not promoted to production when the product is not synced
+		// nor in observe mode
+		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, productEntity, providerAccount.AdminURLStr, err)
+		statusReconciler.drift = drift
+		return statusReconciler, err
+	}
+
+	proxyConfigPromotion, proxyConfigSmokeCheck, err := r.reconcileProxyConfigPromotion(productResource, productEntity)
 	statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, productEntity, providerAccount.AdminURLStr, err)
+	statusReconciler.proxyConfigPromotion = proxyConfigPromotion
+	statusReconciler.proxyConfigSmokeCheck = proxyConfigSmokeCheck
 	return statusReconciler, err
 }
 
+// finalize removes the 3scale product, when required by the deletion policy,
+// and then releases the custom resource by removing the finalizer
+func (r *ProductReconciler) finalize(productResource *capabilitiesv1beta1.Product) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("product", productResource.Name)
+
+	if productResource.IsRemoteDeletionEnabled() && productResource.Status.ID != nil {
+		err := r.deleteRemoteProduct(productResource)
+		switch {
+		case controllerhelper.IsProviderAccountNotFound(err):
+			// Without provider account the 3scale product cannot be deleted, it is orphaned
+			logger.Info("3scale product not deleted, provider account not found", "error", err.Error())
+			r.EventRecorder().Eventf(productResource, corev1.EventTypeWarning, "Orphaned", "3scale product [%d] not deleted: %v", *productResource.Status.ID, err)
+		case err != nil:
+			logger.Error(err, "Failed to delete 3scale product")
+			r.EventRecorder().Eventf(productResource, corev1.EventTypeWarning, "DeletionError", "%v", err)
+
+			productResource.Status.Conditions.SetCondition(common.Condition{
+				Type:    capabilitiesv1beta1.ProductFailedConditionType,
+				Status:  corev1.ConditionTrue,
+				Message: err.Error(),
+			})
+			statusUpdateErr := r.UpdateResourceStatus(productResource)
+			if statusUpdateErr != nil {
+				return ctrl.Result{}, fmt.Errorf("Failed to delete product: %v. Failed to update product status: %w", err, statusUpdateErr)
+			}
+
+			return ctrl.Result{}, err
+		default:
+			r.EventRecorder().Eventf(productResource, corev1.EventTypeNormal, "Deleted", "3scale product [%d] deleted", *productResource.Status.ID)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(productResource, capabilitiesv1beta1.ProductFinalizer)
+	err := r.UpdateResource(productResource)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("Failed removing product finalizer: %w", err)
+	}
+
+	logger.Info("resource finalizer removed")
+	return ctrl.Result{}, nil
+}
+
+func (r *ProductReconciler) deleteRemoteProduct(productResource *capabilitiesv1beta1.Product) error {
+	logger := r.Logger().WithValues("product", productResource.Name)
+
+	providerAccount, err := controllerhelper.LookupProviderAccountWithCRRef(r.Client(), productResource.Namespace, productResource.Spec.ProviderAccountCRRef, productResource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		return err
+	}
+
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount)
+	if err != nil {
+		return err
+	}
+
+	err = threescaleAPIClient.DeleteProduct(*productResource.Status.ID)
+	if err != nil && !threescaleapi.IsNotFound(err) {
+		return fmt.Errorf("product [%s] delete request: %w", productResource.Spec.SystemName, err)
+	}
+
+	return nil
+}
+
 func (r *ProductReconciler) validateSpec(resource *capabilitiesv1beta1.Product) error {
 	errors := field.ErrorList{}
 	errors = append(errors, resource.Validate()...)
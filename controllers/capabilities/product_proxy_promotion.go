@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// proxyConfigSmokeCheckRetryPeriod is the time to wait before checking again
+// the staging gateway after a failed smoke check
+const proxyConfigSmokeCheckRetryPeriod = 30 * time.Second
+
+// reconcileProxyConfigPromotion promotes the staging proxy configuration to production as requested by the product spec.
+// When a smoke check is set, the staging gateway must pass it before promoting.
+// It must only be called once the product has been synchronized.
+// Returns the promotion and smoke check status to be reported.
+func (r *ProductReconciler) reconcileProxyConfigPromotion(resource *capabilitiesv1beta1.Product, entity *controllerhelper.ProductEntity) (*capabilitiesv1beta1.ProxyConfigPromotionStatus, *capabilitiesv1beta1.ProxyConfigSmokeCheckStatus, error) {
+	logger := r.Logger().WithValues("product", resource.Name)
+	currentStatus := resource.Status.ProxyConfigPromotion
+	currentSmokeCheck := resource.Status.ProxyConfigSmokeCheck
+
+	if resource.Spec.ProxyConfigPromotion == nil {
+		return currentStatus, currentSmokeCheck, nil
+	}
+
+	latestStagingVersion, err := entity.LatestProxyConfigVersion(controllerhelper.ProxyConfigStagingEnvironment)
+	if err != nil {
+		return currentStatus, currentSmokeCheck, err
+	}
+
+	if latestStagingVersion == nil {
+		// Nothing to promote
+		return currentStatus, currentSmokeCheck, nil
+	}
+
+	stagingVersion := *latestStagingVersion
+	if resource.Spec.ProxyConfigPromotion.StagingVersion != nil {
+		stagingVersion = *resource.Spec.ProxyConfigPromotion.StagingVersion
+		if stagingVersion > *latestStagingVersion {
+			fieldErr := field.Invalid(
+				field.NewPath("spec").Child("proxyConfigPromotion").Child("stagingVersion"),
+				stagingVersion,
+				"staging proxy config version does not exist",
+			)
+			return currentStatus, currentSmokeCheck, &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: field.ErrorList{fieldErr},
+			}
+		}
+	}
+
+	latestProductionVersion, err := entity.LatestProxyConfigVersion(controllerhelper.ProxyConfigProductionEnvironment)
+	if err != nil {
+		return currentStatus, currentSmokeCheck, err
+	}
+
+	// Production is not promoted again unless it has been changed out of the operator
+	if currentStatus != nil && currentStatus.StagingVersion == stagingVersion &&
+		latestProductionVersion != nil && *latestProductionVersion == currentStatus.ProductionVersion {
+		return currentStatus, currentSmokeCheck, nil
+	}
+
+	smokeCheck := currentSmokeCheck
+	if resource.Spec.ProxyConfigPromotion.SmokeCheck != nil {
+		if proxyConfigSmokeCheckRetryAfter(resource) > 0 {
+			// The staging gateway failed the check recently, wait before checking again
+			return currentStatus, currentSmokeCheck, nil
+		}
+
+		smokeCheck, err = r.runProxyConfigSmokeCheck(resource, entity, stagingVersion)
+		if err != nil {
+			return currentStatus, currentSmokeCheck, err
+		}
+
+		if !smokeCheck.Passed {
+			logger.Info("Staging gateway smoke check failed", "stagingVersion", stagingVersion, "message", smokeCheck.Message)
+			r.EventRecorder().Eventf(resource, corev1.EventTypeWarning, "SmokeCheckFailed",
+				"staging proxy config version [%d] not promoted: %s", stagingVersion, smokeCheck.Message)
+			return currentStatus, smokeCheck, nil
+		}
+	}
+
+	logger.Info("Promoting proxy config to production", "stagingVersion", stagingVersion)
+	productionVersion, err := entity.PromoteProxyToProduction(stagingVersion)
+	if err != nil {
+		return currentStatus, smokeCheck, err
+	}
+
+	r.EventRecorder().Eventf(resource, corev1.EventTypeNormal, "Promoted",
+		"staging proxy config version [%d] promoted to production version [%d]", stagingVersion, productionVersion)
+
+	return &capabilitiesv1beta1.ProxyConfigPromotionStatus{
+		StagingVersion:    stagingVersion,
+		ProductionVersion: productionVersion,
+	}, smokeCheck, nil
+}
+
+// runProxyConfigSmokeCheck sends the smoke check request to the staging gateway.
+// The staging public base URL configured in 3scale is checked unless the spec sets an URL
+func (r *ProductReconciler) runProxyConfigSmokeCheck(resource *capabilitiesv1beta1.Product, entity *controllerhelper.ProductEntity, stagingVersion int64) (*capabilitiesv1beta1.ProxyConfigSmokeCheckStatus, error) {
+	spec := resource.Spec.ProxyConfigPromotion.SmokeCheck
+
+	smokeCheck := &controllerhelper.SmokeCheck{InsecureSkipVerify: spec.InsecureSkipVerify}
+
+	if spec.URL != nil {
+		smokeCheck.BaseURL = *spec.URL
+	} else {
+		proxy, err := entity.Proxy()
+		if err != nil {
+			return nil, err
+		}
+		smokeCheck.BaseURL = proxy.Element.SandboxEndpoint
+	}
+
+	if spec.Path != nil {
+		smokeCheck.Path = *spec.Path
+	}
+
+	if spec.ExpectedStatusCode != nil {
+		smokeCheck.ExpectedStatusCode = int(*spec.ExpectedStatusCode)
+	}
+
+	if spec.TimeoutSeconds != nil {
+		smokeCheck.Timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+
+	if spec.HeadersSecretRef != nil {
+		headers, err := r.proxyConfigSmokeCheckHeaders(resource)
+		if err != nil {
+			return nil, err
+		}
+		smokeCheck.Headers = headers
+	}
+
+	result := smokeCheck.Run()
+
+	return &capabilitiesv1beta1.ProxyConfigSmokeCheckStatus{
+		StagingVersion: stagingVersion,
+		Passed:         result.Passed,
+		StatusCode:     int32(result.StatusCode),
+		Message:        result.Message,
+		LastCheckTime:  metav1.Now(),
+	}, nil
+}
+
+func (r *ProductReconciler) proxyConfigSmokeCheckHeaders(resource *capabilitiesv1beta1.Product) (map[string]string, error) {
+	secretRef := resource.Spec.ProxyConfigPromotion.SmokeCheck.HeadersSecretRef
+	secretRefFldPath := field.NewPath("spec").Child("proxyConfigPromotion").Child("smokeCheck").Child("headersSecretRef")
+
+	secret := &corev1.Secret{}
+	objectKey := types.NamespacedName{Name: secretRef.Name, Namespace: resource.Namespace}
+	if err := r.Client().Get(r.Context(), objectKey, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: field.ErrorList{field.Invalid(secretRefFldPath, secretRef, "Secret not found")},
+			}
+		}
+
+		// unexpected error
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	for name, value := range secret.Data {
+		headers[name] = string(value)
+	}
+
+	return headers, nil
+}
+
+// proxyConfigSmokeCheckRetryAfter returns the time to wait before checking again the staging gateway.
+// Zero when the staging proxy configuration is not waiting for a failed smoke check to be retried
+func proxyConfigSmokeCheckRetryAfter(resource *capabilitiesv1beta1.Product) time.Duration {
+	if resource.Spec.ProxyConfigPromotion == nil || resource.Spec.ProxyConfigPromotion.SmokeCheck == nil {
+		return 0
+	}
+
+	smokeCheck := resource.Status.ProxyConfigSmokeCheck
+	if smokeCheck == nil || smokeCheck.Passed {
+		return 0
+	}
+
+	promotion := resource.Status.ProxyConfigPromotion
+	if promotion != nil && promotion.StagingVersion == smokeCheck.StagingVersion {
+		return 0
+	}
+
+	retryAfter := proxyConfigSmokeCheckRetryPeriod - time.Since(smokeCheck.LastCheckTime.Time)
+	if retryAfter < 0 {
+		return 0
+	}
+
+	return retryAfter
+}
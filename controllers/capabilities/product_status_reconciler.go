@@ -22,6 +22,15 @@ type ProductStatusReconciler struct {
 	providerAccountHost string
 	syncError           error
 	logger              logr.Logger
+	// proxyConfigPromotion is the outcome of the proxy config promotion.
+	// When nil, the currently reported promotion is kept
+	proxyConfigPromotion *capabilitiesv1beta1.ProxyConfigPromotionStatus
+	// proxyConfigSmokeCheck is the outcome of the staging gateway smoke check.
+	// When nil, the currently reported smoke check is kept
+	proxyConfigSmokeCheck *capabilitiesv1beta1.ProxyConfigSmokeCheckStatus
+	// drift holds the changes computed in observe mode.
+	// nil when the product is reconciled in sync mode
+	drift *controllerhelper.DriftRecorder
 }
 
 func NewProductStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, entity *controllerhelper.ProductEntity, providerAccountHost string, syncError error) *ProductStatusReconciler {
@@ -82,6 +91,16 @@ func (s *ProductStatusReconciler) calculateStatus() *capabilitiesv1beta1.Product
 
 	newStatus.ProviderAccountHost = s.providerAccountHost
 
+	newStatus.ProxyConfigPromotion = s.resource.Status.ProxyConfigPromotion
+	if s.proxyConfigPromotion != nil {
+		newStatus.ProxyConfigPromotion = s.proxyConfigPromotion
+	}
+
+	newStatus.ProxyConfigSmokeCheck = s.resource.Status.ProxyConfigSmokeCheck
+	if s.proxyConfigSmokeCheck != nil {
+		newStatus.ProxyConfigSmokeCheck = s.proxyConfigSmokeCheck
+	}
+
 	newStatus.ObservedGeneration = s.resource.Status.ObservedGeneration
 
 	newStatus.Conditions = s.resource.Status.Conditions.Copy()
@@ -89,6 +108,13 @@ func (s *ProductStatusReconciler) calculateStatus() *capabilitiesv1beta1.Product
 	newStatus.Conditions.SetCondition(s.orphanCondition())
 	newStatus.Conditions.SetCondition(s.invalidCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
+	newStatus.Conditions.SetCondition(s.throttledCondition())
+	if s.drift == nil {
+		newStatus.Conditions.RemoveCondition(capabilitiesv1beta1.ProductDriftedConditionType)
+	} else if s.syncError == nil {
+		// drift is only complete when there are no errors
+		newStatus.Conditions.SetCondition(s.driftedCondition())
+	}
 
 	return newStatus
 }
@@ -99,13 +125,28 @@ func (s *ProductStatusReconciler) syncCondition() common.Condition {
 		Status: corev1.ConditionFalse,
 	}
 
-	if s.syncError == nil {
+	// In observe mode, 3scale is in sync only when there is no drift
+	if s.syncError == nil && !s.drift.HasDrift() {
 		condition.Status = corev1.ConditionTrue
 	}
 
 	return condition
 }
 
+func (s *ProductStatusReconciler) driftedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.ProductDriftedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.drift.HasDrift() {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.drift.Summary()
+	}
+
+	return condition
+}
+
 func (s *ProductStatusReconciler) orphanCondition() common.Condition {
 	condition := common.Condition{
 		Type:   capabilitiesv1beta1.ProductOrphanConditionType,
@@ -148,3 +189,18 @@ func (s *ProductStatusReconciler) failedCondition() common.Condition {
 
 	return condition
 }
+
+func (s *ProductStatusReconciler) throttledCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.ProductThrottledConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if throttling := controllerhelper.AdminAPIThrottlingFor(s.providerAccountHost); throttling != nil {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = common.ConditionReason(throttling.Reason)
+		condition.Message = throttling.Message
+	}
+
+	return condition
+}
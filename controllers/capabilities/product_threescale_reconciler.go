@@ -18,14 +18,18 @@ type ProductThreescaleReconciler struct {
 	productEntity       *controllerhelper.ProductEntity
 	backendRemoteIndex  *controllerhelper.BackendAPIRemoteIndex
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
-	logger              logr.Logger
+	oidcAPIClient       *controllerhelper.OIDCAPIClient
+	// drift enables observe mode when set
+	drift  *controllerhelper.DriftRecorder
+	logger logr.Logger
 }
 
-func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
+func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, oidcAPIClient *controllerhelper.OIDCAPIClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
 	return &ProductThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
+		oidcAPIClient:       oidcAPIClient,
 		backendRemoteIndex:  backendRemoteIndex,
 		logger:              b.Logger().WithValues("3scale Reconciler", resource.Name),
 	}
@@ -36,6 +40,11 @@ func (t *ProductThreescaleReconciler) Reconcile() (*controllerhelper.ProductEnti
 	if err != nil {
 		return nil, err
 	}
+	if productEntity == nil {
+		// observe mode: the product does not exist
+		return nil, nil
+	}
+	productEntity.SetDriftRecorder(t.drift)
 	t.productEntity = productEntity
 
 	taskRunner := helper.NewTaskRunner(nil, t.logger)
@@ -79,6 +88,9 @@ func (t *ProductThreescaleReconciler) reconcile3scaleProduct() (*controllerhelpe
 	var productObj *threescaleapi.Product
 	if exists {
 		productObj = &productList.Products[idx]
+	} else if t.drift != nil {
+		t.drift.Record("product [%s] create", t.resource.Spec.SystemName)
+		return nil, nil
 	} else {
 		// Create product using system_name.
 		// it cannot be modified later
@@ -0,0 +1,524 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// invalidResourceNameChars matches the system name characters not allowed in custom resource names
+var invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ProductImportReconciler reconciles a ProductImport object
+type ProductImportReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that ProductImportReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ProductImportReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=productimports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=productimports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=productimports/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,namespace=placeholder,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,namespace=placeholder,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *ProductImportReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	_ = context.Background()
+	reqLogger := r.Logger().WithValues("productimport", req.NamespacedName)
+	reqLogger.Info("Reconcile ProductImport", "Operator version", version.Version)
+
+	// Fetch the ProductImport instance
+	productImport := &capabilitiesv1beta1.ProductImport{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, productImport)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(productImport, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// Ignore deleted ProductImports, this can happen when foregroundDeletion is enabled
+	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+	if productImport.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	// One-shot: the import only runs again when the spec changes
+	if productImport.IsCompleted() {
+		reqLogger.Info("import already completed")
+		return ctrl.Result{}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcile(productImport)
+	metrics.ObserveReconcile(capabilitiesv1beta1.ProductImportKind, reconcileErr, false)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed to import products: %v. Failed to update product import status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("Failed to update product import status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			// On Validation error, no need to retry as spec is not valid and needs to be changed
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(productImport, corev1.EventTypeWarning, "Invalid ProductImport Spec", "%v", reconcileErr)
+			return ctrl.Result{}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(productImport, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+	} else {
+		r.EventRecorder().Eventf(productImport, corev1.EventTypeNormal, "Imported", "%d products and %d backends imported",
+			len(statusReconciler.products), len(statusReconciler.backends))
+	}
+
+	reqLogger.Info("END", "error", reconcileErr)
+	return ctrl.Result{}, reconcileErr
+}
+
+func (r *ProductImportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.ProductImport{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+func (r *ProductImportReconciler) reconcile(resource *capabilitiesv1beta1.ProductImport) (*ProductImportStatusReconciler, error) {
+	logger := r.Logger().WithValues("productimport", resource.Name)
+	statusReconciler := NewProductImportStatusReconciler(r.BaseReconciler, resource)
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), resource.Namespace, resource.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		statusReconciler.syncError = err
+		return statusReconciler, err
+	}
+	statusReconciler.providerAccountHost = providerAccount.AdminURLStr
+
+	threescaleAPIClient, err := controllerhelper.PortaClient(providerAccount)
+	if err != nil {
+		statusReconciler.syncError = err
+		return statusReconciler, err
+	}
+
+	oidcAPIClient, err := controllerhelper.NewOIDCAPIClient(providerAccount)
+	if err != nil {
+		statusReconciler.syncError = err
+		return statusReconciler, err
+	}
+
+	backendRemoteIndex, err := controllerhelper.NewBackendAPIRemoteIndex(threescaleAPIClient, logger)
+	if err != nil {
+		statusReconciler.syncError = err
+		return statusReconciler, err
+	}
+
+	productEntities, err := r.selectProducts(resource, threescaleAPIClient, logger)
+	if err != nil {
+		statusReconciler.syncError = err
+		return statusReconciler, err
+	}
+
+	importer := &productImporter{
+		BaseReconciler:     r.BaseReconciler,
+		resource:           resource,
+		providerAccount:    providerAccount,
+		backendRemoteIndex: backendRemoteIndex,
+		oidcAPIClient:      oidcAPIClient,
+		backends:           map[string]capabilitiesv1beta1.ImportedBackendStatus{},
+		logger:             logger,
+	}
+
+	for _, productEntity := range productEntities {
+		productStatus, err := importer.importProduct(productEntity)
+		if err != nil {
+			statusReconciler.syncError = err
+			break
+		}
+		statusReconciler.products = append(statusReconciler.products, *productStatus)
+	}
+	statusReconciler.backends = importer.backendStatusList()
+
+	return statusReconciler, statusReconciler.syncError
+}
+
+// selectProducts returns the 3scale products selected by the spec
+func (r *ProductImportReconciler) selectProducts(resource *capabilitiesv1beta1.ProductImport, threescaleAPIClient *threescaleapi.ThreeScaleClient, logger logr.Logger) ([]*controllerhelper.ProductEntity, error) {
+	productList, err := threescaleAPIClient.ListProducts()
+	if err != nil {
+		return nil, fmt.Errorf("product import [%s] list products: %w", resource.Name, err)
+	}
+
+	productIndex := map[string]*threescaleapi.Product{}
+	for idx := range productList.Products {
+		productIndex[productList.Products[idx].Element.SystemName] = &productList.Products[idx]
+	}
+
+	var productEntities []*controllerhelper.ProductEntity
+	if len(resource.Spec.ProductSystemNames) == 0 {
+		for idx := range productList.Products {
+			productEntities = append(productEntities, controllerhelper.NewProductEntity(&productList.Products[idx], threescaleAPIClient, logger))
+		}
+		return productEntities, nil
+	}
+
+	fieldErrors := field.ErrorList{}
+	systemNamesFldPath := field.NewPath("spec").Child("productSystemNames")
+	for idx, systemName := range resource.Spec.ProductSystemNames {
+		productObj, ok := productIndex[systemName]
+		if !ok {
+			fieldErrors = append(fieldErrors, field.Invalid(systemNamesFldPath.Index(idx), systemName, "3scale product not found"))
+			continue
+		}
+		productEntities = append(productEntities, controllerhelper.NewProductEntity(productObj, threescaleAPIClient, logger))
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, &helper.SpecFieldError{
+			ErrorType:      helper.InvalidError,
+			FieldErrorList: fieldErrors,
+		}
+	}
+
+	return productEntities, nil
+}
+
+// productImporter generates, and optionally adopts, the custom resources of the 3scale products
+type productImporter struct {
+	*reconcilers.BaseReconciler
+	resource           *capabilitiesv1beta1.ProductImport
+	providerAccount    *controllerhelper.ProviderAccount
+	backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex
+	oidcAPIClient      *controllerhelper.OIDCAPIClient
+	// backends already imported, indexed by system name
+	backends map[string]capabilitiesv1beta1.ImportedBackendStatus
+	logger   logr.Logger
+}
+
+func (p *productImporter) importProduct(productEntity *controllerhelper.ProductEntity) (*capabilitiesv1beta1.ImportedProductStatus, error) {
+	imported, err := controllerhelper.ImportProduct(productEntity, p.backendRemoteIndex, p.oidcAPIClient)
+	if err != nil {
+		return nil, fmt.Errorf("Error importing product [%d]: %w", productEntity.ID(), err)
+	}
+
+	product := &capabilitiesv1beta1.Product{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+			Kind:       capabilitiesv1beta1.ProductKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      importResourceName(imported.Spec.SystemName),
+			Namespace: p.resource.Namespace,
+		},
+		Spec: imported.Spec,
+	}
+	product.Spec.ProviderAccountRef = p.resource.Spec.ProviderAccountRef
+	// The 3scale product existed before the custom resource
+	product.Spec.DeletionPolicy = capabilitiesv1beta1.DeletionPolicyOrphan
+	if oidcSpec := product.Spec.OIDCSpec(); oidcSpec != nil {
+		oidcSpec.IssuerEndpointRef.Name = product.Name + capabilitiesv1beta1.ProductImportOIDCIssuerEndpointSecretSuffix
+	}
+
+	manifests := map[string]string{}
+	err = addManifest(manifests, capabilitiesv1beta1.ProductImportProductManifestKey, product)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make([]*capabilitiesv1beta1.Backend, 0, len(product.Spec.BackendUsages))
+	for backendSystemName := range product.Spec.BackendUsages {
+		backendEntity, ok := p.backendRemoteIndex.FindBySystemName(backendSystemName)
+		if !ok {
+			return nil, fmt.Errorf("Error importing product [%s]: backend [%s] not found", product.Spec.SystemName, backendSystemName)
+		}
+
+		backendSpec, err := controllerhelper.ImportBackendSpec(backendEntity)
+		if err != nil {
+			return nil, fmt.Errorf("Error importing backend [%s]: %w", backendSystemName, err)
+		}
+
+		backend := &capabilitiesv1beta1.Backend{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: capabilitiesv1beta1.GroupVersion.String(),
+				Kind:       capabilitiesv1beta1.BackendKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      importResourceName(backendSystemName),
+				Namespace: p.resource.Namespace,
+			},
+			Spec: *backendSpec,
+		}
+		backend.Spec.ProviderAccountRef = p.resource.Spec.ProviderAccountRef
+		// The 3scale backend existed before the custom resource
+		backend.Spec.DeletionPolicy = capabilitiesv1beta1.DeletionPolicyOrphan
+
+		err = addManifest(manifests, fmt.Sprintf("backend-%s.yaml", backend.Name), backend)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, backend)
+	}
+
+	configMapName := fmt.Sprintf("%s-%s", p.resource.Name, product.Name)
+	err = p.reconcileManifestsConfigMap(configMapName, manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	// Backends first, they are referenced by the product
+	for _, backend := range backends {
+		if _, ok := p.backends[backend.Spec.SystemName]; ok {
+			continue
+		}
+
+		backendEntity, _ := p.backendRemoteIndex.FindBySystemName(backend.Spec.SystemName)
+		backendStatus := capabilitiesv1beta1.ImportedBackendStatus{
+			ID:           backendEntity.ID(),
+			SystemName:   backend.Spec.SystemName,
+			ResourceName: backend.Name,
+		}
+
+		if p.resource.Spec.Adopt {
+			err = p.adoptBackend(backend, backendEntity.ID())
+			if err != nil {
+				return nil, err
+			}
+			backendStatus.Adopted = true
+		}
+
+		p.backends[backend.Spec.SystemName] = backendStatus
+	}
+
+	productStatus := &capabilitiesv1beta1.ImportedProductStatus{
+		ID:                 productEntity.ID(),
+		SystemName:         product.Spec.SystemName,
+		ResourceName:       product.Name,
+		ManifestsConfigMap: configMapName,
+	}
+
+	if p.resource.Spec.Adopt {
+		err = p.adoptProduct(product, productEntity.ID(), imported.OIDCIssuerEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		productStatus.Adopted = true
+	}
+
+	p.logger.Info("product imported", "systemName", productStatus.SystemName, "adopted", productStatus.Adopted)
+
+	return productStatus, nil
+}
+
+func (p *productImporter) reconcileManifestsConfigMap(name string, manifests map[string]string) error {
+	desired := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: p.resource.Namespace,
+			Labels:    map[string]string{"app": "3scale-api-management"},
+		},
+		Data: manifests,
+	}
+
+	err := p.SetOwnerReference(p.resource, desired)
+	if err != nil {
+		return err
+	}
+
+	return p.ReconcileResource(&corev1.ConfigMap{}, desired, productImportManifestsMutator)
+}
+
+// adoptBackend creates the Backend custom resource pointing to the 3scale backend.
+// Existing custom resources for the same 3scale backend are kept
+func (p *productImporter) adoptBackend(backend *capabilitiesv1beta1.Backend, backendID int64) error {
+	existing := &capabilitiesv1beta1.Backend{}
+	err := p.GetResource(types.NamespacedName{Name: backend.Name, Namespace: backend.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil {
+		if existing.Spec.SystemName != backend.Spec.SystemName {
+			return fmt.Errorf("Error adopting backend [%s]: Backend [%s] already exists for backend [%s]", backend.Spec.SystemName, existing.Name, existing.Spec.SystemName)
+		}
+		return nil
+	}
+
+	err = p.CreateResource(backend)
+	if err != nil {
+		return fmt.Errorf("Error adopting backend [%s]: %w", backend.Spec.SystemName, err)
+	}
+
+	backend.Status.ID = &backendID
+	backend.Status.ProviderAccountHost = p.providerAccount.AdminURLStr
+	err = p.UpdateResourceStatus(backend)
+	if err != nil {
+		return fmt.Errorf("Error adopting backend [%s]: %w", backend.Spec.SystemName, err)
+	}
+
+	return nil
+}
+
+// adoptProduct creates the Product custom resource pointing to the 3scale product.
+// Existing custom resources for the same 3scale product are kept
+func (p *productImporter) adoptProduct(product *capabilitiesv1beta1.Product, productID int64, oidcIssuerEndpoint string) error {
+	existing := &capabilitiesv1beta1.Product{}
+	err := p.GetResource(types.NamespacedName{Name: product.Name, Namespace: product.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil {
+		if existing.Spec.SystemName != product.Spec.SystemName {
+			return fmt.Errorf("Error adopting product [%s]: Product [%s] already exists for product [%s]", product.Spec.SystemName, existing.Name, existing.Spec.SystemName)
+		}
+		return nil
+	}
+
+	if oidcSpec := product.Spec.OIDCSpec(); oidcSpec != nil {
+		issuerEndpointSecret := &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      oidcSpec.IssuerEndpointRef.Name,
+				Namespace: product.Namespace,
+			},
+			StringData: map[string]string{
+				capabilitiesv1beta1.OIDCIssuerEndpointSecretField: oidcIssuerEndpoint,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		err = p.ReconcileResource(&corev1.Secret{}, issuerEndpointSecret, reconcilers.CreateOnlyMutator)
+		if err != nil {
+			return fmt.Errorf("Error adopting product [%s]: %w", product.Spec.SystemName, err)
+		}
+	}
+
+	err = p.CreateResource(product)
+	if err != nil {
+		return fmt.Errorf("Error adopting product [%s]: %w", product.Spec.SystemName, err)
+	}
+
+	product.Status.ID = &productID
+	product.Status.ProviderAccountHost = p.providerAccount.AdminURLStr
+	err = p.UpdateResourceStatus(product)
+	if err != nil {
+		return fmt.Errorf("Error adopting product [%s]: %w", product.Spec.SystemName, err)
+	}
+
+	return nil
+}
+
+func (p *productImporter) backendStatusList() []capabilitiesv1beta1.ImportedBackendStatus {
+	systemNames := make([]string, 0, len(p.backends))
+	for systemName := range p.backends {
+		systemNames = append(systemNames, systemName)
+	}
+	sort.Strings(systemNames)
+
+	result := make([]capabilitiesv1beta1.ImportedBackendStatus, 0, len(systemNames))
+	for _, systemName := range systemNames {
+		result = append(result, p.backends[systemName])
+	}
+
+	return result
+}
+
+func addManifest(manifests map[string]string, key string, obj common.KubernetesObject) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("Error serializing %s: %w", key, err)
+	}
+
+	manifests[key] = string(data)
+	return nil
+}
+
+func productImportManifestsMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*corev1.ConfigMap)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *corev1.ConfigMap", existingObj)
+	}
+	desired, ok := desiredObj.(*corev1.ConfigMap)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *corev1.ConfigMap", desiredObj)
+	}
+
+	if reflect.DeepEqual(existing.Data, desired.Data) {
+		return false, nil
+	}
+
+	existing.Data = desired.Data
+	return true, nil
+}
+
+// importResourceName returns a valid custom resource name for the 3scale system name
+func importResourceName(systemName string) string {
+	name := invalidResourceNameChars.ReplaceAllString(strings.ToLower(systemName), "-")
+	return strings.Trim(name, "-")
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+)
+
+// ProviderAccountReconciler reconciles a ProviderAccount object
+type ProviderAccountReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that ProviderAccountReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ProviderAccountReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=provideraccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=provideraccounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=provideraccounts/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *ProviderAccountReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	_ = context.Background()
+	reqLogger := r.Logger().WithValues("provideraccount", req.NamespacedName)
+	reqLogger.Info("Reconcile ProviderAccount", "Operator version", version.Version)
+
+	// Fetch the ProviderAccount instance
+	providerAccountCR := &capabilitiesv1beta1.ProviderAccount{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, providerAccountCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(providerAccountCR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// Ignore deleted ProviderAccounts, this can happen when foregroundDeletion is enabled
+	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+	if providerAccountCR.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	providerAccountHost, reconcileErr := r.reconcile(providerAccountCR)
+	metrics.ObserveReconcile(capabilitiesv1beta1.ProviderAccountKind, reconcileErr, false)
+	statusReconciler := NewProviderAccountStatusReconciler(r.BaseReconciler, providerAccountCR, providerAccountHost, reconcileErr)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed to validate provider account: %v. Failed to update provider account status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("Failed to update provider account status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(providerAccountCR, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+	}
+
+	reqLogger.Info("END", "error", reconcileErr)
+	return ctrl.Result{}, reconcileErr
+}
+
+func (r *ProviderAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.ProviderAccount{}).
+		Complete(r)
+}
+
+// reconcile validates the credentials secret. Returns the admin portal URL
+func (r *ProviderAccountReconciler) reconcile(resource *capabilitiesv1beta1.ProviderAccount) (string, error) {
+	providerAccount, err := controllerhelper.ProviderAccountFromSecret(r.Client(), resource.Namespace, resource.Spec.CredentialsRef.Name)
+	if err != nil {
+		return "", err
+	}
+
+	// Validates admin URL and TLS settings
+	_, err = controllerhelper.PortaClient(providerAccount)
+	if err != nil {
+		return providerAccount.AdminURLStr, err
+	}
+
+	return providerAccount.AdminURLStr, nil
+}
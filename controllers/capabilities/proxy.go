@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
@@ -86,15 +88,123 @@ func (t *ProductThreescaleReconciler) syncProxy(_ interface{}) error {
 
 	t.syncProxyGatewayResponse(params, existing)
 
+	err = t.syncProxyOIDC(params)
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] proxy: %w", t.resource.Spec.SystemName, err)
+	}
+
 	if len(params) > 0 {
 		err := t.productEntity.UpdateProxy(params)
 		if err != nil {
 			return fmt.Errorf("Error updating product proxy: %w", err)
 		}
 	}
+
+	err = t.syncProxyOIDCConfiguration()
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] proxy: %w", t.resource.Spec.SystemName, err)
+	}
+
+	return nil
+}
+
+func (t *ProductThreescaleReconciler) syncProxyOIDC(params threescaleapi.Params) error {
+	oidcSpec := t.resource.Spec.OIDCSpec()
+	if oidcSpec == nil {
+		return nil
+	}
+
+	existing, err := t.oidcAPIClient.ProxyOIDC(t.productEntity.ID())
+	if err != nil {
+		return err
+	}
+
+	issuerEndpoint, err := t.oidcIssuerEndpoint(oidcSpec)
+	if err != nil {
+		return err
+	}
+
+	if existing.OidcIssuerEndpoint != issuerEndpoint {
+		params["oidc_issuer_endpoint"] = issuerEndpoint
+	}
+
+	if existing.OidcIssuerType != oidcSpec.IssuerType {
+		params["oidc_issuer_type"] = oidcSpec.IssuerType
+	}
+
+	if oidcSpec.JwtClaimWithClientID != nil && existing.JwtClaimWithClientID != *oidcSpec.JwtClaimWithClientID {
+		params["jwt_claim_with_client_id"] = *oidcSpec.JwtClaimWithClientID
+	}
+
+	if oidcSpec.JwtClaimWithClientIDType != nil && existing.JwtClaimWithClientIDType != *oidcSpec.JwtClaimWithClientIDType {
+		params["jwt_claim_with_client_id_type"] = *oidcSpec.JwtClaimWithClientIDType
+	}
+
+	return nil
+}
+
+func (t *ProductThreescaleReconciler) syncProxyOIDCConfiguration() error {
+	oidcSpec := t.resource.Spec.OIDCSpec()
+	if oidcSpec == nil || oidcSpec.AuthenticationFlow == nil {
+		return nil
+	}
+
+	existing, err := t.oidcAPIClient.OIDCConfiguration(t.productEntity.ID())
+	if err != nil {
+		return err
+	}
+
+	params := threescaleapi.Params{}
+
+	flowCases := []struct {
+		desired  bool
+		existing bool
+		field    string
+	}{
+		{
+			oidcSpec.AuthenticationFlow.StandardFlowEnabled,
+			existing.StandardFlowEnabled,
+			"standard_flow_enabled",
+		},
+		{
+			oidcSpec.AuthenticationFlow.ImplicitFlowEnabled,
+			existing.ImplicitFlowEnabled,
+			"implicit_flow_enabled",
+		},
+		{
+			oidcSpec.AuthenticationFlow.ServiceAccountsEnabled,
+			existing.ServiceAccountsEnabled,
+			"service_accounts_enabled",
+		},
+		{
+			oidcSpec.AuthenticationFlow.DirectAccessGrantsEnabled,
+			existing.DirectAccessGrantsEnabled,
+			"direct_access_grants_enabled",
+		},
+	}
+	for _, flowCase := range flowCases {
+		if flowCase.desired != flowCase.existing {
+			params[flowCase.field] = strconv.FormatBool(flowCase.desired)
+		}
+	}
+
+	if len(params) > 0 && t.drift != nil {
+		t.drift.Record("product [%s] update oidc configuration %s", t.resource.Spec.SystemName, controllerhelper.FormatDriftParams(params))
+	} else if len(params) > 0 {
+		_, err := t.oidcAPIClient.UpdateOIDCConfiguration(t.productEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("Error updating product oidc configuration: %w", err)
+		}
+	}
+
 	return nil
 }
 
+func (t *ProductThreescaleReconciler) oidcIssuerEndpoint(oidcSpec *capabilitiesv1beta1.OIDCSpec) (string, error) {
+	secretSource := helper.NewSecretSource(t.Client(), t.resource.Namespace)
+	return secretSource.RequiredFieldValueFromRequiredSecret(oidcSpec.IssuerEndpointRef.Name, capabilitiesv1beta1.OIDCIssuerEndpointSecretField)
+}
+
 func (t *ProductThreescaleReconciler) syncProxyGatewayResponse(params threescaleapi.Params, existing *threescaleapi.ProxyJSON) {
 	gatewayResponse := t.resource.Spec.GatewayResponse()
 	if gatewayResponse == nil {
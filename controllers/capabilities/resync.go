@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+// resyncJitterFactor spreads the resyncs of resources reconciled at the same time,
+// i.e. when the operator starts, so the admin API does not get all the requests at once
+const resyncJitterFactor = 0.1
+
+// resyncResult returns the result requeueing the resource for the next resync against 3scale.
+// Changes done in 3scale out of the operator are reverted by the resync
+func resyncResult(obj metav1.Object, defaultPeriod time.Duration) ctrl.Result {
+	period := capabilitiesv1beta1.ResyncPeriod(obj, defaultPeriod)
+	if period == 0 {
+		return ctrl.Result{}
+	}
+
+	return ctrl.Result{RequeueAfter: wait.Jitter(period, resyncJitterFactor)}
+}
+
+// isResync returns true when the resource spec has already been synchronized with 3scale,
+// so any change done by the reconciliation corrects a change done in 3scale out of the operator
+func isResync(obj metav1.Object, observedGeneration int64, synced bool) bool {
+	return synced && obj.GetGeneration() == observedGeneration
+}
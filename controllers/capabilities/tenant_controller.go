@@ -19,19 +19,24 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
-	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	porta_client_pkg "github.com/3scale/3scale-porta-go-client/client"
 )
 
 // Secret field name with Tenant's admin user password
@@ -43,24 +48,28 @@ const TenantProviderKeySecretField = "token"
 // Tenant's credentials secret field name for admin domain url
 const TenantAdminDomainKeySecretField = "adminURL"
 
+// 3scale tenant account state when suspended
+const TenantSuspendedAccountState = "suspended"
+
 // TenantReconciler reconciles a Tenant object
 type TenantReconciler struct {
-	Client client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	*reconcilers.BaseReconciler
 }
 
+// blank assignment to verify that TenantReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &TenantReconciler{}
+
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=tenants,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=tenants/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=tenants/finalizers,verbs=get;list;watch;create;update;patch;delete
 
 func (r *TenantReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	_ = context.Background()
-	reqLogger := r.Log.WithValues("tenant", req.NamespacedName)
+	reqLogger := r.Logger().WithValues("tenant", req.NamespacedName)
 
 	// Fetch the Tenant instance
-	tenantR := &capabilitiesv1alpha1.Tenant{}
-	err := r.Client.Get(context.TODO(), req.NamespacedName, tenantR)
+	tenantR := &capabilitiesv1beta1.Tenant{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, tenantR)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -73,9 +82,38 @@ func (r *TenantReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(tenantR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	if tenantR.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(tenantR, capabilitiesv1beta1.TenantFinalizer) {
+			return r.finalize(tenantR)
+		}
+
+		// Ignore deleted Tenants, this can happen when foregroundDeletion is enabled
+		// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(tenantR, capabilitiesv1beta1.TenantFinalizer) {
+		controllerutil.AddFinalizer(tenantR, capabilitiesv1beta1.TenantFinalizer)
+		err := r.UpdateResource(tenantR)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed adding tenant finalizer: %w", err)
+		}
+
+		reqLogger.Info("resource finalizer added. Requeueing.")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	changed := tenantR.SetDefaults()
 	if changed {
-		err = r.Client.Update(context.TODO(), tenantR)
+		err = r.Client().Update(context.TODO(), tenantR)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
@@ -84,26 +122,26 @@ func (r *TenantReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
-	masterAccessToken, err := r.FetchMasterCredentials(r.Client, tenantR)
-	if err != nil {
-		reqLogger.Error(err, "Error fetching master credentials secret")
-		// Error reading the object - requeue the request.
-		return ctrl.Result{}, err
+	statusReconciler, reconcileErr := r.reconcile(tenantR)
+	metrics.ObserveReconcile(capabilitiesv1beta1.TenantKind, reconcileErr, false)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed to sync tenant: %v. Failed to update tenant status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("Failed to update tenant status: %w", statusUpdateErr)
 	}
 
-	portaClient, err := controllerhelper.PortaClientFromURLString(tenantR.Spec.SystemMasterUrl, masterAccessToken)
-	if err != nil {
-		reqLogger.Error(err, "Error creating porta client object")
-		// Error reading the object - requeue the request.
-		return ctrl.Result{}, err
+	if statusResult.Requeue {
+		return statusResult, nil
 	}
 
-	internalReconciler := NewTenantInternalReconciler(r.Client, tenantR, portaClient, reqLogger)
-	err = internalReconciler.Run()
-	if err != nil {
-		reqLogger.Error(err, "Error in tenant reconciliation")
+	if reconcileErr != nil {
+		reqLogger.Error(reconcileErr, "Error in tenant reconciliation")
+		r.EventRecorder().Eventf(tenantR, v1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
 		// Error reading the object - requeue the request.
-		return ctrl.Result{}, err
+		return ctrl.Result{}, reconcileErr
 	}
 
 	reqLogger.Info("Tenant reconciled successfully")
@@ -112,12 +150,91 @@ func (r *TenantReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&capabilitiesv1alpha1.Tenant{}).
+		For(&capabilitiesv1beta1.Tenant{}).
 		Complete(r)
 }
 
-// FetchMasterCredentials get secret using k8s client
-func (r *TenantReconciler) FetchMasterCredentials(k8sClient client.Client, tenantR *capabilitiesv1alpha1.Tenant) (string, error) {
+func (r *TenantReconciler) reconcile(tenantR *capabilitiesv1beta1.Tenant) (*TenantStatusReconciler, error) {
+	logger := r.Logger().WithValues("tenant", tenantR.Name)
+
+	portaClient, err := r.masterPortaClient(tenantR)
+	if err != nil {
+		return NewTenantStatusReconciler(r.BaseReconciler, tenantR, nil, nil, err), err
+	}
+
+	internalReconciler := NewTenantInternalReconciler(r.Client(), tenantR, portaClient, logger)
+	tenantDef, adminUserDef, err := internalReconciler.Run()
+	return NewTenantStatusReconciler(r.BaseReconciler, tenantR, tenantDef, adminUserDef, err), err
+}
+
+// finalize schedules the 3scale tenant for deletion, when required by the deletion policy,
+// and then releases the custom resource by removing the finalizer.
+func (r *TenantReconciler) finalize(tenantR *capabilitiesv1beta1.Tenant) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("tenant", tenantR.Name)
+
+	if tenantR.IsRemoteDeletionEnabled() && tenantR.Status.TenantId != 0 {
+		err := r.deleteRemoteTenant(tenantR)
+		if err != nil {
+			logger.Error(err, "Failed to delete 3scale tenant")
+			r.EventRecorder().Eventf(tenantR, v1.EventTypeWarning, "DeletionError", "%v", err)
+			tenantR.Status.Conditions.SetCondition(common.Condition{
+				Type:    capabilitiesv1beta1.TenantFailedConditionType,
+				Status:  v1.ConditionTrue,
+				Message: err.Error(),
+			})
+			statusUpdateErr := r.UpdateResourceStatus(tenantR)
+			if statusUpdateErr != nil {
+				return ctrl.Result{}, fmt.Errorf("Failed to update tenant status: %w", statusUpdateErr)
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		r.EventRecorder().Eventf(tenantR, v1.EventTypeNormal, "Deleted", "3scale tenant [%d] scheduled for deletion", tenantR.Status.TenantId)
+	}
+
+	controllerutil.RemoveFinalizer(tenantR, capabilitiesv1beta1.TenantFinalizer)
+	err := r.UpdateResource(tenantR)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("Failed removing tenant finalizer: %w", err)
+	}
+
+	logger.Info("resource finalizer removed")
+	return ctrl.Result{}, nil
+}
+
+// deleteRemoteTenant schedules the 3scale tenant for deletion using the master API
+func (r *TenantReconciler) deleteRemoteTenant(tenantR *capabilitiesv1beta1.Tenant) error {
+	portaClient, err := r.masterPortaClient(tenantR)
+	if err != nil {
+		return err
+	}
+
+	err = portaClient.DeleteTenant(tenantR.Status.TenantId)
+	if err != nil && !porta_client_pkg.IsNotFound(err) {
+		return fmt.Errorf("tenant [%d] delete request: %w", tenantR.Status.TenantId, err)
+	}
+
+	return nil
+}
+
+func (r *TenantReconciler) masterPortaClient(tenantR *capabilitiesv1beta1.Tenant) (*porta_client_pkg.ThreeScaleClient, error) {
+	masterAccount, err := r.FetchMasterCredentials(r.Client(), tenantR)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching master credentials secret: %w", err)
+	}
+
+	portaClient, err := controllerhelper.PortaClient(masterAccount)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating porta client object: %w", err)
+	}
+
+	return portaClient, nil
+}
+
+// FetchMasterCredentials get secret using k8s client.
+// The secret may also have the admin API client TLS settings
+func (r *TenantReconciler) FetchMasterCredentials(k8sClient client.Client, tenantR *capabilitiesv1beta1.Tenant) (*controllerhelper.ProviderAccount, error) {
 	masterCredentialsSecret := &v1.Secret{}
 
 	err := k8sClient.Get(context.TODO(),
@@ -129,15 +246,24 @@ func (r *TenantReconciler) FetchMasterCredentials(k8sClient client.Client, tenan
 		masterCredentialsSecret)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	masterAccessTokenByteArray, ok := masterCredentialsSecret.Data[component.SystemSecretSystemSeedMasterAccessTokenFieldName]
 	if !ok {
-		return "", fmt.Errorf("Key not found in master secret (ns: %s, name: %s) key: %s",
+		return nil, fmt.Errorf("Key not found in master secret (ns: %s, name: %s) key: %s",
 			tenantR.Spec.MasterCredentialsRef.Namespace, tenantR.Spec.MasterCredentialsRef.Name,
 			component.SystemSecretSystemSeedMasterAccessTokenFieldName)
 	}
 
-	return bytes.NewBuffer(masterAccessTokenByteArray).String(), nil
+	tlsConfig, err := controllerhelper.AdminAPITLSConfigFromSecretData(masterCredentialsSecret.Name, masterCredentialsSecret.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &controllerhelper.ProviderAccount{
+		AdminURLStr: tenantR.Spec.SystemMasterUrl,
+		Token:       bytes.NewBuffer(masterAccessTokenByteArray).String(),
+		TLS:         tlsConfig,
+	}, nil
 }
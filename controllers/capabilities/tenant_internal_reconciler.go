@@ -4,9 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"reflect"
 
-	apiv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	apiv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
 	porta_client_pkg "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
@@ -20,13 +19,13 @@ import (
 // TenantInternalReconciler reconciles a Tenant object
 type TenantInternalReconciler struct {
 	k8sClient   client.Client
-	tenantR     *apiv1alpha1.Tenant
+	tenantR     *apiv1beta1.Tenant
 	portaClient *porta_client_pkg.ThreeScaleClient
 	logger      logr.Logger
 }
 
 // NewTenantInternalReconciler constructs InternalReconciler object
-func NewTenantInternalReconciler(k8sClient client.Client, tenantR *apiv1alpha1.Tenant,
+func NewTenantInternalReconciler(k8sClient client.Client, tenantR *apiv1beta1.Tenant,
 	portaClient *porta_client_pkg.ThreeScaleClient, log logr.Logger) *TenantInternalReconciler {
 	return &TenantInternalReconciler{
 		k8sClient:   k8sClient,
@@ -41,25 +40,26 @@ func NewTenantInternalReconciler(k8sClient client.Client, tenantR *apiv1alpha1.T
 // - Have 3scale Tenant Account
 // - Have active admin user
 // - Have secret with tenant's access_token
-func (r *TenantInternalReconciler) Run() error {
+// - Have tenant account in the desired state (active/suspended)
+// Tenant and admin user found so far are returned even on error, so they can be kept in the status
+func (r *TenantInternalReconciler) Run() (*porta_client_pkg.Tenant, *porta_client_pkg.User, error) {
 	tenantDef, err := r.reconcileTenant()
 	if err != nil {
-		return err
+		return tenantDef, nil, err
 	}
 
 	adminUserDef, err := r.reconcileAdminUser(tenantDef)
 	if err != nil {
-		return err
+		return tenantDef, nil, err
 	}
 
 	err = r.reconcileAccessTokenSecret(tenantDef)
 	if err != nil {
-		return err
+		return tenantDef, adminUserDef, err
 	}
 
-	tenantStatus := r.getTenantStatus(tenantDef, adminUserDef)
-
-	return r.updateTenantStatus(tenantStatus)
+	err = r.reconcileTenantState(tenantDef)
+	return tenantDef, adminUserDef, err
 }
 
 // This method makes sure that tenant exists, otherwise it will create one
@@ -77,6 +77,7 @@ func (r *TenantInternalReconciler) reconcileTenant() (*porta_client_pkg.Tenant,
 		if err != nil {
 			return nil, err
 		}
+		r.logger.Info("Tenant created", "TenantId", tenantDef.Signup.Account.ID)
 	} else {
 		r.logger.Info("Tenant already exists", "TenantId", tenantDef.Signup.Account.ID)
 		// Tenant is not created, check tenant desired state matches current state
@@ -137,6 +138,32 @@ func (r *TenantInternalReconciler) syncTenant(tenantDef *porta_client_pkg.Tenant
 	return nil
 }
 
+// This method makes sure tenant account is suspended or active as required
+func (r *TenantInternalReconciler) reconcileTenantState(tenantDef *porta_client_pkg.Tenant) error {
+	currentlySuspended := tenantDef.Signup.Account.State == TenantSuspendedAccountState
+
+	stateEvent := ""
+	if r.tenantR.IsSuspended() && !currentlySuspended {
+		stateEvent = "suspend"
+	} else if !r.tenantR.IsSuspended() && currentlySuspended {
+		stateEvent = "resume"
+	}
+
+	if stateEvent == "" {
+		return nil
+	}
+
+	r.logger.Info("Syncing tenant state", "TenantId", tenantDef.Signup.Account.ID, "event", stateEvent)
+	params := porta_client_pkg.Params{"state_event": stateEvent}
+	updatedTenant, err := r.portaClient.UpdateTenant(tenantDef.Signup.Account.ID, params)
+	if err != nil {
+		return fmt.Errorf("tenant [%d] %s request: %w", tenantDef.Signup.Account.ID, stateEvent, err)
+	}
+
+	tenantDef.Signup.Account.State = updatedTenant.Signup.Account.State
+	return nil
+}
+
 ////
 //
 // This method makes sure admin user:
@@ -361,34 +388,17 @@ func (r *TenantInternalReconciler) findTenantProviderKey(tenantDef *porta_client
 	return appList.Applications[0].Application.UserKey, nil
 }
 
-func (r *TenantInternalReconciler) getTenantStatus(tenantDef *porta_client_pkg.Tenant, adminUserDef *porta_client_pkg.User) *apiv1alpha1.TenantStatus {
-	return &apiv1alpha1.TenantStatus{
-		TenantId: tenantDef.Signup.Account.ID,
-		AdminId:  adminUserDef.ID,
-	}
-}
-
-func (r *TenantInternalReconciler) updateTenantStatus(tenantStatus *apiv1alpha1.TenantStatus) error {
-	// don't update the status if there aren't any changes.
-	if reflect.DeepEqual(r.tenantR.Status, *tenantStatus) {
-		return nil
-	}
-	r.logger.Info("update tenant status", "status", tenantStatus)
-	r.tenantR.Status = *tenantStatus
-	return r.k8sClient.Status().Update(context.TODO(), r.tenantR)
-}
-
 // addOwnerRefToObject appends the desired OwnerReference to the object
 func (r *TenantInternalReconciler) addOwnerRefToObject(o metav1.Object, ref metav1.OwnerReference) {
 	o.SetOwnerReferences(append(o.GetOwnerReferences(), ref))
 }
 
 // asOwner returns an owner reference set as the tenant CR
-func (r *TenantInternalReconciler) asOwner(t *apiv1alpha1.Tenant) metav1.OwnerReference {
+func (r *TenantInternalReconciler) asOwner(t *apiv1beta1.Tenant) metav1.OwnerReference {
 	trueVar := true
 	return metav1.OwnerReference{
-		APIVersion: apiv1alpha1.GroupVersion.String(),
-		Kind:       apiv1alpha1.TenantKind,
+		APIVersion: apiv1beta1.GroupVersion.String(),
+		Kind:       apiv1beta1.TenantKind,
 		Name:       t.Name,
 		UID:        t.UID,
 		Controller: &trueVar,
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	porta_client_pkg "github.com/3scale/3scale-porta-go-client/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+type TenantStatusReconciler struct {
+	*reconcilers.BaseReconciler
+	resource     *capabilitiesv1beta1.Tenant
+	tenantDef    *porta_client_pkg.Tenant
+	adminUserDef *porta_client_pkg.User
+	syncError    error
+	logger       logr.Logger
+}
+
+func NewTenantStatusReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Tenant, tenantDef *porta_client_pkg.Tenant, adminUserDef *porta_client_pkg.User, syncError error) *TenantStatusReconciler {
+	return &TenantStatusReconciler{
+		BaseReconciler: b,
+		resource:       resource,
+		tenantDef:      tenantDef,
+		adminUserDef:   adminUserDef,
+		syncError:      syncError,
+		logger:         b.Logger().WithValues("Status Reconciler", resource.Name),
+	}
+}
+
+func (s *TenantStatusReconciler) Reconcile() (reconcile.Result, error) {
+	s.logger.V(1).Info("START")
+
+	newStatus := s.calculateStatus()
+
+	equalStatus := s.resource.Status.Equals(newStatus, s.logger)
+	s.logger.V(1).Info("Status", "status is different", !equalStatus)
+	s.logger.V(1).Info("Status", "generation is different", s.resource.Generation != s.resource.Status.ObservedGeneration)
+	if equalStatus && s.resource.Generation == s.resource.Status.ObservedGeneration {
+		// Steady state
+		s.logger.V(1).Info("Status was not updated")
+		return reconcile.Result{}, nil
+	}
+
+	// Save the generation number we acted on, otherwise we might wrongfully indicate
+	// that we've seen a spec update when we retry.
+	newStatus.ObservedGeneration = s.resource.Generation
+
+	s.logger.V(1).Info("Updating Status", "sequence no:", fmt.Sprintf("sequence No: %v->%v", s.resource.Status.ObservedGeneration, newStatus.ObservedGeneration))
+
+	s.resource.Status = *newStatus
+	updateErr := s.Client().Status().Update(s.Context(), s.resource)
+	if updateErr != nil {
+		// Ignore conflicts, resource might just be outdated.
+		if errors.IsConflict(updateErr) {
+			s.logger.Info("Failed to update status: resource might just be outdated")
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("Failed to update status: %w", updateErr)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (s *TenantStatusReconciler) calculateStatus() *capabilitiesv1beta1.TenantStatus {
+	// Keep previously observed values when the remote tenant could not be read.
+	// Losing the tenant ID would create a new tenant.
+	newStatus := &capabilitiesv1beta1.TenantStatus{
+		TenantId: s.resource.Status.TenantId,
+		AdminId:  s.resource.Status.AdminId,
+		State:    s.resource.Status.State,
+	}
+
+	if s.tenantDef != nil {
+		newStatus.TenantId = s.tenantDef.Signup.Account.ID
+		newStatus.State = s.tenantDef.Signup.Account.State
+	}
+
+	if s.adminUserDef != nil {
+		newStatus.AdminId = s.adminUserDef.ID
+	}
+
+	newStatus.ObservedGeneration = s.resource.Status.ObservedGeneration
+
+	newStatus.Conditions = s.resource.Status.Conditions.Copy()
+	newStatus.Conditions.SetCondition(s.syncCondition())
+	newStatus.Conditions.SetCondition(s.failedCondition())
+	newStatus.Conditions.SetCondition(s.suspendedCondition(newStatus.State))
+
+	return newStatus
+}
+
+func (s *TenantStatusReconciler) syncCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.TenantSyncedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if s.syncError == nil {
+		condition.Status = corev1.ConditionTrue
+	}
+
+	return condition
+}
+
+func (s *TenantStatusReconciler) failedCondition() common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.TenantFailedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	// This condition could be activated together with other conditions
+	if s.syncError != nil {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = s.syncError.Error()
+	}
+
+	return condition
+}
+
+func (s *TenantStatusReconciler) suspendedCondition(state string) common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.TenantSuspendedConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if state == TenantSuspendedAccountState {
+		condition.Status = corev1.ConditionTrue
+	}
+
+	return condition
+}
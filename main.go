@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"time"
 
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -46,6 +47,8 @@ import (
 	appscontroller "github.com/3scale/3scale-operator/controllers/apps"
 	capabilitiescontroller "github.com/3scale/3scale-operator/controllers/capabilities"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescalemetrics "github.com/3scale/3scale-operator/pkg/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	controllerruntimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	// +kubebuilder:scaffold:imports
@@ -75,12 +78,27 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var capabilitiesResyncPeriod time.Duration
+	adminAPIGuardOptions := controllerhelper.DefaultAdminAPIGuardOptions
+	var adminAPIQPS float64
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&capabilitiesResyncPeriod, "capabilities-resync-period", 0,
+		"Period of the resync of products and backends against 3scale, reverting changes done in the admin portal. "+
+			"Overridden per resource with the "+capabilitiesv1beta1.ResyncPeriodAnnotation+" annotation. Zero disables the resync.")
+	flag.Float64Var(&adminAPIQPS, "admin-api-qps", float64(adminAPIGuardOptions.QPS),
+		"Maximum requests per second to the admin API of each provider account.")
+	flag.IntVar(&adminAPIGuardOptions.Burst, "admin-api-burst", adminAPIGuardOptions.Burst,
+		"Maximum burst of requests to the admin API of each provider account.")
+	flag.IntVar(&adminAPIGuardOptions.MaxRetries, "admin-api-max-retries", adminAPIGuardOptions.MaxRetries,
+		"Retries of the admin API requests rate limited or failed with server errors.")
 	flag.Parse()
 
+	adminAPIGuardOptions.QPS = float32(adminAPIQPS)
+	controllerhelper.SetAdminAPIGuardOptions(adminAPIGuardOptions)
+
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
 	printVersion()
@@ -90,6 +108,7 @@ func main() {
 		setupLog.Error(err, "Failed to get watch namespace")
 		os.Exit(1)
 	}
+	controllerhelper.SetWatchNamespace(namespace)
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Namespace:          namespace,
@@ -120,6 +139,12 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "APIManager")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = (&appsv1alpha1.APIManager{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "APIManager")
+			os.Exit(1)
+		}
+	}
 
 	discoveryClientAPIManagerBackup, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
@@ -138,6 +163,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClientAPIManagerBackupSchedule, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&appscontroller.APIManagerBackupScheduleReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			context.Background(),
+			ctrl.Log.WithName("controllers").WithName("APIManagerBackupSchedule"),
+			discoveryClientAPIManagerBackupSchedule,
+			mgr.GetEventRecorderFor("APIManagerBackupSchedule")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "APIManagerBackupSchedule")
+		os.Exit(1)
+	}
+
 	discoveryClientAPIManagerRestore, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to create discovery client")
@@ -155,14 +197,29 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClientTenant, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
 	if err = (&capabilitiescontroller.TenantReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Tenant"),
-		Scheme: mgr.GetScheme(),
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			context.Background(),
+			ctrl.Log.WithName("controllers").WithName("Tenant"),
+			discoveryClientTenant,
+			mgr.GetEventRecorderFor("Tenant")),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Tenant")
 		os.Exit(1)
 	}
+	// Webhook server requires serving certificates, see config/default/manager_webhook_patch.yaml
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = (&capabilitiesv1beta1.Tenant{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Tenant")
+			os.Exit(1)
+		}
+	}
 
 	discoveryClientBackend, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
@@ -176,10 +233,17 @@ func main() {
 			ctrl.Log.WithName("controllers").WithName("Backend"),
 			discoveryClientBackend,
 			mgr.GetEventRecorderFor("Backend")),
+		ResyncPeriod: capabilitiesResyncPeriod,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Backend")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = (&capabilitiesv1beta1.Backend{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Backend")
+			os.Exit(1)
+		}
+	}
 
 	discoveryClientProduct, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
@@ -193,10 +257,17 @@ func main() {
 			ctrl.Log.WithName("controllers").WithName("Product"),
 			discoveryClientProduct,
 			mgr.GetEventRecorderFor("Product")),
+		ResyncPeriod: capabilitiesResyncPeriod,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Product")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = (&capabilitiesv1beta1.Product{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Product")
+			os.Exit(1)
+		}
+	}
 
 	discoveryClientOpenAPI, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
@@ -214,6 +285,80 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenAPI")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = (&capabilitiesv1beta1.OpenAPI{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenAPI")
+			os.Exit(1)
+		}
+	}
+
+	discoveryClientDeveloperAccount, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&capabilitiescontroller.DeveloperAccountReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			context.Background(),
+			ctrl.Log.WithName("controllers").WithName("DeveloperAccount"),
+			discoveryClientDeveloperAccount,
+			mgr.GetEventRecorderFor("DeveloperAccount")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DeveloperAccount")
+		os.Exit(1)
+	}
+
+	discoveryClientApplication, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&capabilitiescontroller.ApplicationReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			context.Background(),
+			ctrl.Log.WithName("controllers").WithName("Application"),
+			discoveryClientApplication,
+			mgr.GetEventRecorderFor("Application")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Application")
+		os.Exit(1)
+	}
+
+	discoveryClientProductImport, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&capabilitiescontroller.ProductImportReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			context.Background(),
+			ctrl.Log.WithName("controllers").WithName("ProductImport"),
+			discoveryClientProductImport,
+			mgr.GetEventRecorderFor("ProductImport")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProductImport")
+		os.Exit(1)
+	}
+
+	discoveryClientProviderAccount, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&capabilitiescontroller.ProviderAccountReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			context.Background(),
+			ctrl.Log.WithName("controllers").WithName("ProviderAccount"),
+			discoveryClientProviderAccount,
+			mgr.GetEventRecorderFor("ProviderAccount")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProviderAccount")
+		os.Exit(1)
+	}
 
 	discoveryClientWebConsole, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
@@ -232,7 +377,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry()
+	registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry(mgr)
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -262,8 +407,12 @@ func printVersion() {
 	setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
 }
 
-func registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry() {
+func registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry(mgr ctrl.Manager) {
 	register3scaleVersionInfoMetric()
+	threescalemetrics.MustRegister(controllerruntimemetrics.Registry)
+	controllerruntimemetrics.Registry.MustRegister(
+		threescalemetrics.NewManagedResourcesCollector(mgr.GetClient(), ctrl.Log.WithName("metrics")),
+	)
 }
 
 func register3scaleVersionInfoMetric() {
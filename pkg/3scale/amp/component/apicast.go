@@ -143,6 +143,7 @@ func (apicast *Apicast) StagingDeploymentConfig() *appsv1.DeploymentConfig {
 					Affinity:           apicast.Options.StagingAffinity,
 					Tolerations:        apicast.Options.StagingTolerations,
 					ServiceAccountName: "amp",
+					Volumes:            apicast.customizationVolumes(apicast.Options.StagingCustomization),
 					Containers: []v1.Container{
 						v1.Container{
 							Ports: []v1.ContainerPort{
@@ -161,6 +162,7 @@ func (apicast *Apicast) StagingDeploymentConfig() *appsv1.DeploymentConfig {
 								},
 							},
 							Env:             apicast.buildApicastStagingEnv(),
+							VolumeMounts:    apicast.customizationVolumeMounts(apicast.Options.StagingCustomization),
 							Image:           "amp-apicast:latest",
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Name:            ApicastStagingName,
@@ -250,6 +252,7 @@ func (apicast *Apicast) ProductionDeploymentConfig() *appsv1.DeploymentConfig {
 					Affinity:           apicast.Options.ProductionAffinity,
 					Tolerations:        apicast.Options.ProductionTolerations,
 					ServiceAccountName: "amp",
+					Volumes:            apicast.customizationVolumes(apicast.Options.ProductionCustomization),
 					InitContainers: []v1.Container{
 						v1.Container{
 							Name:    "system-master-svc",
@@ -281,6 +284,7 @@ func (apicast *Apicast) ProductionDeploymentConfig() *appsv1.DeploymentConfig {
 								},
 							},
 							Env:             apicast.buildApicastProductionEnv(),
+							VolumeMounts:    apicast.customizationVolumeMounts(apicast.Options.ProductionCustomization),
 							Image:           "amp-apicast:latest",
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Name:            ApicastProductionName,
@@ -335,7 +339,7 @@ func (apicast *Apicast) buildApicastStagingEnv() []v1.EnvVar {
 		helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", "0"),
 		helper.EnvVarFromValue("THREESCALE_DEPLOYMENT_ENV", "staging"),
 	)
-	return result
+	return apicast.customizationEnv(result, apicast.Options.StagingCustomization)
 }
 
 func (apicast *Apicast) buildApicastProductionEnv() []v1.EnvVar {
@@ -346,7 +350,7 @@ func (apicast *Apicast) buildApicastProductionEnv() []v1.EnvVar {
 		helper.EnvVarFromValue("APICAST_CONFIGURATION_CACHE", "300"),
 		helper.EnvVarFromValue("THREESCALE_DEPLOYMENT_ENV", "production"),
 	)
-	return result
+	return apicast.customizationEnv(result, apicast.Options.ProductionCustomization)
 }
 
 func (apicast *Apicast) EnvironmentConfigMap() *v1.ConfigMap {
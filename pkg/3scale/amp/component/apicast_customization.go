@@ -0,0 +1,126 @@
+package component
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	ApicastCACertificateVolumeName      = "ca-certificate"
+	ApicastCACertificateMountPath       = "/var/run/secrets/ca-certificate"
+	ApicastCACertificateSecretKey       = "ca-bundle.crt"
+	ApicastCustomPolicyVolumeNamePrefix = "custom-policy-"
+	ApicastCustomPoliciesPath           = "/opt/app-root/src/policies"
+)
+
+// ApicastCustomizationManagedEnv returns the environment variables set by the
+// operator from the typed fields of the customization options
+func ApicastCustomizationManagedEnv(options ApicastCustomizationOptions) []v1.EnvVar {
+	result := []v1.EnvVar{}
+
+	optionalValues := []struct {
+		name  string
+		value *string
+	}{
+		{"APICAST_LOG_LEVEL", options.LogLevel},
+		{"APICAST_SERVICES_FILTER_BY_URL", options.ServicesFilterByURL},
+		{"ALL_PROXY", options.AllProxy},
+		{"HTTP_PROXY", options.HTTPProxy},
+		{"HTTPS_PROXY", options.HTTPSProxy},
+		{"NO_PROXY", options.NoProxy},
+	}
+	for _, optionalValue := range optionalValues {
+		if optionalValue.value != nil {
+			result = append(result, helper.EnvVarFromValue(optionalValue.name, *optionalValue.value))
+		}
+	}
+
+	if options.CACertificateSecretName != nil {
+		result = append(result, helper.EnvVarFromValue("SSL_CERT_FILE", path.Join(ApicastCACertificateMountPath, ApicastCACertificateSecretKey)))
+	}
+
+	return result
+}
+
+// customizationEnv appends the environment variables of the customization
+// options to the environment variables managed by the operator.
+// Custom environment variables colliding with the managed ones are rejected
+// when the options are built
+func (apicast *Apicast) customizationEnv(env []v1.EnvVar, options ApicastCustomizationOptions) []v1.EnvVar {
+	result := append([]v1.EnvVar{}, env...)
+	result = append(result, ApicastCustomizationManagedEnv(options)...)
+	return append(result, options.Env...)
+}
+
+func (apicast *Apicast) customizationVolumes(options ApicastCustomizationOptions) []v1.Volume {
+	var volumes []v1.Volume
+
+	if options.CACertificateSecretName != nil {
+		volumes = append(volumes, v1.Volume{
+			Name: ApicastCACertificateVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: *options.CACertificateSecretName,
+					Items: []v1.KeyToPath{
+						{Key: ApicastCACertificateSecretKey, Path: ApicastCACertificateSecretKey},
+					},
+				},
+			},
+		})
+	}
+
+	for idx, policy := range options.CustomPolicies {
+		volumes = append(volumes, v1.Volume{
+			Name:         customPolicyVolumeName(idx),
+			VolumeSource: policy.VolumeSource,
+		})
+	}
+
+	for _, volume := range options.Volumes {
+		volumes = append(volumes, v1.Volume{
+			Name:         volume.Name,
+			VolumeSource: volume.VolumeSource,
+		})
+	}
+
+	return volumes
+}
+
+func (apicast *Apicast) customizationVolumeMounts(options ApicastCustomizationOptions) []v1.VolumeMount {
+	var volumeMounts []v1.VolumeMount
+
+	if options.CACertificateSecretName != nil {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      ApicastCACertificateVolumeName,
+			MountPath: ApicastCACertificateMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	// Policies are loaded from <policies path>/<name>/<version>
+	for idx, policy := range options.CustomPolicies {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      customPolicyVolumeName(idx),
+			MountPath: path.Join(ApicastCustomPoliciesPath, policy.Name, policy.Version),
+			ReadOnly:  true,
+		})
+	}
+
+	for _, volume := range options.Volumes {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: volume.MountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumeMounts
+}
+
+func customPolicyVolumeName(idx int) string {
+	return fmt.Sprintf("%s%d", ApicastCustomPolicyVolumeNamePrefix, idx)
+}
@@ -16,15 +16,45 @@ type ApicastOptions struct {
 	StagingResourceRequirements    v1.ResourceRequirements `validate:"-"`
 	ProductionReplicas             int32
 	StagingReplicas                int32
-	CommonLabels                   map[string]string `validate:"required"`
-	CommonStagingLabels            map[string]string `validate:"required"`
-	CommonProductionLabels         map[string]string `validate:"required"`
-	StagingPodTemplateLabels       map[string]string `validate:"required"`
-	ProductionPodTemplateLabels    map[string]string `validate:"required"`
-	ProductionAffinity             *v1.Affinity      `validate:"-"`
-	ProductionTolerations          []v1.Toleration   `validate:"-"`
-	StagingAffinity                *v1.Affinity      `validate:"-"`
-	StagingTolerations             []v1.Toleration   `validate:"-"`
+	ProductionAutoscaling          *AutoscalingOptions
+	CommonLabels                   map[string]string           `validate:"required"`
+	CommonStagingLabels            map[string]string           `validate:"required"`
+	CommonProductionLabels         map[string]string           `validate:"required"`
+	StagingPodTemplateLabels       map[string]string           `validate:"required"`
+	ProductionPodTemplateLabels    map[string]string           `validate:"required"`
+	ProductionAffinity             *v1.Affinity                `validate:"-"`
+	ProductionTolerations          []v1.Toleration             `validate:"-"`
+	StagingAffinity                *v1.Affinity                `validate:"-"`
+	StagingTolerations             []v1.Toleration             `validate:"-"`
+	ProductionCustomization        ApicastCustomizationOptions `validate:"-"`
+	StagingCustomization           ApicastCustomizationOptions `validate:"-"`
+}
+
+// ApicastCustomizationOptions holds the APIcast environment configuration
+// not managed by the operator
+type ApicastCustomizationOptions struct {
+	LogLevel                *string
+	ServicesFilterByURL     *string
+	AllProxy                *string
+	HTTPProxy               *string
+	HTTPSProxy              *string
+	NoProxy                 *string
+	CACertificateSecretName *string
+	CustomPolicies          []ApicastCustomPolicy
+	Volumes                 []ApicastCustomVolume
+	Env                     []v1.EnvVar
+}
+
+type ApicastCustomPolicy struct {
+	Name         string
+	Version      string
+	VolumeSource v1.VolumeSource
+}
+
+type ApicastCustomVolume struct {
+	Name         string
+	MountPath    string
+	VolumeSource v1.VolumeSource
 }
 
 func NewApicastOptions() *ApicastOptions {
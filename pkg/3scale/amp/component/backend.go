@@ -15,8 +15,10 @@ import (
 )
 
 const (
-	BackendListenerName = "backend-listener"
-	BackendWorkerName   = "backend-worker"
+	BackendListenerName   = "backend-listener"
+	BackendWorkerName     = "backend-worker"
+	BackendCronName       = "backend-cron"
+	BackendRedisCheckName = "backend-redis-svc"
 )
 
 const (
@@ -27,6 +29,10 @@ const (
 	BackendSecretBackendRedisStorageSentinelRoleFieldName  = "REDIS_STORAGE_SENTINEL_ROLE"
 	BackendSecretBackendRedisQueuesSentinelHostsFieldName  = "REDIS_QUEUES_SENTINEL_HOSTS"
 	BackendSecretBackendRedisQueuesSentinelRoleFieldName   = "REDIS_QUEUES_SENTINEL_ROLE"
+	BackendSecretBackendRedisStorageUsernameFieldName      = "REDIS_STORAGE_USERNAME"
+	BackendSecretBackendRedisStoragePasswordFieldName      = "REDIS_STORAGE_PASSWORD"
+	BackendSecretBackendRedisQueuesUsernameFieldName       = "REDIS_QUEUES_USERNAME"
+	BackendSecretBackendRedisQueuesPasswordFieldName       = "REDIS_QUEUES_PASSWORD"
 )
 
 const (
@@ -106,9 +112,10 @@ func (backend *Backend) WorkerDeploymentConfig() *appsv1.DeploymentConfig {
 				Spec: v1.PodSpec{
 					Affinity:    backend.Options.WorkerAffinity,
 					Tolerations: backend.Options.WorkerTolerations,
+					Volumes:     backend.redisVolumes(),
 					InitContainers: []v1.Container{
 						v1.Container{
-							Name:  "backend-redis-svc",
+							Name:  BackendRedisCheckName,
 							Image: "amp-backend:latest",
 							Command: []string{
 								"/opt/app/entrypoint.sh",
@@ -116,6 +123,7 @@ func (backend *Backend) WorkerDeploymentConfig() *appsv1.DeploymentConfig {
 								"-c",
 								"until rake connectivity:redis_storage_queue_check; do sleep $SLEEP_SECONDS; done",
 							}, Env: append(backend.buildBackendCommonEnv(), helper.EnvVarFromValue("SLEEP_SECONDS", "1")),
+							VolumeMounts: backend.redisVolumeMounts(),
 						},
 					},
 					Containers: []v1.Container{
@@ -124,6 +132,7 @@ func (backend *Backend) WorkerDeploymentConfig() *appsv1.DeploymentConfig {
 							Image:           "amp-backend:latest",
 							Args:            []string{"bin/3scale_backend_worker", "run"},
 							Env:             backend.buildBackendWorkerEnv(),
+							VolumeMounts:    backend.redisVolumeMounts(),
 							Resources:       backend.Options.WorkerResourceRequirements,
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Ports:           backend.workerPorts(),
@@ -181,9 +190,10 @@ func (backend *Backend) CronDeploymentConfig() *appsv1.DeploymentConfig {
 				Spec: v1.PodSpec{
 					Affinity:    backend.Options.CronAffinity,
 					Tolerations: backend.Options.CronTolerations,
+					Volumes:     backend.redisVolumes(),
 					InitContainers: []v1.Container{
 						v1.Container{
-							Name:  "backend-redis-svc",
+							Name:  BackendRedisCheckName,
 							Image: "amp-backend:latest",
 							Command: []string{
 								"/opt/app/entrypoint.sh",
@@ -191,14 +201,16 @@ func (backend *Backend) CronDeploymentConfig() *appsv1.DeploymentConfig {
 								"-c",
 								"until rake connectivity:redis_storage_queue_check; do sleep $SLEEP_SECONDS; done",
 							}, Env: append(backend.buildBackendCommonEnv(), helper.EnvVarFromValue("SLEEP_SECONDS", "1")),
+							VolumeMounts: backend.redisVolumeMounts(),
 						},
 					},
 					Containers: []v1.Container{
 						v1.Container{
-							Name:            "backend-cron",
+							Name:            BackendCronName,
 							Image:           "amp-backend:latest",
 							Args:            []string{"backend-cron"},
 							Env:             backend.buildBackendCronEnv(),
+							VolumeMounts:    backend.redisVolumeMounts(),
 							Resources:       backend.Options.CronResourceRequirements,
 							ImagePullPolicy: v1.PullIfNotPresent,
 						},
@@ -256,14 +268,16 @@ func (backend *Backend) ListenerDeploymentConfig() *appsv1.DeploymentConfig {
 				Spec: v1.PodSpec{
 					Affinity:    backend.Options.ListenerAffinity,
 					Tolerations: backend.Options.ListenerTolerations,
+					Volumes:     backend.redisVolumes(),
 					Containers: []v1.Container{
 						v1.Container{
-							Name:      BackendListenerName,
-							Image:     "amp-backend:latest",
-							Args:      []string{"bin/3scale_backend", "start", "-e", "production", "-p", "3000", "-x", "/dev/stdout"},
-							Ports:     backend.listenerPorts(),
-							Env:       backend.buildBackendListenerEnv(),
-							Resources: backend.Options.ListenerResourceRequirements,
+							Name:         BackendListenerName,
+							Image:        "amp-backend:latest",
+							Args:         []string{"bin/3scale_backend", "start", "-e", "production", "-p", "3000", "-x", "/dev/stdout"},
+							Ports:        backend.listenerPorts(),
+							Env:          backend.buildBackendListenerEnv(),
+							VolumeMounts: backend.redisVolumeMounts(),
+							Resources:    backend.Options.ListenerResourceRequirements,
 							LivenessProbe: &v1.Probe{
 								Handler: v1.Handler{TCPSocket: &v1.TCPSocketAction{
 									Port: intstr.IntOrString{
@@ -367,8 +381,52 @@ func (backend *Backend) EnvironmentConfigMap() *v1.ConfigMap {
 	}
 }
 
+func (backend *Backend) storageRedisConnection() redisConnection {
+	return redisConnection{
+		name:    "backend-redis-storage",
+		options: backend.Options.StorageRedisConnection,
+		envNames: redisConnectionEnvNames{
+			username:   "CONFIG_REDIS_USERNAME",
+			password:   "CONFIG_REDIS_PASSWORD",
+			ssl:        "CONFIG_REDIS_SSL",
+			caFile:     "CONFIG_REDIS_CA_FILE",
+			clientCert: "CONFIG_REDIS_CERT",
+			privateKey: "CONFIG_REDIS_PRIVATE_KEY",
+		},
+		secretName:  BackendSecretBackendRedisSecretName,
+		usernameKey: BackendSecretBackendRedisStorageUsernameFieldName,
+		passwordKey: BackendSecretBackendRedisStoragePasswordFieldName,
+	}
+}
+
+func (backend *Backend) queuesRedisConnection() redisConnection {
+	return redisConnection{
+		name:    "backend-redis-queues",
+		options: backend.Options.QueuesRedisConnection,
+		envNames: redisConnectionEnvNames{
+			username:   "CONFIG_QUEUES_USERNAME",
+			password:   "CONFIG_QUEUES_PASSWORD",
+			ssl:        "CONFIG_QUEUES_SSL",
+			caFile:     "CONFIG_QUEUES_CA_FILE",
+			clientCert: "CONFIG_QUEUES_CERT",
+			privateKey: "CONFIG_QUEUES_PRIVATE_KEY",
+		},
+		secretName:  BackendSecretBackendRedisSecretName,
+		usernameKey: BackendSecretBackendRedisQueuesUsernameFieldName,
+		passwordKey: BackendSecretBackendRedisQueuesPasswordFieldName,
+	}
+}
+
+func (backend *Backend) redisVolumes() []v1.Volume {
+	return redisConnectionsVolumes(backend.storageRedisConnection(), backend.queuesRedisConnection())
+}
+
+func (backend *Backend) redisVolumeMounts() []v1.VolumeMount {
+	return redisConnectionsVolumeMounts(backend.storageRedisConnection(), backend.queuesRedisConnection())
+}
+
 func (backend *Backend) buildBackendCommonEnv() []v1.EnvVar {
-	return []v1.EnvVar{
+	result := []v1.EnvVar{
 		helper.EnvVarFromSecret("CONFIG_REDIS_PROXY", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageURLFieldName),
 		helper.EnvVarFromSecret("CONFIG_REDIS_SENTINEL_HOSTS", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelHostsFieldName),
 		helper.EnvVarFromSecret("CONFIG_REDIS_SENTINEL_ROLE", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelRoleFieldName),
@@ -377,6 +435,7 @@ func (backend *Backend) buildBackendCommonEnv() []v1.EnvVar {
 		helper.EnvVarFromSecret("CONFIG_QUEUES_SENTINEL_ROLE", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisQueuesSentinelRoleFieldName),
 		helper.EnvVarFromConfigMap("RACK_ENV", "backend-environment", "RACK_ENV"),
 	}
+	return append(result, redisConnectionsEnvVars(backend.storageRedisConnection(), backend.queuesRedisConnection())...)
 }
 
 func (backend *Backend) buildBackendWorkerEnv() []v1.EnvVar {
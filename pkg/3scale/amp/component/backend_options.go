@@ -17,6 +17,10 @@ type BackendOptions struct {
 	ListenerReplicas             int32
 	WorkerReplicas               int32
 	CronReplicas                 int32
+	ListenerAutoscaling          *AutoscalingOptions
+	WorkerAutoscaling            *AutoscalingOptions
+	StorageRedisConnection       *RedisConnectionOptions
+	QueuesRedisConnection        *RedisConnectionOptions
 	SystemBackendUsername        string            `validate:"required"`
 	SystemBackendPassword        string            `validate:"required"`
 	TenantName                   string            `validate:"required"`
@@ -0,0 +1,244 @@
+package component
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageStreamTagImages returns the image references of the given ImageStreams
+// tags, keyed by "<imagestream>:<tag>" as referenced by the DeploymentConfig
+// image change triggers
+func ImageStreamTagImages(imageStreams ...*imagev1.ImageStream) map[string]string {
+	res := map[string]string{}
+	for _, imageStream := range imageStreams {
+		for _, tag := range imageStream.Spec.Tags {
+			if tag.From == nil || tag.From.Kind != "DockerImage" {
+				continue
+			}
+			res[fmt.Sprintf("%s:%s", imageStream.Name, tag.Name)] = tag.From.Name
+		}
+	}
+	return res
+}
+
+// DeploymentFromDeploymentConfig renders the apps/v1 Deployment equivalent
+// to the given DeploymentConfig. Containers referenced by image change triggers
+// get the image the trigger ImageStreamTag resolves to in images.
+// Pre lifecycle hooks are run by the Job returned by DeploymentPreHookJob.
+// Post hooks and config change triggers have no Deployment equivalent and are
+// dropped
+func DeploymentFromDeploymentConfig(dc *appsv1.DeploymentConfig, images map[string]string) (*k8sappsv1.Deployment, error) {
+	if dc.Spec.Template == nil {
+		return nil, fmt.Errorf("DeploymentConfig %s has no pod template", dc.Name)
+	}
+
+	replicas := dc.Spec.Replicas
+	template := dc.Spec.Template.DeepCopy()
+
+	for _, trigger := range dc.Spec.Triggers {
+		if trigger.Type != appsv1.DeploymentTriggerOnImageChange || trigger.ImageChangeParams == nil {
+			continue
+		}
+
+		image, ok := images[trigger.ImageChangeParams.From.Name]
+		if !ok {
+			return nil, fmt.Errorf("DeploymentConfig %s: image for ImageStreamTag %s not found", dc.Name, trigger.ImageChangeParams.From.Name)
+		}
+
+		for _, containerName := range trigger.ImageChangeParams.ContainerNames {
+			if !setContainerImage(template.Spec.InitContainers, containerName, image) &&
+				!setContainerImage(template.Spec.Containers, containerName, image) {
+				return nil, fmt.Errorf("DeploymentConfig %s: container %s not found", dc.Name, containerName)
+			}
+		}
+	}
+
+	strategy, err := deploymentStrategy(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	var progressDeadlineSeconds *int32
+	if dc.Spec.Strategy.RollingParams != nil && dc.Spec.Strategy.RollingParams.TimeoutSeconds != nil {
+		progressDeadlineSeconds = &[]int32{int32(*dc.Spec.Strategy.RollingParams.TimeoutSeconds)}[0]
+	}
+
+	return &k8sappsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dc.Name,
+			Namespace:   dc.Namespace,
+			Labels:      dc.Labels,
+			Annotations: dc.Annotations,
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: &replicas,
+			// Keep the DeploymentConfig selector so existing services
+			// keep selecting the pods
+			Selector:                &metav1.LabelSelector{MatchLabels: dc.Spec.Selector},
+			Template:                *template,
+			Strategy:                strategy,
+			MinReadySeconds:         dc.Spec.MinReadySeconds,
+			RevisionHistoryLimit:    dc.Spec.RevisionHistoryLimit,
+			ProgressDeadlineSeconds: progressDeadlineSeconds,
+		},
+	}, nil
+}
+
+// DeploymentPreHookLabel is the label of the Jobs running the pre lifecycle
+// hook of a DeploymentConfig. Its value is the DeploymentConfig name
+const DeploymentPreHookLabel = "deploymentPreHook"
+
+// DeploymentPreHookJob returns the Job running the pre lifecycle hook of the
+// given DeploymentConfig for the pod template of its equivalent Deployment,
+// or nil when the DeploymentConfig has no pre hook. Like the DeploymentConfig
+// hook pod, the Job runs once per pod template: its name is
+// "<name>-pre-hook-<template hash>", so template changes get a new Job
+func DeploymentPreHookJob(dc *appsv1.DeploymentConfig, deployment *k8sappsv1.Deployment) (*batchv1.Job, error) {
+	if dc.Spec.Strategy.RollingParams == nil || dc.Spec.Strategy.RollingParams.Pre == nil {
+		return nil, nil
+	}
+
+	name := fmt.Sprintf("%s-pre-hook", dc.Name)
+	template := deployment.Spec.Template.DeepCopy()
+	hookContainer, err := lifecycleHookContainer(name, dc.Spec.Strategy.RollingParams.Pre, template)
+	if err != nil {
+		return nil, fmt.Errorf("DeploymentConfig %s pre hook: %w", dc.Name, err)
+	}
+
+	templateHash, err := podTemplateHash(&deployment.Spec.Template)
+	if err != nil {
+		return nil, fmt.Errorf("DeploymentConfig %s pre hook: %w", dc.Name, err)
+	}
+
+	labels := map[string]string{}
+	for k, v := range dc.Labels {
+		labels[k] = v
+	}
+	labels[DeploymentPreHookLabel] = dc.Name
+
+	// The hook pod must not be selected by the services of the workload
+	template.ObjectMeta = metav1.ObjectMeta{Labels: labels}
+	template.Spec.InitContainers = nil
+	template.Spec.Containers = []v1.Container{*hookContainer}
+	template.Spec.RestartPolicy = v1.RestartPolicyNever
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", name, templateHash),
+			Namespace: dc.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &[]int32{1}[0],
+			Template:    *template,
+		},
+	}, nil
+}
+
+func podTemplateHash(template *v1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:10], nil
+}
+
+func deploymentStrategy(dc *appsv1.DeploymentConfig) (k8sappsv1.DeploymentStrategy, error) {
+	switch dc.Spec.Strategy.Type {
+	case appsv1.DeploymentStrategyTypeRecreate:
+		return k8sappsv1.DeploymentStrategy{Type: k8sappsv1.RecreateDeploymentStrategyType}, nil
+	case appsv1.DeploymentStrategyTypeRolling, "":
+		strategy := k8sappsv1.DeploymentStrategy{Type: k8sappsv1.RollingUpdateDeploymentStrategyType}
+		if dc.Spec.Strategy.RollingParams != nil {
+			strategy.RollingUpdate = &k8sappsv1.RollingUpdateDeployment{
+				MaxUnavailable: dc.Spec.Strategy.RollingParams.MaxUnavailable,
+				MaxSurge:       dc.Spec.Strategy.RollingParams.MaxSurge,
+			}
+		}
+		return strategy, nil
+	default:
+		return k8sappsv1.DeploymentStrategy{}, fmt.Errorf("DeploymentConfig %s: unsupported strategy type %s", dc.Name, dc.Spec.Strategy.Type)
+	}
+}
+
+// lifecycleHookContainer returns the container of the hook pod: the hook
+// container with the hook command, environment and volumes
+func lifecycleHookContainer(name string, hook *appsv1.LifecycleHook, template *v1.PodTemplateSpec) (*v1.Container, error) {
+	if hook.ExecNewPod == nil {
+		return nil, fmt.Errorf("only execNewPod hooks are supported")
+	}
+
+	var hookContainer *v1.Container
+	for idx := range template.Spec.Containers {
+		if template.Spec.Containers[idx].Name == hook.ExecNewPod.ContainerName {
+			hookContainer = &template.Spec.Containers[idx]
+		}
+	}
+	if hookContainer == nil {
+		return nil, fmt.Errorf("container %s not found", hook.ExecNewPod.ContainerName)
+	}
+
+	env := []v1.EnvVar{}
+	for _, envVar := range hookContainer.Env {
+		if !envVarDefined(hook.ExecNewPod.Env, envVar.Name) {
+			env = append(env, envVar)
+		}
+	}
+	env = append(env, hook.ExecNewPod.Env...)
+
+	volumeMounts := []v1.VolumeMount{}
+	for _, volumeMount := range hookContainer.VolumeMounts {
+		for _, volumeName := range hook.ExecNewPod.Volumes {
+			if volumeMount.Name == volumeName {
+				volumeMounts = append(volumeMounts, volumeMount)
+			}
+		}
+	}
+
+	return &v1.Container{
+		Name:            name,
+		Image:           hookContainer.Image,
+		Command:         hook.ExecNewPod.Command,
+		Env:             env,
+		EnvFrom:         hookContainer.EnvFrom,
+		Resources:       hookContainer.Resources,
+		VolumeMounts:    volumeMounts,
+		ImagePullPolicy: hookContainer.ImagePullPolicy,
+		SecurityContext: hookContainer.SecurityContext,
+	}, nil
+}
+
+func setContainerImage(containers []v1.Container, name, image string) bool {
+	for idx := range containers {
+		if containers[idx].Name == name {
+			containers[idx].Image = image
+			return true
+		}
+	}
+	return false
+}
+
+func envVarDefined(env []v1.EnvVar, name string) bool {
+	for _, envVar := range env {
+		if envVar.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package component
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// IngressGVK is the networking.k8s.io/v1 Ingress kind.
+	// The k8s.io/api version in use does not ship the v1 Ingress types,
+	// so Ingresses are handled as unstructured objects
+	IngressGVK = schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	// HTTPRouteGVK is the Gateway API HTTPRoute kind
+	HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	// GatewayGVK is the Gateway API Gateway kind
+	GatewayGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+)
+
+const (
+	systemServicePort  = 3000
+	backendServicePort = 3000
+	apicastServicePort = 8080
+)
+
+// ExposureHost is a host exposed outside the cluster
+// and the service the host is routed to.
+// Hosts are lowercase, as required by Ingresses and HTTPRoutes
+type ExposureHost struct {
+	Host        string
+	ServiceName string
+	ServicePort int64
+}
+
+// Name returns the name of the objects exposing the host
+func (h ExposureHost) Name() string {
+	return fmt.Sprintf("%s-%s", h.ServiceName, h.Host)
+}
+
+type Exposure struct {
+	Options *ExposureOptions
+}
+
+func NewExposure(options *ExposureOptions) *Exposure {
+	return &Exposure{Options: options}
+}
+
+// Hosts returns the hosts known from the APIManager: master, default tenant
+// admin and developer portals and backend listener.
+// Hosts of other tenants and products are read from 3scale
+func (e *Exposure) Hosts() []ExposureHost {
+	hosts := []ExposureHost{
+		{
+			Host:        strings.ToLower(fmt.Sprintf("%s.%s", e.Options.MasterName, e.Options.WildcardDomain)),
+			ServiceName: "system-master",
+			ServicePort: systemServicePort,
+		},
+		{
+			Host:        strings.ToLower(fmt.Sprintf("backend-%s.%s", e.Options.TenantName, e.Options.WildcardDomain)),
+			ServiceName: BackendListenerName,
+			ServicePort: backendServicePort,
+		},
+	}
+
+	return append(hosts, TenantExposureHosts(
+		fmt.Sprintf("%s-admin.%s", e.Options.TenantName, e.Options.WildcardDomain),
+		fmt.Sprintf("%s.%s", e.Options.TenantName, e.Options.WildcardDomain),
+	)...)
+}
+
+// TenantExposureHosts returns the admin and developer portal hosts of a tenant
+func TenantExposureHosts(adminDomain, domain string) []ExposureHost {
+	hosts := []ExposureHost{}
+	if adminDomain != "" {
+		hosts = append(hosts, ExposureHost{Host: strings.ToLower(adminDomain), ServiceName: "system-provider", ServicePort: systemServicePort})
+	}
+	if domain != "" {
+		hosts = append(hosts, ExposureHost{Host: strings.ToLower(domain), ServiceName: "system-developer", ServicePort: systemServicePort})
+	}
+	return hosts
+}
+
+// ProductExposureHosts returns the apicast hosts of a product
+// from its staging and production public base URLs
+func ProductExposureHosts(stagingEndpoint, productionEndpoint string) ([]ExposureHost, error) {
+	hosts := []ExposureHost{}
+
+	cases := []struct {
+		endpoint    string
+		serviceName string
+	}{
+		{stagingEndpoint, ApicastStagingName},
+		{productionEndpoint, ApicastProductionName},
+	}
+
+	for _, c := range cases {
+		if c.endpoint == "" {
+			continue
+		}
+
+		endpointURL, err := url.Parse(c.endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("parsing endpoint %s: %w", c.endpoint, err)
+		}
+
+		hosts = append(hosts, ExposureHost{Host: strings.ToLower(endpointURL.Hostname()), ServiceName: c.serviceName, ServicePort: apicastServicePort})
+	}
+
+	return hosts, nil
+}
+
+func (e *Exposure) Ingress(host ExposureHost) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{
+				"host": host.Host,
+				"http": map[string]interface{}{
+					"paths": []interface{}{
+						map[string]interface{}{
+							"path":     "/",
+							"pathType": "Prefix",
+							"backend": map[string]interface{}{
+								"service": map[string]interface{}{
+									"name": host.ServiceName,
+									"port": map[string]interface{}{
+										"number": host.ServicePort,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if e.Options.IngressClassName != nil {
+		spec["ingressClassName"] = *e.Options.IngressClassName
+	}
+
+	if e.Options.IngressTLSSecretName != nil {
+		spec["tls"] = []interface{}{
+			map[string]interface{}{
+				"hosts":      []interface{}{host.Host},
+				"secretName": *e.Options.IngressTLSSecretName,
+			},
+		}
+	}
+
+	ingress := e.object(IngressGVK, host, spec)
+	ingress.SetAnnotations(e.Options.IngressAnnotations)
+	return ingress
+}
+
+func (e *Exposure) HTTPRoute(host ExposureHost) *unstructured.Unstructured {
+	parentRefs := []interface{}{}
+	for _, ref := range e.Options.GatewayParentRefs {
+		parentRef := map[string]interface{}{
+			"name": ref.Name,
+		}
+		if ref.Namespace != nil {
+			parentRef["namespace"] = *ref.Namespace
+		}
+		if ref.SectionName != nil {
+			parentRef["sectionName"] = *ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	return e.object(HTTPRouteGVK, host, map[string]interface{}{
+		"parentRefs": parentRefs,
+		"hostnames":  []interface{}{host.Host},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": host.ServiceName,
+						"port": host.ServicePort,
+					},
+				},
+			},
+		},
+	})
+}
+
+func (e *Exposure) object(gvk schema.GroupVersionKind, host ExposureHost, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(host.Name())
+	obj.SetLabels(e.Options.CommonLabels)
+	return obj
+}
@@ -0,0 +1,35 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// GatewayParentRef references the Gateway listener the HTTPRoutes are attached to
+type GatewayParentRef struct {
+	Name        string  `validate:"required"`
+	Namespace   *string `validate:"-"`
+	SectionName *string `validate:"-"`
+}
+
+type ExposureOptions struct {
+	WildcardDomain string `validate:"required"`
+	TenantName     string `validate:"required"`
+	MasterName     string `validate:"required"`
+
+	IngressClassName     *string           `validate:"-"`
+	IngressAnnotations   map[string]string `validate:"-"`
+	IngressTLSSecretName *string           `validate:"-"`
+
+	GatewayParentRefs []GatewayParentRef `validate:"dive"`
+
+	CommonLabels map[string]string `validate:"required"`
+}
+
+func NewExposureOptions() *ExposureOptions {
+	return &ExposureOptions{}
+}
+
+func (e *ExposureOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(e)
+}
@@ -37,7 +37,7 @@ func (ha *HighAvailability) SystemDatabaseSecret() *v1.Secret {
 }
 
 func (ha *HighAvailability) BackendRedisSecret() *v1.Secret {
-	return &v1.Secret{
+	secret := &v1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
 			Kind:       "Secret",
@@ -56,10 +56,19 @@ func (ha *HighAvailability) BackendRedisSecret() *v1.Secret {
 		},
 		Type: v1.SecretTypeOpaque,
 	}
+
+	if ha.Options.ExternalRedisManaged {
+		secret.StringData[BackendSecretBackendRedisStorageUsernameFieldName] = ha.Options.BackendRedisStorageUsername
+		secret.StringData[BackendSecretBackendRedisStoragePasswordFieldName] = ha.Options.BackendRedisStoragePassword
+		secret.StringData[BackendSecretBackendRedisQueuesUsernameFieldName] = ha.Options.BackendRedisQueuesUsername
+		secret.StringData[BackendSecretBackendRedisQueuesPasswordFieldName] = ha.Options.BackendRedisQueuesPassword
+	}
+
+	return secret
 }
 
 func (ha *HighAvailability) SystemRedisSecret() *v1.Secret {
-	return &v1.Secret{
+	secret := &v1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
 			Kind:       "Secret",
@@ -80,4 +89,13 @@ func (ha *HighAvailability) SystemRedisSecret() *v1.Secret {
 		},
 		Type: v1.SecretTypeOpaque,
 	}
+
+	if ha.Options.ExternalRedisManaged {
+		secret.StringData[SystemSecretSystemRedisUsername] = ha.Options.SystemRedisUsername
+		secret.StringData[SystemSecretSystemRedisPassword] = ha.Options.SystemRedisPassword
+		secret.StringData[SystemSecretSystemRedisMessageBusUsername] = ha.Options.SystemMessageBusRedisUsername
+		secret.StringData[SystemSecretSystemRedisMessageBusPassword] = ha.Options.SystemMessageBusRedisPassword
+	}
+
+	return secret
 }
@@ -19,6 +19,18 @@ type HighAvailabilityOptions struct {
 	SystemMessageBusRedisSentinelsRole  string
 	SystemMessageBusRedisNamespace      string
 
+	// ExternalRedisManaged is set when the redis settings are read from the
+	// APIManager externalRedis section. The secrets then hold the credentials
+	ExternalRedisManaged          bool
+	BackendRedisStorageUsername   string
+	BackendRedisStoragePassword   string
+	BackendRedisQueuesUsername    string
+	BackendRedisQueuesPassword    string
+	SystemRedisUsername           string
+	SystemRedisPassword           string
+	SystemMessageBusRedisUsername string
+	SystemMessageBusRedisPassword string
+
 	BackendRedisLabels   map[string]string `validate:"required"`
 	SystemRedisLabels    map[string]string `validate:"required"`
 	SystemDatabaseLabels map[string]string `validate:"required"`
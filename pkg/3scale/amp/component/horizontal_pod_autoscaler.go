@@ -0,0 +1,75 @@
+package component
+
+import (
+	appsv1 "github.com/openshift/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultTargetCPUUtilizationPercentage int32 = 80
+)
+
+type AutoscalingOptions struct {
+	MinReplicas                       int32  `validate:"min=1"`
+	MaxReplicas                       int32  `validate:"gtefield=MinReplicas"`
+	TargetCPUUtilizationPercentage    *int32 `validate:"-"`
+	TargetMemoryUtilizationPercentage *int32 `validate:"-"`
+}
+
+// HorizontalPodAutoscaler returns the HorizontalPodAutoscaler scaling the
+// given DeploymentConfig. When options are nil, only the object metadata is set
+func HorizontalPodAutoscaler(dc *appsv1.DeploymentConfig, options *AutoscalingOptions) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2beta2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   dc.Name,
+			Labels: dc.Labels,
+		},
+	}
+
+	if options == nil {
+		return hpa
+	}
+
+	metrics := []autoscalingv2beta2.MetricSpec{}
+	if options.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(v1.ResourceCPU, *options.TargetCPUUtilizationPercentage))
+	}
+	if options.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(v1.ResourceMemory, *options.TargetMemoryUtilizationPercentage))
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, resourceMetric(v1.ResourceCPU, DefaultTargetCPUUtilizationPercentage))
+	}
+
+	hpa.Spec = autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+			APIVersion: "apps.openshift.io/v1",
+			Kind:       "DeploymentConfig",
+			Name:       dc.Name,
+		},
+		MinReplicas: &[]int32{options.MinReplicas}[0],
+		MaxReplicas: options.MaxReplicas,
+		Metrics:     metrics,
+	}
+
+	return hpa
+}
+
+func resourceMetric(name v1.ResourceName, averageUtilization int32) autoscalingv2beta2.MetricSpec {
+	return autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:               autoscalingv2beta2.UtilizationMetricType,
+				AverageUtilization: &[]int32{averageUtilization}[0],
+			},
+		},
+	}
+}
@@ -43,3 +43,7 @@ func ZyncPostgreSQLImageURL() string {
 func OCCLIImageURL() string {
 	return "quay.io/openshift/origin-cli:4.2"
 }
+
+func S3CLIImageURL() string {
+	return "amazon/aws-cli:2.0.60"
+}
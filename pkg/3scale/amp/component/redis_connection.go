@@ -0,0 +1,185 @@
+package component
+
+import (
+	"path"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	RedisTLSMountBasePath        = "/tls"
+	RedisTLSCACertificateKey     = "ca.crt"
+	RedisTLSClientCertificateKey = v1.TLSCertKey
+	RedisTLSClientKeyKey         = v1.TLSPrivateKeyKey
+)
+
+// RedisConnectionOptions are the ACL and TLS settings of the connection to an
+// external Redis database. URLs and Sentinels are read from the
+// backend-redis and system-redis secrets
+type RedisConnectionOptions struct {
+	// Username and Password are set when the secret holds them
+	Username bool
+	Password bool
+	TLS      *RedisTLSOptions
+}
+
+type RedisTLSOptions struct {
+	CASecretName                *string
+	ClientCertificateSecretName *string
+}
+
+// redisConnectionEnvNames are the environment variables
+// a component reads the connection settings from
+type redisConnectionEnvNames struct {
+	username   string
+	password   string
+	ssl        string
+	caFile     string
+	clientCert string
+	privateKey string
+}
+
+// redisConnection is the connection of a component to a Redis database
+type redisConnection struct {
+	// name identifies the database, it names the TLS volume and its mount path
+	name        string
+	options     *RedisConnectionOptions
+	envNames    redisConnectionEnvNames
+	secretName  string
+	usernameKey string
+	passwordKey string
+}
+
+func (c redisConnection) volumeName() string {
+	return c.name + "-tls"
+}
+
+func (c redisConnection) mountPath() string {
+	return path.Join(RedisTLSMountBasePath, c.name)
+}
+
+func (c redisConnection) envVars() []v1.EnvVar {
+	result := []v1.EnvVar{}
+	if c.options == nil {
+		return result
+	}
+
+	if c.options.Username {
+		result = append(result, helper.EnvVarFromSecret(c.envNames.username, c.secretName, c.usernameKey))
+	}
+	if c.options.Password {
+		result = append(result, helper.EnvVarFromSecret(c.envNames.password, c.secretName, c.passwordKey))
+	}
+
+	tls := c.options.TLS
+	if tls == nil {
+		return result
+	}
+
+	result = append(result, helper.EnvVarFromValue(c.envNames.ssl, "true"))
+	if tls.CASecretName != nil {
+		result = append(result, helper.EnvVarFromValue(c.envNames.caFile, path.Join(c.mountPath(), RedisTLSCACertificateKey)))
+	}
+	if tls.ClientCertificateSecretName != nil {
+		result = append(result,
+			helper.EnvVarFromValue(c.envNames.clientCert, path.Join(c.mountPath(), RedisTLSClientCertificateKey)),
+			helper.EnvVarFromValue(c.envNames.privateKey, path.Join(c.mountPath(), RedisTLSClientKeyKey)),
+		)
+	}
+
+	return result
+}
+
+// volumes returns the projected volume with the TLS material of the connection
+func (c redisConnection) volumes() []v1.Volume {
+	if c.options == nil || c.options.TLS == nil {
+		return nil
+	}
+
+	sources := []v1.VolumeProjection{}
+	if c.options.TLS.CASecretName != nil {
+		sources = append(sources, secretProjection(*c.options.TLS.CASecretName, RedisTLSCACertificateKey))
+	}
+	if c.options.TLS.ClientCertificateSecretName != nil {
+		sources = append(sources, secretProjection(*c.options.TLS.ClientCertificateSecretName, RedisTLSClientCertificateKey, RedisTLSClientKeyKey))
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	return []v1.Volume{
+		{
+			Name: c.volumeName(),
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{Sources: sources},
+			},
+		},
+	}
+}
+
+func (c redisConnection) volumeMounts() []v1.VolumeMount {
+	var volumeMounts []v1.VolumeMount
+	for _, volume := range c.volumes() {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: c.mountPath(),
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
+
+func secretProjection(secretName string, keys ...string) v1.VolumeProjection {
+	items := []v1.KeyToPath{}
+	for _, key := range keys {
+		items = append(items, v1.KeyToPath{Key: key, Path: key})
+	}
+	return v1.VolumeProjection{
+		Secret: &v1.SecretProjection{
+			LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+			Items:                items,
+		},
+	}
+}
+
+func redisConnectionsEnvVars(connections ...redisConnection) []v1.EnvVar {
+	result := []v1.EnvVar{}
+	for _, connection := range connections {
+		result = append(result, connection.envVars()...)
+	}
+	return result
+}
+
+// redisConnectionsVolumes returns the TLS volumes of the connections.
+// Connections to the same database share the volume
+func redisConnectionsVolumes(connections ...redisConnection) []v1.Volume {
+	var result []v1.Volume
+	names := map[string]bool{}
+	for _, connection := range connections {
+		for _, volume := range connection.volumes() {
+			if names[volume.Name] {
+				continue
+			}
+			names[volume.Name] = true
+			result = append(result, volume)
+		}
+	}
+	return result
+}
+
+func redisConnectionsVolumeMounts(connections ...redisConnection) []v1.VolumeMount {
+	var result []v1.VolumeMount
+	names := map[string]bool{}
+	for _, connection := range connections {
+		for _, volumeMount := range connection.volumeMounts() {
+			if names[volumeMount.Name] {
+				continue
+			}
+			names[volumeMount.Name] = true
+			result = append(result, volumeMount)
+		}
+	}
+	return result
+}
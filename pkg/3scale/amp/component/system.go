@@ -50,6 +50,10 @@ const (
 	SystemSecretSystemRedisSentinelRole                = "SENTINEL_ROLE"
 	SystemSecretSystemRedisMessageBusSentinelHosts     = "MESSAGE_BUS_SENTINEL_HOSTS"
 	SystemSecretSystemRedisMessageBusSentinelRole      = "MESSAGE_BUS_SENTINEL_ROLE"
+	SystemSecretSystemRedisUsername                    = "USERNAME"
+	SystemSecretSystemRedisPassword                    = "PASSWORD"
+	SystemSecretSystemRedisMessageBusUsername          = "MESSAGE_BUS_USERNAME"
+	SystemSecretSystemRedisMessageBusPassword          = "MESSAGE_BUS_PASSWORD"
 )
 
 const (
@@ -92,8 +96,10 @@ const (
 )
 
 const (
-	SystemSidekiqName       = "system-sidekiq"
-	SystemAppDeploymentName = "system-app"
+	SystemSidekiqName         = "system-sidekiq"
+	SystemSidekiqCheckSvcName = "check-svc"
+	SystemSphinxName          = "system-sphinx"
+	SystemAppDeploymentName   = "system-app"
 
 	SystemAppMasterContainerName    = "system-master"
 	SystemAppProviderContainerName  = "system-provider"
@@ -181,10 +187,83 @@ func (system *System) SystemRedisEnvVars() []v1.EnvVar {
 		helper.EnvVarFromSecret("MESSAGE_BUS_REDIS_SENTINEL_HOSTS", SystemSecretSystemRedisSecretName, SystemSecretSystemRedisMessageBusSentinelHosts),
 		helper.EnvVarFromSecret("MESSAGE_BUS_REDIS_SENTINEL_ROLE", SystemSecretSystemRedisSecretName, SystemSecretSystemRedisMessageBusSentinelRole),
 	)
+	result = append(result, redisConnectionsEnvVars(system.redisConnection(), system.messageBusRedisConnection())...)
 
 	return result
 }
 
+func (system *System) redisConnection() redisConnection {
+	return redisConnection{
+		name:    "system-redis",
+		options: system.Options.RedisConnection,
+		envNames: redisConnectionEnvNames{
+			username:   "REDIS_USERNAME",
+			password:   "REDIS_PASSWORD",
+			ssl:        "REDIS_SSL",
+			caFile:     "REDIS_CA_FILE",
+			clientCert: "REDIS_CLIENT_CERT",
+			privateKey: "REDIS_PRIVATE_KEY",
+		},
+		secretName:  SystemSecretSystemRedisSecretName,
+		usernameKey: SystemSecretSystemRedisUsername,
+		passwordKey: SystemSecretSystemRedisPassword,
+	}
+}
+
+func (system *System) messageBusRedisConnection() redisConnection {
+	return redisConnection{
+		name:    "system-redis-message-bus",
+		options: system.Options.MessageBusRedisConnection,
+		envNames: redisConnectionEnvNames{
+			username:   "MESSAGE_BUS_REDIS_USERNAME",
+			password:   "MESSAGE_BUS_REDIS_PASSWORD",
+			ssl:        "MESSAGE_BUS_REDIS_SSL",
+			caFile:     "MESSAGE_BUS_REDIS_CA_FILE",
+			clientCert: "MESSAGE_BUS_REDIS_CLIENT_CERT",
+			privateKey: "MESSAGE_BUS_REDIS_PRIVATE_KEY",
+		},
+		secretName:  SystemSecretSystemRedisSecretName,
+		usernameKey: SystemSecretSystemRedisMessageBusUsername,
+		passwordKey: SystemSecretSystemRedisMessageBusPassword,
+	}
+}
+
+func (system *System) backendRedisConnection() redisConnection {
+	return redisConnection{
+		name:    "backend-redis-storage",
+		options: system.Options.BackendRedisConnection,
+		envNames: redisConnectionEnvNames{
+			username:   "BACKEND_REDIS_USERNAME",
+			password:   "BACKEND_REDIS_PASSWORD",
+			ssl:        "BACKEND_REDIS_SSL",
+			caFile:     "BACKEND_REDIS_CA_FILE",
+			clientCert: "BACKEND_REDIS_CLIENT_CERT",
+			privateKey: "BACKEND_REDIS_PRIVATE_KEY",
+		},
+		secretName:  BackendSecretBackendRedisSecretName,
+		usernameKey: BackendSecretBackendRedisStorageUsernameFieldName,
+		passwordKey: BackendSecretBackendRedisStoragePasswordFieldName,
+	}
+}
+
+// redisOnlyVolumes returns the volumes of the system redis connections, used
+// by the pods not connecting to backend redis
+func (system *System) redisOnlyVolumes() []v1.Volume {
+	return redisConnectionsVolumes(system.redisConnection(), system.messageBusRedisConnection())
+}
+
+func (system *System) redisOnlyVolumeMounts() []v1.VolumeMount {
+	return redisConnectionsVolumeMounts(system.redisConnection(), system.messageBusRedisConnection())
+}
+
+func (system *System) redisVolumes() []v1.Volume {
+	return redisConnectionsVolumes(system.redisConnection(), system.messageBusRedisConnection(), system.backendRedisConnection())
+}
+
+func (system *System) redisVolumeMounts() []v1.VolumeMount {
+	return redisConnectionsVolumeMounts(system.redisConnection(), system.messageBusRedisConnection(), system.backendRedisConnection())
+}
+
 func (system *System) buildSystemBaseEnv() []v1.EnvVar {
 	result := []v1.EnvVar{}
 
@@ -311,11 +390,12 @@ func (system *System) buildSystemAppPostHookEnv() []v1.EnvVar {
 }
 
 func (system *System) BackendRedisEnvVars() []v1.EnvVar {
-	return []v1.EnvVar{
+	result := []v1.EnvVar{
 		helper.EnvVarFromSecret("BACKEND_REDIS_URL", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageURLFieldName),
 		helper.EnvVarFromSecret("BACKEND_REDIS_SENTINEL_HOSTS", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelHostsFieldName),
 		helper.EnvVarFromSecret("BACKEND_REDIS_SENTINEL_ROLE", BackendSecretBackendRedisSecretName, BackendSecretBackendRedisStorageSentinelRoleFieldName),
 	}
+	return append(result, redisConnectionsEnvVars(system.backendRedisConnection())...)
 }
 
 func (system *System) EnvironmentConfigMap() *v1.ConfigMap {
@@ -495,6 +575,7 @@ func (system *System) appPodVolumes() []v1.Volume {
 	}
 
 	res = append(res, systemConfigVolume)
+	res = append(res, system.redisVolumes()...)
 	return res
 }
 
@@ -503,6 +584,17 @@ func (system *System) volumeNamesForSystemAppPreHookPod() []string {
 	if system.Options.PvcFileStorageOptions != nil {
 		res = append(res, SystemFileStoragePVCName)
 	}
+	res = append(res, system.redisVolumeNames()...)
+	return res
+}
+
+// redisVolumeNames returns the redis TLS volumes, hook pods connecting
+// to redis inherit the mounts of the container they are based on
+func (system *System) redisVolumeNames() []string {
+	res := []string{}
+	for _, volume := range system.redisVolumes() {
+		res = append(res, volume.Name)
+	}
 	return res
 }
 
@@ -546,7 +638,8 @@ func (system *System) AppDeploymentConfig() *appsv1.DeploymentConfig {
 						ExecNewPod: &appsv1.ExecNewPodHook{
 							Command:       []string{"bash", "-c", "bundle exec rake boot openshift:post_deploy"},
 							Env:           system.buildSystemAppPostHookEnv(),
-							ContainerName: SystemAppMasterContainerName}}},
+							ContainerName: SystemAppMasterContainerName,
+							Volumes:       system.redisVolumeNames()}}},
 			},
 			MinReadySeconds: 0,
 			Triggers: appsv1.DeploymentTriggerPolicies{
@@ -755,6 +848,7 @@ func (system *System) SidekiqPodVolumes() []v1.Volume {
 	}
 
 	res = append(res, systemConfigVolume)
+	res = append(res, system.redisVolumes()...)
 	return res
 }
 
@@ -791,7 +885,7 @@ func (system *System) SidekiqDeploymentConfig() *appsv1.DeploymentConfig {
 					Type: appsv1.DeploymentTriggerOnImageChange,
 					ImageChangeParams: &appsv1.DeploymentTriggerImageChangeParams{
 						Automatic:      true,
-						ContainerNames: []string{"check-svc", SystemSidekiqName},
+						ContainerNames: []string{SystemSidekiqCheckSvcName, SystemSidekiqName},
 						From: v1.ObjectReference{
 							Kind: "ImageStreamTag",
 							Name: fmt.Sprintf("amp-system:%s", system.Options.ImageTag)}}},
@@ -808,14 +902,15 @@ func (system *System) SidekiqDeploymentConfig() *appsv1.DeploymentConfig {
 					Volumes:     system.SidekiqPodVolumes(),
 					InitContainers: []v1.Container{
 						v1.Container{
-							Name:  "check-svc",
+							Name:  SystemSidekiqCheckSvcName,
 							Image: "amp-system:latest",
 							Command: []string{
 								"bash",
 								"-c",
 								"bundle exec sh -c \"until rake boot:redis && curl --output /dev/null --silent --fail --head http://system-master:3000/status; do sleep $SLEEP_SECONDS; done\"",
 							},
-							Env: append(system.SystemRedisEnvVars(), helper.EnvVarFromValue("SLEEP_SECONDS", "1")),
+							Env:          append(system.SystemRedisEnvVars(), helper.EnvVarFromValue("SLEEP_SECONDS", "1")),
+							VolumeMounts: system.redisOnlyVolumeMounts(),
 						},
 					},
 					Containers: []v1.Container{
@@ -858,6 +953,7 @@ func (system *System) appCommonContainerVolumeMounts(systemStorageReadonly bool)
 		res = append(res, system.systemStorageVolumeMount(systemStorageReadonly))
 	}
 	res = append(res, system.systemConfigVolumeMount())
+	res = append(res, system.redisVolumeMounts()...)
 
 	return res
 }
@@ -888,6 +984,7 @@ func (system *System) sidekiqContainerVolumeMounts() []v1.VolumeMount {
 	}
 	res = append(res, systemTmpVolumeMount)
 	res = append(res, system.systemConfigVolumeMount())
+	res = append(res, system.redisVolumeMounts()...)
 	return res
 }
 
@@ -1182,7 +1279,7 @@ func (system *System) SphinxDeploymentConfig() *appsv1.DeploymentConfig {
 							},
 						},
 					},
-					Volumes: []v1.Volume{
+					Volumes: append([]v1.Volume{
 						v1.Volume{
 							Name: "system-sphinx-database",
 							VolumeSource: v1.VolumeSource{
@@ -1191,19 +1288,19 @@ func (system *System) SphinxDeploymentConfig() *appsv1.DeploymentConfig {
 								},
 							},
 						},
-					},
+					}, system.redisOnlyVolumes()...),
 					Containers: []v1.Container{
 						v1.Container{
-							Name:            "system-sphinx",
+							Name:            SystemSphinxName,
 							Image:           "amp-system:latest",
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Args:            []string{"rake", "openshift:thinking_sphinx:start"},
-							VolumeMounts: []v1.VolumeMount{
+							VolumeMounts: append([]v1.VolumeMount{
 								v1.VolumeMount{
 									Name:      "system-sphinx-database",
 									MountPath: "/opt/system/db/sphinx",
 								},
-							},
+							}, system.redisOnlyVolumeMounts()...),
 							Env: system.buildSystemSphinxEnv(),
 							LivenessProbe: &v1.Probe{
 								Handler: v1.Handler{
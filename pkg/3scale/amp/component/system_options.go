@@ -49,6 +49,12 @@ type SystemOptions struct {
 	AppReplicas     *int32 `validate:"required"`
 	SidekiqReplicas *int32 `validate:"required"`
 
+	AppAutoscaling *AutoscalingOptions
+
+	RedisConnection           *RedisConnectionOptions
+	MessageBusRedisConnection *RedisConnectionOptions
+	BackendRedisConnection    *RedisConnectionOptions
+
 	AdminAccessToken    string  `validate:"required"`
 	AdminPassword       string  `validate:"required"`
 	AdminUsername       string  `validate:"required"`
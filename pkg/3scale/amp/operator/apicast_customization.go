@@ -0,0 +1,93 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func (a *ApicastOptionsProvider) setCustomizationOptions() error {
+	stagingCustomization, err := apicastCustomizationOptions(a.apimanager.Spec.Apicast.StagingSpec.ApicastCustomizationSpec)
+	if err != nil {
+		return fmt.Errorf("apicast staging: %w", err)
+	}
+	a.apicastOptions.StagingCustomization = stagingCustomization
+
+	productionCustomization, err := apicastCustomizationOptions(a.apimanager.Spec.Apicast.ProductionSpec.ApicastCustomizationSpec)
+	if err != nil {
+		return fmt.Errorf("apicast production: %w", err)
+	}
+	a.apicastOptions.ProductionCustomization = productionCustomization
+
+	return nil
+}
+
+func apicastCustomizationOptions(spec appsv1alpha1.ApicastCustomizationSpec) (component.ApicastCustomizationOptions, error) {
+	options := component.ApicastCustomizationOptions{
+		LogLevel:            spec.LogLevel,
+		ServicesFilterByURL: spec.ServicesFilterByURL,
+		AllProxy:            spec.AllProxy,
+		HTTPProxy:           spec.HTTPProxy,
+		HTTPSProxy:          spec.HTTPSProxy,
+		NoProxy:             spec.NoProxy,
+	}
+
+	if spec.CACertificateSecretRef != nil {
+		options.CACertificateSecretName = &spec.CACertificateSecretRef.Name
+	}
+
+	if err := spec.ValidateEnv(); err != nil {
+		return options, err
+	}
+	options.Env = spec.Env
+
+	for _, policy := range spec.CustomPolicies {
+		volumeSource, err := configMapOrSecretVolumeSource(policy.ConfigMapRef, policy.SecretRef)
+		if err != nil {
+			return options, fmt.Errorf("custom policy %s %s: %w", policy.Name, policy.Version, err)
+		}
+		options.CustomPolicies = append(options.CustomPolicies, component.ApicastCustomPolicy{
+			Name:         policy.Name,
+			Version:      policy.Version,
+			VolumeSource: volumeSource,
+		})
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.Name == component.ApicastCACertificateVolumeName ||
+			strings.HasPrefix(volume.Name, component.ApicastCustomPolicyVolumeNamePrefix) {
+			return options, fmt.Errorf("volume %s: name is reserved by the operator", volume.Name)
+		}
+		volumeSource, err := configMapOrSecretVolumeSource(volume.ConfigMapRef, volume.SecretRef)
+		if err != nil {
+			return options, fmt.Errorf("volume %s: %w", volume.Name, err)
+		}
+		options.Volumes = append(options.Volumes, component.ApicastCustomVolume{
+			Name:         volume.Name,
+			MountPath:    volume.MountPath,
+			VolumeSource: volumeSource,
+		})
+	}
+
+	return options, nil
+}
+
+func configMapOrSecretVolumeSource(configMapRef, secretRef *v1.LocalObjectReference) (v1.VolumeSource, error) {
+	if (configMapRef == nil) == (secretRef == nil) {
+		return v1.VolumeSource{}, fmt.Errorf("exactly one of configMapRef or secretRef must be set")
+	}
+
+	if configMapRef != nil {
+		return v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: *configMapRef},
+		}, nil
+	}
+
+	return v1.VolumeSource{
+		Secret: &v1.SecretVolumeSource{SecretName: secretRef.Name},
+	}, nil
+}
@@ -45,6 +45,11 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 	a.setNodeAffinityAndTolerationsOptions()
 	a.setReplicas()
 
+	err = a.setCustomizationOptions()
+	if err != nil {
+		return nil, fmt.Errorf("GetApicastOptions: %w", err)
+	}
+
 	err = a.apicastOptions.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("GetApicastOptions validating: %w", err)
@@ -84,6 +89,12 @@ func (a *ApicastOptionsProvider) setNodeAffinityAndTolerationsOptions() {
 func (a *ApicastOptionsProvider) setReplicas() {
 	a.apicastOptions.ProductionReplicas = int32(*a.apimanager.Spec.Apicast.ProductionSpec.Replicas)
 	a.apicastOptions.StagingReplicas = int32(*a.apimanager.Spec.Apicast.StagingSpec.Replicas)
+
+	// Autoscaled replicas start from the lower limit
+	a.apicastOptions.ProductionAutoscaling = autoscalingOptions(a.apimanager.Spec.Apicast.ProductionSpec.Autoscaling)
+	if a.apicastOptions.ProductionAutoscaling != nil {
+		a.apicastOptions.ProductionReplicas = a.apicastOptions.ProductionAutoscaling.MinReplicas
+	}
 }
 
 func (a *ApicastOptionsProvider) commonLabels() map[string]string {
@@ -235,6 +235,73 @@ func TestGetApicastOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{"WithProductionAutoscaling",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestApicastOptions()
+				apimanager.Spec.Apicast.ProductionSpec.Autoscaling = &appsv1alpha1.AutoscalingSpec{
+					MinReplicas:                       &[]int32{2}[0],
+					MaxReplicas:                       5,
+					TargetMemoryUtilizationPercentage: &[]int32{70}[0],
+				}
+				return apimanager
+			},
+			func() *component.ApicastOptions {
+				opts := defaultApicastOptions()
+				opts.ProductionReplicas = 2
+				opts.ProductionAutoscaling = &component.AutoscalingOptions{
+					MinReplicas:                       2,
+					MaxReplicas:                       5,
+					TargetMemoryUtilizationPercentage: &[]int32{70}[0],
+				}
+				return opts
+			},
+		},
+		{"WithStagingCustomization",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestApicastOptions()
+				apimanager.Spec.Apicast.StagingSpec.ApicastCustomizationSpec = appsv1alpha1.ApicastCustomizationSpec{
+					LogLevel:               &[]string{"debug"}[0],
+					HTTPSProxy:             &[]string{"http://proxy.example.com:3128"}[0],
+					CACertificateSecretRef: &v1.LocalObjectReference{Name: "ca-secret"},
+					CustomPolicies: []appsv1alpha1.CustomPolicySpec{
+						{Name: "example", Version: "0.1", ConfigMapRef: &v1.LocalObjectReference{Name: "example-policy"}},
+					},
+					Volumes: []appsv1alpha1.CustomVolumeSpec{
+						{Name: "templates", MountPath: "/opt/templates", SecretRef: &v1.LocalObjectReference{Name: "templates"}},
+					},
+					Env: []v1.EnvVar{{Name: "APICAST_WORKERS", Value: "2"}},
+				}
+				return apimanager
+			},
+			func() *component.ApicastOptions {
+				opts := defaultApicastOptions()
+				opts.StagingCustomization = component.ApicastCustomizationOptions{
+					LogLevel:                &[]string{"debug"}[0],
+					HTTPSProxy:              &[]string{"http://proxy.example.com:3128"}[0],
+					CACertificateSecretName: &[]string{"ca-secret"}[0],
+					CustomPolicies: []component.ApicastCustomPolicy{
+						{
+							Name:    "example",
+							Version: "0.1",
+							VolumeSource: v1.VolumeSource{
+								ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "example-policy"}},
+							},
+						},
+					},
+					Volumes: []component.ApicastCustomVolume{
+						{
+							Name:      "templates",
+							MountPath: "/opt/templates",
+							VolumeSource: v1.VolumeSource{
+								Secret: &v1.SecretVolumeSource{SecretName: "templates"},
+							},
+						},
+					},
+					Env: []v1.EnvVar{{Name: "APICAST_WORKERS", Value: "2"}},
+				}
+				return opts
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -251,3 +318,87 @@ func TestGetApicastOptionsProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestGetApicastOptionsProviderInvalidCustomization(t *testing.T) {
+	cases := []struct {
+		name          string
+		customization appsv1alpha1.ApicastCustomizationSpec
+	}{
+		{"PolicyWithoutSource",
+			appsv1alpha1.ApicastCustomizationSpec{
+				CustomPolicies: []appsv1alpha1.CustomPolicySpec{{Name: "example", Version: "0.1"}},
+			},
+		},
+		{"VolumeWithBothSources",
+			appsv1alpha1.ApicastCustomizationSpec{
+				Volumes: []appsv1alpha1.CustomVolumeSpec{
+					{
+						Name:         "templates",
+						MountPath:    "/opt/templates",
+						ConfigMapRef: &v1.LocalObjectReference{Name: "templates"},
+						SecretRef:    &v1.LocalObjectReference{Name: "templates"},
+					},
+				},
+			},
+		},
+		{"VolumeWithReservedName",
+			appsv1alpha1.ApicastCustomizationSpec{
+				Volumes: []appsv1alpha1.CustomVolumeSpec{
+					{Name: "custom-policy-0", MountPath: "/opt/policy", ConfigMapRef: &v1.LocalObjectReference{Name: "policy"}},
+				},
+			},
+		},
+		{"EnvWithReservedName",
+			appsv1alpha1.ApicastCustomizationSpec{
+				Env: []v1.EnvVar{{Name: "THREESCALE_DEPLOYMENT_ENV", Value: "staging"}},
+			},
+		},
+		{"EnvSetByCustomizationField",
+			appsv1alpha1.ApicastCustomizationSpec{
+				HTTPProxy: &[]string{"http://proxy:8080"}[0],
+				Env:       []v1.EnvVar{{Name: "HTTP_PROXY", Value: "http://other:8080"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			apimanager := basicApimanagerTestApicastOptions()
+			apimanager.Spec.Apicast.ProductionSpec.ApicastCustomizationSpec = tc.customization
+			_, err := NewApicastOptionsProvider(apimanager).GetApicastOptions()
+			if err == nil {
+				subT.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestApicastCustomizationManagedEnvValidated(t *testing.T) {
+	value := "value"
+	spec := appsv1alpha1.ApicastCustomizationSpec{
+		LogLevel:               &value,
+		ServicesFilterByURL:    &value,
+		AllProxy:               &value,
+		HTTPProxy:              &value,
+		HTTPSProxy:             &value,
+		NoProxy:                &value,
+		CACertificateSecretRef: &v1.LocalObjectReference{Name: "ca"},
+	}
+
+	options, err := apicastCustomizationOptions(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every environment variable set from the typed fields is rejected
+	// when set in the custom env
+	for _, envVar := range component.ApicastCustomizationManagedEnv(options) {
+		t.Run(envVar.Name, func(subT *testing.T) {
+			customization := spec
+			customization.Env = []v1.EnvVar{{Name: envVar.Name, Value: value}}
+			if err := customization.ValidateEnv(); err == nil {
+				subT.Errorf("expected error for env %s", envVar.Name)
+			}
+		})
+	}
+}
@@ -39,6 +39,18 @@ func ApicastEnvCMMutator(existingObj, desiredObj common.KubernetesObject) (bool,
 	return update, nil
 }
 
+// ApicastDeploymentConfigMutator reconciles, besides the generic fields, the
+// custom environment variables and volumes of the apicast container
+func ApicastDeploymentConfigMutator(containerName string) reconcilers.MutateFn {
+	return reconcilers.DeploymentConfigEnvAndVolumesMutator(reconcilers.GenericDeploymentConfigMutator, containerName)
+}
+
+// ApicastDeploymentMutator reconciles, besides the generic fields, the
+// custom environment variables and volumes of the apicast container
+func ApicastDeploymentMutator(containerName string) reconcilers.MutateFn {
+	return reconcilers.DeploymentEnvAndVolumesMutator(reconcilers.GenericDeploymentMutator, containerName)
+}
+
 type ApicastReconciler struct {
 	*BaseAPIManagerLogicReconciler
 }
@@ -56,13 +68,15 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Staging DC
-	err = r.ReconcileDeploymentConfig(apicast.StagingDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileWorkload(apicast.StagingDeploymentConfig(),
+		ApicastDeploymentConfigMutator(component.ApicastStagingName), ApicastDeploymentMutator(component.ApicastStagingName))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// Production DC
-	err = r.ReconcileDeploymentConfig(apicast.ProductionDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileAutoscaledWorkload(apicast.ProductionDeploymentConfig(), apicast.Options.ProductionAutoscaling,
+		ApicastDeploymentConfigMutator(component.ApicastProductionName), ApicastDeploymentMutator(component.ApicastProductionName))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -107,7 +121,7 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(component.ApicastPrometheusRules(r.apiManager.Namespace), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(component.ApicastPrometheusRules(r.apiManager.Namespace), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
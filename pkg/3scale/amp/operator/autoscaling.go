@@ -0,0 +1,26 @@
+package operator
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+// autoscalingOptions returns the autoscaling options of a component,
+// nil when the component is not autoscaled
+func autoscalingOptions(spec *appsv1alpha1.AutoscalingSpec) *component.AutoscalingOptions {
+	if spec == nil {
+		return nil
+	}
+
+	minReplicas := int32(1)
+	if spec.MinReplicas != nil {
+		minReplicas = *spec.MinReplicas
+	}
+
+	return &component.AutoscalingOptions{
+		MinReplicas:                       minReplicas,
+		MaxReplicas:                       spec.MaxReplicas,
+		TargetCPUUtilizationPercentage:    spec.TargetCPUUtilizationPercentage,
+		TargetMemoryUtilizationPercentage: spec.TargetMemoryUtilizationPercentage,
+	}
+}
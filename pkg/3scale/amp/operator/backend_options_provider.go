@@ -40,6 +40,11 @@ func (o *OperatorBackendOptionsProvider) GetBackendOptions() (*component.Backend
 		return nil, fmt.Errorf("GetBackendOptions reading secret options: %w", err)
 	}
 
+	err = o.setRedisConnectionOptions()
+	if err != nil {
+		return nil, fmt.Errorf("GetBackendOptions reading redis connection options: %w", err)
+	}
+
 	o.setResourceRequirementsOptions()
 	o.setNodeAffinityAndTolerationsOptions()
 	o.setReplicas()
@@ -148,6 +153,16 @@ func (o *OperatorBackendOptionsProvider) setReplicas() {
 	o.backendOptions.ListenerReplicas = int32(*o.apimanager.Spec.Backend.ListenerSpec.Replicas)
 	o.backendOptions.WorkerReplicas = int32(*o.apimanager.Spec.Backend.WorkerSpec.Replicas)
 	o.backendOptions.CronReplicas = int32(*o.apimanager.Spec.Backend.CronSpec.Replicas)
+
+	// Autoscaled replicas start from the lower limit
+	o.backendOptions.ListenerAutoscaling = autoscalingOptions(o.apimanager.Spec.Backend.ListenerSpec.Autoscaling)
+	if o.backendOptions.ListenerAutoscaling != nil {
+		o.backendOptions.ListenerReplicas = o.backendOptions.ListenerAutoscaling.MinReplicas
+	}
+	o.backendOptions.WorkerAutoscaling = autoscalingOptions(o.apimanager.Spec.Backend.WorkerSpec.Autoscaling)
+	if o.backendOptions.WorkerAutoscaling != nil {
+		o.backendOptions.WorkerReplicas = o.backendOptions.WorkerAutoscaling.MinReplicas
+	}
 }
 
 func (o *OperatorBackendOptionsProvider) commonLabels() map[string]string {
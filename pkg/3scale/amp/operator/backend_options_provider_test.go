@@ -328,6 +328,27 @@ func TestGetBackendOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{"WithExternalRedis", nil, nil,
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestBackendOptions()
+				apimanager.Spec.HighAvailability = &appsv1alpha1.HighAvailabilitySpec{
+					Enabled:       true,
+					ExternalRedis: testExternalRedisSpec(),
+				}
+				return apimanager
+			},
+			func(in *component.BackendOptions) *component.BackendOptions {
+				opts := defaultBackendOptions(in)
+				opts.StorageRedisConnection = &component.RedisConnectionOptions{
+					Username: true,
+					Password: true,
+					TLS: &component.RedisTLSOptions{
+						CASecretName: &[]string{"redis-ca"}[0],
+					},
+				}
+				return opts
+			},
+		},
 	}
 
 	for _, tc := range cases {
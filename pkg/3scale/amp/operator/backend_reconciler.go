@@ -26,13 +26,17 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Cron DC
-	err = r.ReconcileDeploymentConfig(backend.CronDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileWorkload(backend.CronDeploymentConfig(),
+		reconcilers.DeploymentConfigEnvAndVolumesMutator(reconcilers.GenericDeploymentConfigMutator, component.BackendCronName, component.BackendRedisCheckName),
+		reconcilers.DeploymentEnvAndVolumesMutator(reconcilers.GenericDeploymentMutator, component.BackendCronName, component.BackendRedisCheckName))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// Listerner DC
-	err = r.ReconcileDeploymentConfig(backend.ListenerDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileAutoscaledWorkload(backend.ListenerDeploymentConfig(), backend.Options.ListenerAutoscaling,
+		reconcilers.DeploymentConfigEnvAndVolumesMutator(reconcilers.GenericDeploymentConfigMutator, component.BackendListenerName),
+		reconcilers.DeploymentEnvAndVolumesMutator(reconcilers.GenericDeploymentMutator, component.BackendListenerName))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -50,7 +54,9 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Worker DC
-	err = r.ReconcileDeploymentConfig(backend.WorkerDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileAutoscaledWorkload(backend.WorkerDeploymentConfig(), backend.Options.WorkerAutoscaling,
+		reconcilers.DeploymentConfigEnvAndVolumesMutator(reconcilers.GenericDeploymentConfigMutator, component.BackendWorkerName, component.BackendRedisCheckName),
+		reconcilers.DeploymentEnvAndVolumesMutator(reconcilers.GenericDeploymentMutator, component.BackendWorkerName, component.BackendRedisCheckName))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -106,12 +112,12 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(component.BackendWorkerPrometheusRules(r.apiManager.Namespace), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(component.BackendWorkerPrometheusRules(r.apiManager.Namespace), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(component.BackendListenerPrometheusRules(r.apiManager.Namespace), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(component.BackendListenerPrometheusRules(r.apiManager.Namespace), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/common"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
@@ -14,11 +15,16 @@ import (
 	appsv1 "github.com/openshift/api/apps/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type BaseAPIManagerLogicReconciler struct {
@@ -26,6 +32,7 @@ type BaseAPIManagerLogicReconciler struct {
 	apiManager           *appsv1alpha1.APIManager
 	logger               logr.Logger
 	crdAvailabilityCache *baseAPIManagerLogicReconcilerCRDAvailabilityCache
+	deploymentImages     map[string]string
 }
 
 type baseAPIManagerLogicReconcilerCRDAvailabilityCache struct {
@@ -33,6 +40,12 @@ type baseAPIManagerLogicReconcilerCRDAvailabilityCache struct {
 	prometheusRuleCRDAvailable   *bool
 	podMonitorCRDAvailable       *bool
 	serviceMonitorCRDAvailable   *bool
+	deploymentConfigAvailable    *bool
+	imageStreamAvailable         *bool
+	routeAvailable               *bool
+	ingressAvailable             *bool
+	httpRouteAvailable           *bool
+	hpaAvailable                 *bool
 }
 
 func NewBaseAPIManagerLogicReconciler(b *reconcilers.BaseReconciler, apiManager *appsv1alpha1.APIManager) *BaseAPIManagerLogicReconciler {
@@ -56,6 +69,14 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePodDisruptionBudget(desired *v1
 }
 
 func (r *BaseAPIManagerLogicReconciler) ReconcileImagestream(desired *imagev1.ImageStream, mutatefn reconcilers.MutateFn) error {
+	if r.apiManager.UsesDeployments() {
+		// Deployments reference the images directly
+		kindExists, err := r.HasImageStreams()
+		if err != nil || !kindExists {
+			return err
+		}
+		common.TagObjectToDelete(desired)
+	}
 	return r.ReconcileResource(&imagev1.ImageStream{}, desired, mutatefn)
 }
 
@@ -63,6 +84,203 @@ func (r *BaseAPIManagerLogicReconciler) ReconcileDeploymentConfig(desired *appsv
 	return r.ReconcileResource(&appsv1.DeploymentConfig{}, desired, mutatefn)
 }
 
+func (r *BaseAPIManagerLogicReconciler) ReconcileDeployment(desired *k8sappsv1.Deployment, mutatefn reconcilers.MutateFn) error {
+	return r.ReconcileResource(&k8sappsv1.Deployment{}, desired, mutatefn)
+}
+
+// ReconcileWorkload reconciles the workload described by the desired
+// DeploymentConfig. When the APIManager uses Deployments, the equivalent
+// Deployment is reconciled instead and the DeploymentConfig of a previous
+// install, if any, is removed once replaced
+func (r *BaseAPIManagerLogicReconciler) ReconcileWorkload(desired *appsv1.DeploymentConfig, dcMutateFn, deploymentMutateFn reconcilers.MutateFn) error {
+	if !r.apiManager.UsesDeployments() {
+		return r.ReconcileDeploymentConfig(desired, dcMutateFn)
+	}
+
+	images, err := r.DeploymentImages()
+	if err != nil {
+		return err
+	}
+
+	deployment, err := component.DeploymentFromDeploymentConfig(desired, images)
+	if err != nil {
+		return err
+	}
+
+	// Pods of a new template are not rolled out until the pre hook succeeds.
+	// Job status changes trigger a new reconciliation
+	preHookDone, err := r.reconcileDeploymentPreHook(desired, deployment)
+	if err != nil || !preHookDone {
+		return err
+	}
+
+	// Recreate strategy is used by the workloads mounting ReadWriteOnce volumes.
+	// The DeploymentConfig pods have to release the volumes first
+	if desired.Spec.Strategy.Type == appsv1.DeploymentStrategyTypeRecreate {
+		err = r.deleteReplacedDeploymentConfig(desired)
+		if err != nil {
+			return err
+		}
+		return r.ReconcileDeployment(deployment, deploymentMutateFn)
+	}
+
+	err = r.ReconcileDeployment(deployment, deploymentMutateFn)
+	if err != nil {
+		return err
+	}
+
+	// The DeploymentConfig keeps serving until the Deployment is available.
+	// Deployment status changes trigger a new reconciliation
+	available, err := r.deploymentAvailable(deployment)
+	if err != nil || !available {
+		return err
+	}
+
+	return r.deleteReplacedDeploymentConfig(desired)
+}
+
+// ReconcileAutoscaledWorkload reconciles a workload that can be autoscaled and
+// its HorizontalPodAutoscaler. Replicas of autoscaled workloads are not
+// reconciled. The HorizontalPodAutoscaler is removed when autoscaling is nil
+func (r *BaseAPIManagerLogicReconciler) ReconcileAutoscaledWorkload(desired *appsv1.DeploymentConfig, autoscaling *component.AutoscalingOptions, dcMutateFn, deploymentMutateFn reconcilers.MutateFn) error {
+	if autoscaling != nil {
+		dcMutateFn = reconcilers.ReplicasIgnoredMutator(dcMutateFn)
+		deploymentMutateFn = reconcilers.ReplicasIgnoredMutator(deploymentMutateFn)
+	}
+
+	err := r.ReconcileWorkload(desired, dcMutateFn, deploymentMutateFn)
+	if err != nil {
+		return err
+	}
+
+	hpa := component.HorizontalPodAutoscaler(desired, autoscaling)
+	if autoscaling == nil {
+		common.TagObjectToDelete(hpa)
+	}
+	return r.ReconcileHorizontalPodAutoscaler(hpa, reconcilers.GenericHorizontalPodAutoscalerMutator)
+}
+
+func (r *BaseAPIManagerLogicReconciler) ReconcileHorizontalPodAutoscaler(desired *autoscalingv2beta2.HorizontalPodAutoscaler, mutatefn reconcilers.MutateFn) error {
+	kindExists, err := r.HasHorizontalPodAutoscalers()
+	if err != nil {
+		return err
+	}
+
+	if !kindExists {
+		if !common.IsObjectTaggedToDelete(desired) {
+			errToLog := fmt.Errorf("Error creating horizontalpodautoscaler object '%s'. %s HorizontalPodAutoscalers are not supported in your cluster", desired.Name, autoscalingv2beta2.SchemeGroupVersion)
+			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "ReconcileError", "%s", errToLog.Error())
+			r.logger.Error(errToLog, "ReconcileError")
+		}
+		return nil
+	}
+
+	if r.apiManager.UsesDeployments() && desired.Spec.ScaleTargetRef.Kind == "DeploymentConfig" {
+		desired.Spec.ScaleTargetRef.APIVersion = "apps/v1"
+		desired.Spec.ScaleTargetRef.Kind = "Deployment"
+	}
+	return r.ReconcileResource(&autoscalingv2beta2.HorizontalPodAutoscaler{}, desired, mutatefn)
+}
+
+func (r *BaseAPIManagerLogicReconciler) deleteReplacedDeploymentConfig(desired *appsv1.DeploymentConfig) error {
+	kindExists, err := r.HasDeploymentConfigs()
+	if err != nil || !kindExists {
+		return err
+	}
+
+	dc := desired.DeepCopy()
+	common.TagToObjectDeleteWithPropagationPolicy(dc, metav1.DeletePropagationForeground)
+	return r.ReconcileDeploymentConfig(dc, reconcilers.CreateOnlyMutator)
+}
+
+// reconcileDeploymentPreHook runs the pre lifecycle hook of the desired
+// DeploymentConfig as a Job for the pod template of the given Deployment.
+// Returns whether the hook Job has succeeded. Failed Jobs are recreated to
+// retry the hook, and the Jobs of previous templates are removed once the
+// current one succeeds
+func (r *BaseAPIManagerLogicReconciler) reconcileDeploymentPreHook(desired *appsv1.DeploymentConfig, deployment *k8sappsv1.Deployment) (bool, error) {
+	job, err := component.DeploymentPreHookJob(desired, deployment)
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return true, nil
+	}
+
+	err = r.ReconcileResource(&batchv1.Job{}, job, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return false, err
+	}
+
+	existing := &batchv1.Job{}
+	err = r.GetResource(r.NamespacedNameWithAPIManagerNamespace(job), existing)
+	if err != nil {
+		return false, err
+	}
+
+	for _, condition := range existing.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			err = r.DeleteResource(existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if err != nil && !errors.IsNotFound(err) {
+				return false, err
+			}
+			return false, fmt.Errorf("pre hook job %s failed: %s", existing.Name, condition.Message)
+		}
+	}
+
+	if existing.Status.Succeeded == 0 {
+		r.Logger().V(1).Info("Pre hook job has still not finished", "job", existing.Name)
+		return false, nil
+	}
+
+	previousJobs := &batchv1.JobList{}
+	err = r.Client().List(r.Context(), previousJobs, client.InNamespace(r.apiManager.Namespace), client.MatchingLabels{component.DeploymentPreHookLabel: desired.Name})
+	if err != nil {
+		return false, err
+	}
+	for idx := range previousJobs.Items {
+		if previousJobs.Items[idx].Name == existing.Name {
+			continue
+		}
+		err = r.DeleteResource(&previousJobs.Items[idx], client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (r *BaseAPIManagerLogicReconciler) deploymentAvailable(desired *k8sappsv1.Deployment) (bool, error) {
+	existing := &k8sappsv1.Deployment{}
+	err := r.GetResource(r.NamespacedNameWithAPIManagerNamespace(desired), existing)
+	if err != nil {
+		return false, err
+	}
+
+	replicas := int32(1)
+	if existing.Spec.Replicas != nil {
+		replicas = *existing.Spec.Replicas
+	}
+
+	return existing.Status.ObservedGeneration >= existing.Generation &&
+		existing.Status.UpdatedReplicas >= replicas &&
+		existing.Status.AvailableReplicas >= replicas, nil
+}
+
+// DeploymentImages returns the images of the Deployments, keyed by the
+// ImageStreamTag the DeploymentConfigs reference
+func (r *BaseAPIManagerLogicReconciler) DeploymentImages() (map[string]string, error) {
+	if r.deploymentImages == nil {
+		images, err := DeploymentImages(r.apiManager, r.Client())
+		if err != nil {
+			return nil, err
+		}
+		r.deploymentImages = images
+	}
+	return r.deploymentImages, nil
+}
+
 func (r *BaseAPIManagerLogicReconciler) ReconcileService(desired *v1.Service, mutateFn reconcilers.MutateFn) error {
 	return r.ReconcileResource(&v1.Service{}, desired, mutateFn)
 }
@@ -76,6 +294,18 @@ func (r *BaseAPIManagerLogicReconciler) ReconcileServiceAccount(desired *v1.Serv
 }
 
 func (r *BaseAPIManagerLogicReconciler) ReconcileRoute(desired *routev1.Route, mutateFn reconcilers.MutateFn) error {
+	if !r.apiManager.UsesRoutes() {
+		// The host is exposed by the ExposureReconciler.
+		// Routes only need to be removed when the cluster supports them
+		kindExists, err := r.HasRoutes()
+		if err != nil {
+			return err
+		}
+		if !kindExists {
+			return nil
+		}
+		common.TagObjectToDelete(desired)
+	}
 	return r.ReconcileResource(&routev1.Route{}, desired, mutateFn)
 }
 
@@ -113,6 +343,13 @@ func (r *BaseAPIManagerLogicReconciler) ReconcileGrafanaDashboard(desired *grafa
 	if !r.apiManager.IsMonitoringEnabled() {
 		common.TagObjectToDelete(desired)
 	}
+
+	desired.Labels = monitoringExtraLabels(r.apiManager.Spec.Monitoring, desired.Labels)
+	err = reconcilers.SetMonitoringSpecChecksum(desired, desired.Spec)
+	if err != nil {
+		return err
+	}
+
 	return r.ReconcileResource(&grafanav1alpha1.GrafanaDashboard{}, desired, mutateFn)
 }
 
@@ -134,6 +371,20 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePrometheusRules(desired *monito
 	if !r.apiManager.IsMonitoringEnabled() {
 		common.TagObjectToDelete(desired)
 	}
+
+	overrideErrs := applyAlertOverrides(r.apiManager.Spec.Monitoring, desired)
+	if alertOverrideErrorsChanged(fmt.Sprintf("%s/%s", r.apiManager.UID, desired.Name), overrideErrs) {
+		for _, overrideErr := range overrideErrs {
+			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "InvalidAlertOverride", "%s", overrideErr.Error())
+			r.logger.Info(overrideErr.Error(), "prometheusrule", desired.Name)
+		}
+	}
+	desired.Labels = monitoringExtraLabels(r.apiManager.Spec.Monitoring, desired.Labels)
+	err = reconcilers.SetMonitoringSpecChecksum(desired, desired.Spec)
+	if err != nil {
+		return err
+	}
+
 	return r.ReconcileResource(&monitoringv1.PrometheusRule{}, desired, mutateFn)
 }
 
@@ -265,3 +516,81 @@ func (b *BaseAPIManagerLogicReconciler) HasPodMonitors() (bool, error) {
 	}
 	return *b.crdAvailabilityCache.podMonitorCRDAvailable, nil
 }
+
+//HasDeploymentConfigs checks if the DeploymentConfig API is supported in current cluster
+func (b *BaseAPIManagerLogicReconciler) HasDeploymentConfigs() (bool, error) {
+	if b.crdAvailabilityCache.deploymentConfigAvailable == nil {
+		res, err := b.BaseReconciler.HasDeploymentConfigs()
+		if err != nil {
+			return res, err
+		}
+		b.crdAvailabilityCache.deploymentConfigAvailable = &res
+		return res, err
+	}
+	return *b.crdAvailabilityCache.deploymentConfigAvailable, nil
+}
+
+//HasImageStreams checks if the ImageStream API is supported in current cluster
+func (b *BaseAPIManagerLogicReconciler) HasImageStreams() (bool, error) {
+	if b.crdAvailabilityCache.imageStreamAvailable == nil {
+		res, err := b.BaseReconciler.HasImageStreams()
+		if err != nil {
+			return res, err
+		}
+		b.crdAvailabilityCache.imageStreamAvailable = &res
+		return res, err
+	}
+	return *b.crdAvailabilityCache.imageStreamAvailable, nil
+}
+
+//HasRoutes checks if the Route API is supported in current cluster
+func (b *BaseAPIManagerLogicReconciler) HasRoutes() (bool, error) {
+	if b.crdAvailabilityCache.routeAvailable == nil {
+		res, err := b.BaseReconciler.HasRoutes()
+		if err != nil {
+			return res, err
+		}
+		b.crdAvailabilityCache.routeAvailable = &res
+		return res, err
+	}
+	return *b.crdAvailabilityCache.routeAvailable, nil
+}
+
+//HasIngresses checks if the networking.k8s.io/v1 Ingress API is supported in current cluster
+func (b *BaseAPIManagerLogicReconciler) HasIngresses() (bool, error) {
+	if b.crdAvailabilityCache.ingressAvailable == nil {
+		res, err := b.BaseReconciler.HasIngresses()
+		if err != nil {
+			return res, err
+		}
+		b.crdAvailabilityCache.ingressAvailable = &res
+		return res, err
+	}
+	return *b.crdAvailabilityCache.ingressAvailable, nil
+}
+
+//HasHTTPRoutes checks if the Gateway API HTTPRoute CRD is supported in current cluster
+func (b *BaseAPIManagerLogicReconciler) HasHTTPRoutes() (bool, error) {
+	if b.crdAvailabilityCache.httpRouteAvailable == nil {
+		res, err := b.BaseReconciler.HasHTTPRoutes()
+		if err != nil {
+			return res, err
+		}
+		b.crdAvailabilityCache.httpRouteAvailable = &res
+		return res, err
+	}
+	return *b.crdAvailabilityCache.httpRouteAvailable, nil
+}
+
+//HasHorizontalPodAutoscalers checks if the autoscaling/v2beta2 HorizontalPodAutoscaler API is supported in current cluster
+func (b *BaseAPIManagerLogicReconciler) HasHorizontalPodAutoscalers() (bool, error) {
+	if b.crdAvailabilityCache.hpaAvailable == nil {
+		res, err := b.BaseReconciler.HasHorizontalPodAutoscalers()
+		if err != nil {
+			return res, err
+		}
+		b.crdAvailabilityCache.hpaAvailable = &res
+		return res, err
+	}
+	return *b.crdAvailabilityCache.hpaAvailable, nil
+}
@@ -5,12 +5,17 @@ import (
 	"testing"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
 	appsv1 "github.com/openshift/api/apps/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
@@ -505,3 +510,240 @@ func TestBaseAPIManagerLogicReconcilerHasServiceMonitors(t *testing.T) {
 		t.Fatalf("Unexpected exists value received. Expected: %t, got: %t", false, exists)
 	}
 }
+
+func TestBaseAPIManagerLogicReconcilerReconcileWorkloadMigration(t *testing.T) {
+	var (
+		log = logf.Log.WithName("operator_test")
+	)
+
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	deploymentKind := appsv1alpha1.DeploymentKindDeployment
+	apimanager.Spec.DeploymentKind = &deploymentKind
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	memcached, err := Memcached(apimanager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingDC := memcached.DeploymentConfig()
+	existingDC.Namespace = namespace
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager, existingDC}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	clientset.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: appsv1.GroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "deploymentconfigs", Namespaced: true, Kind: "DeploymentConfig"},
+			},
+		},
+	}
+	baseReconciler := reconcilers.NewBaseReconciler(cl, s, clientAPIReader, ctx, log, clientset.Discovery(), recorder)
+	apimanagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	reconcileWorkload := func() {
+		err := apimanagerLogicReconciler.ReconcileWorkload(memcached.DeploymentConfig(),
+			reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator,
+			reconcilers.DeploymentResourcesAndAffinityAndTolerationsMutator)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	objKey := client.ObjectKey{Name: existingDC.Name, Namespace: namespace}
+
+	// The DeploymentConfig keeps serving until the Deployment is available
+	reconcileWorkload()
+
+	deployment := &k8sappsv1.Deployment{}
+	if err := cl.Get(ctx, objKey, deployment); err != nil {
+		t.Fatalf("error fetching deployment: %v", err)
+	}
+	if deployment.Spec.Template.Spec.Containers[0].Image != SystemMemcachedImageURL() {
+		t.Errorf("expected image %s, got %s", SystemMemcachedImageURL(), deployment.Spec.Template.Spec.Containers[0].Image)
+	}
+	if err := cl.Get(ctx, objKey, &appsv1.DeploymentConfig{}); err != nil {
+		t.Fatalf("expected DeploymentConfig to exist: %v", err)
+	}
+
+	deployment.Status.UpdatedReplicas = *deployment.Spec.Replicas
+	deployment.Status.AvailableReplicas = *deployment.Spec.Replicas
+	if err := cl.Status().Update(ctx, deployment); err != nil {
+		t.Fatal(err)
+	}
+
+	reconcileWorkload()
+
+	err = cl.Get(ctx, objKey, &appsv1.DeploymentConfig{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected DeploymentConfig to be deleted, got: %v", err)
+	}
+}
+
+func TestBaseAPIManagerLogicReconcilerReconcileRouteWithIngressExposure(t *testing.T) {
+	var (
+		log = logf.Log.WithName("operator_test")
+	)
+
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	exposureType := appsv1alpha1.ExposureTypeIngress
+	apimanager.Spec.Exposure = &appsv1alpha1.ExposureSpec{Type: &exposureType}
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err := Backend(apimanager, fake.NewFakeClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingRoute := backend.ListenerRoute()
+	existingRoute.Namespace = namespace
+
+	cases := []struct {
+		testName  string
+		resources []*metav1.APIResourceList
+	}{
+		{"RoutesSupported", []*metav1.APIResourceList{
+			{
+				GroupVersion: routev1.GroupVersion.String(),
+				APIResources: []metav1.APIResource{{Name: "routes", Namespaced: true, Kind: "Route"}},
+			},
+		}},
+		{"RoutesNotSupported", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			objs := []runtime.Object{apimanager}
+			if tc.resources != nil {
+				objs = append(objs, existingRoute.DeepCopy())
+			}
+			cl := fake.NewFakeClient(objs...)
+			clientset := fakeclientset.NewSimpleClientset()
+			clientset.Resources = tc.resources
+			recorder := record.NewFakeRecorder(10000)
+
+			baseReconciler := reconcilers.NewBaseReconciler(cl, s, cl, ctx, log, clientset.Discovery(), recorder)
+			apimanagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+			err := apimanagerLogicReconciler.ReconcileRoute(backend.ListenerRoute(), reconcilers.CreateOnlyMutator)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			err = cl.Get(ctx, client.ObjectKey{Name: existingRoute.Name, Namespace: namespace}, &routev1.Route{})
+			if !errors.IsNotFound(err) {
+				subT.Fatalf("expected Route not to exist, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestBaseAPIManagerLogicReconcilerReconcileAutoscaledWorkload(t *testing.T) {
+	var (
+		log = logf.Log.WithName("operator_test")
+	)
+
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	memcached, err := Memcached(apimanager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Scaled up by the HorizontalPodAutoscaler
+	existingDC := memcached.DeploymentConfig()
+	existingDC.Namespace = namespace
+	existingDC.Spec.Replicas = 4
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager, existingDC}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	clientset.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: autoscalingv2beta2.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "horizontalpodautoscalers", Namespaced: true, Kind: "HorizontalPodAutoscaler"},
+			},
+		},
+	}
+	baseReconciler := reconcilers.NewBaseReconciler(cl, s, clientAPIReader, ctx, log, clientset.Discovery(), recorder)
+	apimanagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	reconcileWorkload := func(autoscaling *component.AutoscalingOptions) {
+		err := apimanagerLogicReconciler.ReconcileAutoscaledWorkload(memcached.DeploymentConfig(), autoscaling,
+			reconcilers.GenericDeploymentConfigMutator,
+			reconcilers.GenericDeploymentMutator)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	objKey := client.ObjectKey{Name: existingDC.Name, Namespace: namespace}
+
+	reconcileWorkload(&component.AutoscalingOptions{MinReplicas: 1, MaxReplicas: 5})
+
+	dc := &appsv1.DeploymentConfig{}
+	if err := cl.Get(ctx, objKey, dc); err != nil {
+		t.Fatal(err)
+	}
+	if dc.Spec.Replicas != 4 {
+		t.Errorf("expected autoscaled replicas to be kept, got %d", dc.Spec.Replicas)
+	}
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	if err := cl.Get(ctx, objKey, hpa); err != nil {
+		t.Fatalf("error fetching horizontalpodautoscaler: %v", err)
+	}
+	if hpa.Spec.ScaleTargetRef.Kind != "DeploymentConfig" || hpa.Spec.ScaleTargetRef.Name != existingDC.Name {
+		t.Errorf("unexpected scale target: %v", hpa.Spec.ScaleTargetRef)
+	}
+	if hpa.Spec.MaxReplicas != 5 {
+		t.Errorf("expected max replicas 5, got %d", hpa.Spec.MaxReplicas)
+	}
+
+	// Autoscaling disabled
+	reconcileWorkload(nil)
+
+	if err := cl.Get(ctx, objKey, dc); err != nil {
+		t.Fatal(err)
+	}
+	if dc.Spec.Replicas != memcached.DeploymentConfig().Spec.Replicas {
+		t.Errorf("expected replicas %d, got %d", memcached.DeploymentConfig().Spec.Replicas, dc.Spec.Replicas)
+	}
+
+	err = cl.Get(ctx, objKey, &autoscalingv2beta2.HorizontalPodAutoscaler{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected HorizontalPodAutoscaler to be deleted, got: %v", err)
+	}
+}
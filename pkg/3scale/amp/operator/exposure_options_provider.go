@@ -0,0 +1,103 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ExposureOptionsProvider struct {
+	apimanager      *appsv1alpha1.APIManager
+	namespace       string
+	client          client.Client
+	exposureOptions *component.ExposureOptions
+	secretSource    *helper.SecretSource
+}
+
+func NewExposureOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *ExposureOptionsProvider {
+	return &ExposureOptionsProvider{
+		apimanager:      apimanager,
+		namespace:       namespace,
+		client:          client,
+		exposureOptions: component.NewExposureOptions(),
+		secretSource:    helper.NewSecretSource(client, namespace),
+	}
+}
+
+func (e *ExposureOptionsProvider) GetExposureOptions() (*component.ExposureOptions, error) {
+	e.exposureOptions.WildcardDomain = e.apimanager.Spec.WildcardDomain
+	e.exposureOptions.TenantName = *e.apimanager.Spec.TenantName
+
+	masterName, err := e.secretSource.FieldValue(
+		component.SystemSecretSystemSeedSecretName,
+		component.SystemSecretSystemSeedMasterDomainFieldName,
+		component.DefaultSystemMasterName())
+	if err != nil {
+		return nil, fmt.Errorf("GetExposureOptions reading secret options: %w", err)
+	}
+	e.exposureOptions.MasterName = masterName
+
+	e.setIngressOptions()
+
+	err = e.setGatewayOptions()
+	if err != nil {
+		return nil, fmt.Errorf("GetExposureOptions: %w", err)
+	}
+
+	e.exposureOptions.CommonLabels = e.commonLabels()
+
+	err = e.exposureOptions.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetExposureOptions validating: %w", err)
+	}
+	return e.exposureOptions, nil
+}
+
+func (e *ExposureOptionsProvider) setIngressOptions() {
+	if e.apimanager.ExposureType() != appsv1alpha1.ExposureTypeIngress {
+		return
+	}
+
+	ingressSpec := e.apimanager.Spec.Exposure.Ingress
+	if ingressSpec == nil {
+		return
+	}
+
+	e.exposureOptions.IngressClassName = ingressSpec.ClassName
+	e.exposureOptions.IngressAnnotations = ingressSpec.Annotations
+	if ingressSpec.TLSSecretRef != nil {
+		e.exposureOptions.IngressTLSSecretName = &ingressSpec.TLSSecretRef.Name
+	}
+}
+
+func (e *ExposureOptionsProvider) setGatewayOptions() error {
+	if e.apimanager.ExposureType() != appsv1alpha1.ExposureTypeGatewayAPI {
+		return nil
+	}
+
+	gatewaySpec := e.apimanager.Spec.Exposure.Gateway
+	if gatewaySpec == nil || len(gatewaySpec.ParentRefs) == 0 {
+		return fmt.Errorf("exposure type %s requires at least one gateway parentRef", appsv1alpha1.ExposureTypeGatewayAPI)
+	}
+
+	for _, ref := range gatewaySpec.ParentRefs {
+		e.exposureOptions.GatewayParentRefs = append(e.exposureOptions.GatewayParentRefs, component.GatewayParentRef{
+			Name:        ref.Name,
+			Namespace:   ref.Namespace,
+			SectionName: ref.SectionName,
+		})
+	}
+
+	return nil
+}
+
+func (e *ExposureOptionsProvider) commonLabels() map[string]string {
+	return map[string]string{
+		"app":                  *e.apimanager.Spec.AppLabel,
+		"threescale_component": "exposure",
+	}
+}
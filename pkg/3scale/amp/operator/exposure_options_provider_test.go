@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testExposureCommonLabels() map[string]string {
+	return map[string]string{
+		"app":                  appLabel,
+		"threescale_component": "exposure",
+	}
+}
+
+func defaultExposureOptions() *component.ExposureOptions {
+	return &component.ExposureOptions{
+		WildcardDomain: wildcardDomain,
+		TenantName:     tenantName,
+		MasterName:     component.DefaultSystemMasterName(),
+		CommonLabels:   testExposureCommonLabels(),
+	}
+}
+
+func TestExposureOptionsProvider(t *testing.T) {
+	ingressType := appsv1alpha1.ExposureTypeIngress
+	gatewayType := appsv1alpha1.ExposureTypeGatewayAPI
+	className := "nginx"
+	gatewayNamespace := "gateway-ns"
+
+	cases := []struct {
+		testName               string
+		apimanagerFactory      func() *appsv1alpha1.APIManager
+		objects                []runtime.Object
+		expectedOptionsFactory func() *component.ExposureOptions
+	}{
+		{"Default", basicApimanager, nil, defaultExposureOptions},
+		{"MasterNameFromSeedSecret", basicApimanager,
+			[]runtime.Object{
+				GetTestSecret(namespace, component.SystemSecretSystemSeedSecretName, map[string]string{
+					component.SystemSecretSystemSeedMasterDomainFieldName: "mymaster",
+				}),
+			},
+			func() *component.ExposureOptions {
+				opts := defaultExposureOptions()
+				opts.MasterName = "mymaster"
+				return opts
+			},
+		},
+		{"Ingress",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Exposure = &appsv1alpha1.ExposureSpec{
+					Type: &ingressType,
+					Ingress: &appsv1alpha1.IngressExposureSpec{
+						ClassName:    &className,
+						Annotations:  map[string]string{"a": "b"},
+						TLSSecretRef: &v1.LocalObjectReference{Name: "wildcard-tls"},
+					},
+				}
+				return apimanager
+			}, nil,
+			func() *component.ExposureOptions {
+				opts := defaultExposureOptions()
+				tlsSecretName := "wildcard-tls"
+				opts.IngressClassName = &className
+				opts.IngressAnnotations = map[string]string{"a": "b"}
+				opts.IngressTLSSecretName = &tlsSecretName
+				return opts
+			},
+		},
+		{"GatewayAPI",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Exposure = &appsv1alpha1.ExposureSpec{
+					Type: &gatewayType,
+					Gateway: &appsv1alpha1.GatewayExposureSpec{
+						ParentRefs: []appsv1alpha1.GatewayParentReference{
+							{Name: "gw", Namespace: &gatewayNamespace},
+						},
+					},
+				}
+				return apimanager
+			}, nil,
+			func() *component.ExposureOptions {
+				opts := defaultExposureOptions()
+				opts.GatewayParentRefs = []component.GatewayParentRef{
+					{Name: "gw", Namespace: &gatewayNamespace},
+				}
+				return opts
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			cl := fake.NewFakeClient(tc.objects...)
+			optsProvider := NewExposureOptionsProvider(tc.apimanagerFactory(), namespace, cl)
+			opts, err := optsProvider.GetExposureOptions()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			expectedOptions := tc.expectedOptionsFactory()
+			if !reflect.DeepEqual(expectedOptions, opts) {
+				subT.Errorf("Resulting expected options differ: %s", cmp.Diff(expectedOptions, opts))
+			}
+		})
+	}
+}
+
+func TestExposureOptionsProviderGatewayWithoutParentRefs(t *testing.T) {
+	gatewayType := appsv1alpha1.ExposureTypeGatewayAPI
+	apimanager := basicApimanager()
+	apimanager.Spec.Exposure = &appsv1alpha1.ExposureSpec{Type: &gatewayType}
+
+	optsProvider := NewExposureOptionsProvider(apimanager, namespace, fake.NewFakeClient())
+	_, err := optsProvider.GetExposureOptions()
+	if err == nil {
+		t.Fatal("expected error when gateway parentRefs are missing")
+	}
+}
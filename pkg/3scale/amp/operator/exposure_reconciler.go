@@ -0,0 +1,338 @@
+package operator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1alpha1 "github.com/3scale/3scale-operator/apis/capabilities/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ExposureHostsResyncPeriod is the period the tenant and product hosts are
+// read again from 3scale. Zync is notified by system about domain changes,
+// the operator polls for them
+const ExposureHostsResyncPeriod = 5 * time.Minute
+
+// ExposureReconciler exposes the 3scale hosts using Ingresses or Gateway API
+// HTTPRoutes, the way zync does with Routes. Objects of hosts that no longer
+// exist in 3scale are deleted
+type ExposureReconciler struct {
+	*BaseAPIManagerLogicReconciler
+	// threescaleHosts returns the hosts of the tenants and products read from 3scale
+	threescaleHosts func(exposure *component.Exposure) ([]component.ExposureHost, error)
+}
+
+func NewExposureReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *ExposureReconciler {
+	r := &ExposureReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+	r.threescaleHosts = r.hostsFrom3scale
+	return r
+}
+
+func (r *ExposureReconciler) Reconcile() (reconcile.Result, error) {
+	exposure, err := Exposure(r.apiManager, r.Client())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	result := reconcile.Result{}
+	hosts := []component.ExposureHost{}
+	// Objects of hosts not found are only deleted when
+	// the list of hosts is complete
+	hostsComplete := true
+
+	if !r.apiManager.UsesRoutes() {
+		hosts = exposure.Hosts()
+
+		threescaleHosts, err := r.threescaleHosts(exposure)
+		if err != nil {
+			// 3scale might not be reachable yet. Known hosts are kept
+			r.Logger().Info("Tenant and product hosts could not be read from 3scale", "error", err.Error())
+			hostsComplete = false
+		}
+		hosts = append(hosts, threescaleHosts...)
+
+		result.RequeueAfter = ExposureHostsResyncPeriod
+	}
+
+	err = r.reconcileExposureObjects(component.IngressGVK, r.HasIngresses,
+		r.apiManager.ExposureType() == appsv1alpha1.ExposureTypeIngress,
+		exposure.Ingress, hosts, hostsComplete, exposure.Options.CommonLabels)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileExposureObjects(component.HTTPRouteGVK, r.HasHTTPRoutes,
+		r.apiManager.ExposureType() == appsv1alpha1.ExposureTypeGatewayAPI,
+		exposure.HTTPRoute, hosts, hostsComplete, exposure.Options.CommonLabels)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return result, nil
+}
+
+func (r *ExposureReconciler) reconcileExposureObjects(
+	gvk schema.GroupVersionKind,
+	kindExistsFn func() (bool, error),
+	enabled bool,
+	objFn func(component.ExposureHost) *unstructured.Unstructured,
+	hosts []component.ExposureHost,
+	hostsComplete bool,
+	labels map[string]string) error {
+
+	kindExists, err := kindExistsFn()
+	if err != nil {
+		return err
+	}
+
+	if !kindExists {
+		if enabled {
+			errToLog := fmt.Errorf("Error exposing 3scale hosts. %s is not supported in your cluster", gvk.GroupKind())
+			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "ReconcileError", "%s", errToLog.Error())
+			r.Logger().Error(errToLog, "ReconcileError")
+		}
+		return nil
+	}
+
+	desiredNames := map[string]bool{}
+	if enabled {
+		for _, host := range hosts {
+			if desiredNames[host.Name()] {
+				continue
+			}
+			desiredNames[host.Name()] = true
+
+			existing := &unstructured.Unstructured{}
+			existing.SetGroupVersionKind(gvk)
+			err = r.ReconcileResource(existing, objFn(host), reconcilers.UnstructuredSpecMutator)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if enabled && !hostsComplete {
+		return nil
+	}
+
+	existingList := &unstructured.UnstructuredList{}
+	existingList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	err = r.Client().List(r.Context(), existingList, client.InNamespace(r.apiManager.Namespace), client.MatchingLabels(labels))
+	if err != nil {
+		return err
+	}
+
+	for idx := range existingList.Items {
+		obj := &existingList.Items[idx]
+		if desiredNames[obj.GetName()] {
+			continue
+		}
+		err = r.DeleteResource(obj)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hostsFrom3scale reads the admin and developer portal hosts of every tenant
+// from the master account. Product hosts are read from the tenants the
+// operator has credentials for: the default tenant and the tenants managed by
+// Tenant custom resources. Only hosted products use the apicast
+// deployed by the APIManager
+func (r *ExposureReconciler) hostsFrom3scale(exposure *component.Exposure) ([]component.ExposureHost, error) {
+	secretSource := helper.NewSecretSource(r.Client(), r.apiManager.Namespace)
+	masterAccessToken, err := secretSource.RequiredFieldValueFromRequiredSecret(component.SystemSecretSystemSeedSecretName, component.SystemSecretSystemSeedMasterAccessTokenFieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := r.exposureScheme(exposure)
+
+	masterClient, err := controllerhelper.PortaClient(&controllerhelper.ProviderAccount{
+		AdminURLStr: fmt.Sprintf("%s://%s.%s", scheme, exposure.Options.MasterName, exposure.Options.WildcardDomain),
+		Token:       masterAccessToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, err := masterClient.ListAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("listing tenants: %w", err)
+	}
+
+	hosts := []component.ExposureHost{}
+	for _, tenant := range tenants.Accounts {
+		if tenant.Account.State == "scheduled_for_deletion" {
+			continue
+		}
+		hosts = append(hosts, component.TenantExposureHosts(tenant.Account.AdminDomain, tenant.Account.Domain)...)
+	}
+
+	providerAccounts, err := r.providerAccounts(exposure, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, providerAccount := range providerAccounts {
+		providerClient, err := controllerhelper.PortaClient(providerAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		productHosts, err := productHostsFrom3scale(providerClient)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", providerAccount.AdminURLStr, err)
+		}
+		hosts = append(hosts, productHosts...)
+	}
+
+	return hosts, nil
+}
+
+// exposureScheme returns the scheme of the exposed hosts. Ingresses serve HTTPS when they have a TLS certificate.
+// HTTPRoutes serve HTTPS when the Gateway has an HTTPS listener the routes can be attached to.
+// When the Gateway cannot be read, i.e. it is in a namespace the operator has no access to, HTTPS is assumed
+func (r *ExposureReconciler) exposureScheme(exposure *component.Exposure) string {
+	if r.apiManager.ExposureType() == appsv1alpha1.ExposureTypeIngress {
+		if exposure.Options.IngressTLSSecretName != nil {
+			return "https"
+		}
+		return "http"
+	}
+
+	if len(exposure.Options.GatewayParentRefs) == 0 {
+		return "https"
+	}
+
+	parentRef := exposure.Options.GatewayParentRefs[0]
+	gatewayKey := client.ObjectKey{Name: parentRef.Name, Namespace: r.apiManager.Namespace}
+	if parentRef.Namespace != nil {
+		gatewayKey.Namespace = *parentRef.Namespace
+	}
+
+	gateway := &unstructured.Unstructured{}
+	gateway.SetGroupVersionKind(component.GatewayGVK)
+	err := r.Client().Get(r.Context(), gatewayKey, gateway)
+	if err != nil {
+		r.Logger().V(1).Info("Gateway could not be read, assuming HTTPS", "gateway", gatewayKey, "error", err.Error())
+		return "https"
+	}
+
+	listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+	for _, item := range listeners {
+		listener, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if parentRef.SectionName != nil && listener["name"] != *parentRef.SectionName {
+			continue
+		}
+		if listener["protocol"] == "HTTPS" {
+			return "https"
+		}
+	}
+
+	return "http"
+}
+
+func (r *ExposureReconciler) providerAccounts(exposure *component.Exposure, scheme string) ([]*controllerhelper.ProviderAccount, error) {
+	secretSource := helper.NewSecretSource(r.Client(), r.apiManager.Namespace)
+	adminAccessToken, err := secretSource.RequiredFieldValueFromRequiredSecret(component.SystemSecretSystemSeedSecretName, component.SystemSecretSystemSeedAdminAccessTokenFieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	providerAccounts := []*controllerhelper.ProviderAccount{
+		{
+			AdminURLStr: fmt.Sprintf("%s://%s-admin.%s", scheme, exposure.Options.TenantName, exposure.Options.WildcardDomain),
+			Token:       adminAccessToken,
+		},
+	}
+
+	tenantList := &capabilitiesv1alpha1.TenantList{}
+	err = r.Client().List(r.Context(), tenantList, client.InNamespace(r.apiManager.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range tenantList.Items {
+		tenant := &tenantList.Items[idx]
+		if tenant.Status.TenantId == 0 {
+			// Not created yet
+			continue
+		}
+
+		providerAccount, err := controllerhelper.ProviderAccountFromSecret(r.Client(), tenant.Spec.TenantSecretRef.Namespace, tenant.Spec.TenantSecretRef.Name)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", tenant.Name, err)
+		}
+
+		adminURL, err := url.Parse(providerAccount.AdminURLStr)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", tenant.Name, err)
+		}
+		// Skip tenants of other 3scale installations
+		if !strings.HasSuffix(adminURL.Hostname(), "."+exposure.Options.WildcardDomain) {
+			continue
+		}
+
+		providerAccounts = append(providerAccounts, providerAccount)
+	}
+
+	return providerAccounts, nil
+}
+
+func productHostsFrom3scale(threescaleClient *threescaleapi.ThreeScaleClient) ([]component.ExposureHost, error) {
+	productList, err := threescaleClient.ListProducts()
+	if err != nil {
+		return nil, fmt.Errorf("listing products: %w", err)
+	}
+
+	hosts := []component.ExposureHost{}
+	for _, product := range productList.Products {
+		if product.Element.DeploymentOption != "hosted" {
+			continue
+		}
+
+		proxy, err := threescaleClient.ProductProxy(product.Element.ID)
+		if err != nil {
+			return nil, fmt.Errorf("product %s proxy: %w", product.Element.SystemName, err)
+		}
+
+		productHosts, err := component.ProductExposureHosts(proxy.Element.SandboxEndpoint, proxy.Element.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("product %s: %w", product.Element.SystemName, err)
+		}
+		hosts = append(hosts, productHosts...)
+	}
+
+	return hosts, nil
+}
+
+func Exposure(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.Exposure, error) {
+	optsProvider := NewExposureOptionsProvider(apimanager, apimanager.Namespace, client)
+	opts, err := optsProvider.GetExposureOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewExposure(opts), nil
+}
@@ -0,0 +1,294 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func testExposureObject(gvk schema.GroupVersionKind, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func testExposureReconciler(t *testing.T, apimanager *appsv1alpha1.APIManager, resources []*metav1.APIResourceList, objs ...runtime.Object) (*ExposureReconciler, client.Client) {
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	// The fake client lists objects by the types registered in the scheme
+	for _, gvk := range []schema.GroupVersionKind{component.IngressGVK, component.HTTPRouteGVK, component.GatewayGVK} {
+		s.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		s.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+
+	objs = append(objs, apimanager)
+	cl := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	clientset.Resources = resources
+	recorder := record.NewFakeRecorder(10000)
+	log := logf.Log.WithName("operator_test")
+
+	baseReconciler := reconcilers.NewBaseReconciler(cl, s, cl, context.TODO(), log, clientset.Discovery(), recorder)
+	return NewExposureReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)), cl
+}
+
+func TestExposureReconcilerIngress(t *testing.T) {
+	exposureType := appsv1alpha1.ExposureTypeIngress
+	className := "nginx"
+	apimanager := basicApimanager()
+	apimanager.Spec.Exposure = &appsv1alpha1.ExposureSpec{
+		Type: &exposureType,
+		Ingress: &appsv1alpha1.IngressExposureSpec{
+			ClassName:    &className,
+			TLSSecretRef: &v1.LocalObjectReference{Name: "wildcard-tls"},
+		},
+	}
+
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: component.IngressGVK.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: "ingresses", Namespaced: true, Kind: component.IngressGVK.Kind}},
+		},
+	}
+
+	staleIngressName := "system-provider-removed-admin." + wildcardDomain
+	unmanagedIngressName := "unmanaged"
+
+	threescaleHosts := func(*component.Exposure) ([]component.ExposureHost, error) {
+		productHosts, err := component.ProductExposureHosts("https://api-staging."+wildcardDomain+":443", "https://api."+wildcardDomain+":443")
+		if err != nil {
+			return nil, err
+		}
+		return append(component.TenantExposureHosts("other-admin."+wildcardDomain, "other."+wildcardDomain), productHosts...), nil
+	}
+
+	cases := []struct {
+		testName             string
+		threescaleHosts      func(*component.Exposure) ([]component.ExposureHost, error)
+		expectedIngresses    []string
+		expectedStaleDeleted bool
+	}{
+		{"HostsFrom3scale", threescaleHosts,
+			[]string{
+				"system-master-master." + wildcardDomain,
+				"backend-listener-backend-" + strings.ToLower(tenantName) + "." + wildcardDomain,
+				"system-provider-" + strings.ToLower(tenantName) + "-admin." + wildcardDomain,
+				"system-developer-" + strings.ToLower(tenantName) + "." + wildcardDomain,
+				"system-provider-other-admin." + wildcardDomain,
+				"system-developer-other." + wildcardDomain,
+				"apicast-staging-api-staging." + wildcardDomain,
+				"apicast-production-api." + wildcardDomain,
+			}, true,
+		},
+		{"3scaleNotReachable",
+			func(*component.Exposure) ([]component.ExposureHost, error) {
+				return nil, errors.New("connection refused")
+			},
+			[]string{
+				"system-master-master." + wildcardDomain,
+				"system-provider-" + strings.ToLower(tenantName) + "-admin." + wildcardDomain,
+			}, false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			reconciler, cl := testExposureReconciler(subT, apimanager.DeepCopy(), resources,
+				testExposureObject(component.IngressGVK, staleIngressName, testExposureCommonLabels()),
+				testExposureObject(component.IngressGVK, unmanagedIngressName, nil),
+			)
+			reconciler.threescaleHosts = tc.threescaleHosts
+
+			result, err := reconciler.Reconcile()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if result.RequeueAfter != ExposureHostsResyncPeriod {
+				subT.Errorf("expected requeue after %s, got %s", ExposureHostsResyncPeriod, result.RequeueAfter)
+			}
+
+			for _, name := range tc.expectedIngresses {
+				ingress := &unstructured.Unstructured{}
+				ingress.SetGroupVersionKind(component.IngressGVK)
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, ingress)
+				if err != nil {
+					subT.Fatalf("ingress %s: %v", name, err)
+				}
+
+				ingressClassName, _, _ := unstructured.NestedString(ingress.Object, "spec", "ingressClassName")
+				if ingressClassName != className {
+					subT.Errorf("ingress %s: unexpected class name %s", name, ingressClassName)
+				}
+				tls, _, _ := unstructured.NestedSlice(ingress.Object, "spec", "tls")
+				if len(tls) != 1 {
+					subT.Errorf("ingress %s: unexpected tls %v", name, tls)
+				}
+			}
+
+			staleIngress := &unstructured.Unstructured{}
+			staleIngress.SetGroupVersionKind(component.IngressGVK)
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: staleIngressName, Namespace: namespace}, staleIngress)
+			if tc.expectedStaleDeleted != (err != nil) {
+				subT.Errorf("stale ingress: expected deleted %t, got error %v", tc.expectedStaleDeleted, err)
+			}
+
+			unmanagedIngress := &unstructured.Unstructured{}
+			unmanagedIngress.SetGroupVersionKind(component.IngressGVK)
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: unmanagedIngressName, Namespace: namespace}, unmanagedIngress)
+			if err != nil {
+				subT.Errorf("unmanaged ingress: %v", err)
+			}
+		})
+	}
+}
+
+func TestExposureReconcilerGatewayAPI(t *testing.T) {
+	exposureType := appsv1alpha1.ExposureTypeGatewayAPI
+	apimanager := basicApimanager()
+	apimanager.Spec.Exposure = &appsv1alpha1.ExposureSpec{
+		Type: &exposureType,
+		Gateway: &appsv1alpha1.GatewayExposureSpec{
+			ParentRefs: []appsv1alpha1.GatewayParentReference{{Name: "gw"}},
+		},
+	}
+
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: component.HTTPRouteGVK.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: "httproutes", Namespaced: true, Kind: component.HTTPRouteGVK.Kind}},
+		},
+	}
+
+	reconciler, cl := testExposureReconciler(t, apimanager, resources)
+	reconciler.threescaleHosts = func(*component.Exposure) ([]component.ExposureHost, error) { return nil, nil }
+
+	_, err := reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	httpRoute := &unstructured.Unstructured{}
+	httpRoute.SetGroupVersionKind(component.HTTPRouteGVK)
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "system-master-master." + wildcardDomain, Namespace: namespace}, httpRoute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hostnames, _, _ := unstructured.NestedStringSlice(httpRoute.Object, "spec", "hostnames")
+	if len(hostnames) != 1 || hostnames[0] != "master."+wildcardDomain {
+		t.Errorf("unexpected hostnames %v", hostnames)
+	}
+	parentRefs, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "parentRefs")
+	if len(parentRefs) != 1 {
+		t.Errorf("unexpected parentRefs %v", parentRefs)
+	}
+}
+
+func TestExposureReconcilerRoute(t *testing.T) {
+	// Switching back to Routes removes the Ingresses
+	apimanager := basicApimanager()
+
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: component.IngressGVK.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: "ingresses", Namespaced: true, Kind: component.IngressGVK.Kind}},
+		},
+	}
+
+	ingressName := "system-master-master." + wildcardDomain
+	reconciler, cl := testExposureReconciler(t, apimanager, resources,
+		testExposureObject(component.IngressGVK, ingressName, testExposureCommonLabels()))
+	reconciler.threescaleHosts = func(*component.Exposure) ([]component.ExposureHost, error) {
+		t.Fatal("3scale must not be queried when exposing with Routes")
+		return nil, nil
+	}
+
+	result, err := reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("unexpected requeue after %s", result.RequeueAfter)
+	}
+
+	ingress := &unstructured.Unstructured{}
+	ingress.SetGroupVersionKind(component.IngressGVK)
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: ingressName, Namespace: namespace}, ingress)
+	if err == nil {
+		t.Error("expected ingress to be deleted")
+	}
+}
+
+func TestExposureReconcilerExposureScheme(t *testing.T) {
+	ingressType := appsv1alpha1.ExposureTypeIngress
+	gatewayType := appsv1alpha1.ExposureTypeGatewayAPI
+	tlsSecretName := "wildcard-tls"
+	httpsListener := "https"
+	httpListener := "http"
+
+	gateway := testExposureObject(component.GatewayGVK, "gw", nil)
+	gateway.Object["spec"] = map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{"name": httpListener, "protocol": "HTTP"},
+			map[string]interface{}{"name": httpsListener, "protocol": "HTTPS"},
+		},
+	}
+
+	cases := []struct {
+		testName       string
+		exposureType   string
+		options        component.ExposureOptions
+		expectedScheme string
+	}{
+		{"IngressTLS", ingressType, component.ExposureOptions{IngressTLSSecretName: &tlsSecretName}, "https"},
+		{"IngressNoTLS", ingressType, component.ExposureOptions{}, "http"},
+		{"GatewayHTTPSListener", gatewayType, component.ExposureOptions{
+			GatewayParentRefs: []component.GatewayParentRef{{Name: "gw", SectionName: &httpsListener}},
+		}, "https"},
+		{"GatewayHTTPListener", gatewayType, component.ExposureOptions{
+			GatewayParentRefs: []component.GatewayParentRef{{Name: "gw", SectionName: &httpListener}},
+		}, "http"},
+		{"GatewayAnyListener", gatewayType, component.ExposureOptions{
+			GatewayParentRefs: []component.GatewayParentRef{{Name: "gw"}},
+		}, "https"},
+		{"GatewayNotFound", gatewayType, component.ExposureOptions{
+			GatewayParentRefs: []component.GatewayParentRef{{Name: "unknown"}},
+		}, "https"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			exposureType := tc.exposureType
+			apimanager := basicApimanager()
+			apimanager.Spec.Exposure = &appsv1alpha1.ExposureSpec{Type: &exposureType}
+
+			reconciler, _ := testExposureReconciler(subT, apimanager, nil, gateway.DeepCopy())
+			options := tc.options
+			scheme := reconciler.exposureScheme(component.NewExposure(&options))
+			if scheme != tc.expectedScheme {
+				subT.Errorf("expected scheme %s, got %s", tc.expectedScheme, scheme)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package operator
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+// systemMessageBusRedisEndpoint returns the message bus endpoint,
+// which defaults to the system endpoint
+func systemMessageBusRedisEndpoint(spec *appsv1alpha1.ExternalRedisSpec) *appsv1alpha1.RedisEndpointSpec {
+	if spec.SystemMessageBus != nil {
+		return spec.SystemMessageBus
+	}
+	return &spec.System
+}
+
+func redisConnectionOptions(spec *appsv1alpha1.RedisEndpointSpec) *component.RedisConnectionOptions {
+	if spec == nil {
+		return nil
+	}
+
+	if spec.Username == nil && spec.PasswordSecretRef == nil && spec.TLS == nil {
+		return nil
+	}
+
+	options := &component.RedisConnectionOptions{
+		Username: spec.Username != nil,
+		Password: spec.PasswordSecretRef != nil,
+	}
+
+	if spec.TLS != nil {
+		options.TLS = &component.RedisTLSOptions{}
+		if spec.TLS.CASecretRef != nil {
+			options.TLS.CASecretName = &spec.TLS.CASecretRef.Name
+		}
+		if spec.TLS.ClientCertificateSecretRef != nil {
+			options.TLS.ClientCertificateSecretName = &spec.TLS.ClientCertificateSecretRef.Name
+		}
+	}
+
+	return options
+}
+
+func (o *OperatorBackendOptionsProvider) setRedisConnectionOptions() error {
+	err := o.apimanager.ValidateExternalRedis()
+	if err != nil {
+		return err
+	}
+
+	spec := o.apimanager.ExternalRedis()
+	if spec == nil {
+		return nil
+	}
+
+	o.backendOptions.StorageRedisConnection = redisConnectionOptions(&spec.BackendStorage)
+	o.backendOptions.QueuesRedisConnection = redisConnectionOptions(&spec.BackendQueues)
+	return nil
+}
+
+func (s *SystemOptionsProvider) setRedisConnectionOptions() error {
+	err := s.apimanager.ValidateExternalRedis()
+	if err != nil {
+		return err
+	}
+
+	spec := s.apimanager.ExternalRedis()
+	if spec == nil {
+		return nil
+	}
+
+	s.options.RedisConnection = redisConnectionOptions(&spec.System)
+	s.options.MessageBusRedisConnection = redisConnectionOptions(systemMessageBusRedisEndpoint(spec))
+	s.options.BackendRedisConnection = redisConnectionOptions(&spec.BackendStorage)
+	return nil
+}
@@ -1,10 +1,14 @@
 package operator
 
 import (
+	"fmt"
+	"strings"
+
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
+	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,15 +31,23 @@ func NewHighAvailabilityOptionsProvider(apimanager *appsv1alpha1.APIManager, nam
 }
 
 func (h *HighAvailabilityOptionsProvider) GetHighAvailabilityOptions() (*component.HighAvailabilityOptions, error) {
-	err := h.setBackendRedisOptions()
-	if err != nil {
-		return nil, err
-	}
-	err = h.setSystemRedisOptions()
-	if err != nil {
-		return nil, err
+	if h.apimanager.ExternalRedis() != nil {
+		err := h.setExternalRedisOptions()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err := h.setBackendRedisOptions()
+		if err != nil {
+			return nil, err
+		}
+		err = h.setSystemRedisOptions()
+		if err != nil {
+			return nil, err
+		}
 	}
-	err = h.setSystemDatabaseOptions()
+
+	err := h.setSystemDatabaseOptions()
 	if err != nil {
 		return nil, err
 	}
@@ -201,6 +213,135 @@ func (h *HighAvailabilityOptionsProvider) setSystemRedisOptions() error {
 
 }
 
+// setExternalRedisOptions reads the redis settings from the APIManager
+// externalRedis section. Passwords are read from the referenced secrets
+func (h *HighAvailabilityOptionsProvider) setExternalRedisOptions() error {
+	err := h.apimanager.ValidateExternalRedis()
+	if err != nil {
+		return err
+	}
+
+	spec := h.apimanager.ExternalRedis()
+	h.options.ExternalRedisManaged = true
+
+	cases := []struct {
+		name          string
+		spec          *appsv1alpha1.RedisEndpointSpec
+		url           *string
+		sentinelHosts *string
+		sentinelRole  *string
+		username      *string
+		password      *string
+	}{
+		{
+			"backendStorage",
+			&spec.BackendStorage,
+			&h.options.BackendRedisStorageEndpoint,
+			&h.options.BackendRedisStorageSentinelHosts,
+			&h.options.BackendRedisStorageSentinelRole,
+			&h.options.BackendRedisStorageUsername,
+			&h.options.BackendRedisStoragePassword,
+		},
+		{
+			"backendQueues",
+			&spec.BackendQueues,
+			&h.options.BackendRedisQueuesEndpoint,
+			&h.options.BackendRedisQueuesSentinelHosts,
+			&h.options.BackendRedisQueuesSentinelRole,
+			&h.options.BackendRedisQueuesUsername,
+			&h.options.BackendRedisQueuesPassword,
+		},
+		{
+			"system",
+			&spec.System,
+			&h.options.SystemRedisURL,
+			&h.options.SystemRedisSentinelsHosts,
+			&h.options.SystemRedisSentinelsRole,
+			&h.options.SystemRedisUsername,
+			&h.options.SystemRedisPassword,
+		},
+		{
+			"systemMessageBus",
+			systemMessageBusRedisEndpoint(spec),
+			&h.options.SystemMessageBusRedisURL,
+			&h.options.SystemMessageBusRedisSentinelsHosts,
+			&h.options.SystemMessageBusRedisSentinelsRole,
+			&h.options.SystemMessageBusRedisUsername,
+			&h.options.SystemMessageBusRedisPassword,
+		},
+	}
+
+	for _, option := range cases {
+		*option.url = option.spec.URL
+
+		if option.spec.Sentinel != nil {
+			*option.sentinelHosts = strings.Join(option.spec.Sentinel.Hosts, ",")
+			if option.spec.Sentinel.Role != nil {
+				*option.sentinelRole = *option.spec.Sentinel.Role
+			}
+		}
+
+		if option.spec.Username != nil {
+			*option.username = *option.spec.Username
+		}
+
+		if option.spec.PasswordSecretRef != nil {
+			val, err := h.secretSource.RequiredFieldValueFromRequiredSecret(option.spec.PasswordSecretRef.Name, option.spec.PasswordSecretRef.Key)
+			if err != nil {
+				return fmt.Errorf("externalRedis.%s.passwordSecretRef: %w", option.name, err)
+			}
+			*option.password = val
+		}
+
+		err := h.validateRedisTLSSecrets(option.spec.TLS)
+		if err != nil {
+			return fmt.Errorf("externalRedis.%s.tls: %w", option.name, err)
+		}
+	}
+
+	// The namespaces are not part of the externalRedis section
+	h.options.SystemRedisNamespace, err = h.secretSource.FieldValue(
+		component.SystemSecretSystemRedisSecretName, component.SystemSecretSystemRedisNamespace,
+		component.DefaultSystemRedisNamespace())
+	if err != nil {
+		return err
+	}
+	h.options.SystemMessageBusRedisNamespace, err = h.secretSource.FieldValue(
+		component.SystemSecretSystemRedisSecretName, component.SystemSecretSystemRedisMessageBusRedisNamespace,
+		component.DefaultSystemMessageBusRedisNamespace())
+	return err
+}
+
+// validateRedisTLSSecrets checks the referenced secrets hold the keys mounted
+// in the pods, otherwise the pods would not start
+func (h *HighAvailabilityOptionsProvider) validateRedisTLSSecrets(spec *appsv1alpha1.RedisTLSSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	cases := []struct {
+		secretRef *v1.LocalObjectReference
+		keys      []string
+	}{
+		{spec.CASecretRef, []string{component.RedisTLSCACertificateKey}},
+		{spec.ClientCertificateSecretRef, []string{component.RedisTLSClientCertificateKey, component.RedisTLSClientKeyKey}},
+	}
+
+	for _, option := range cases {
+		if option.secretRef == nil {
+			continue
+		}
+		for _, key := range option.keys {
+			_, err := h.secretSource.RequiredFieldValueFromRequiredSecret(option.secretRef.Name, key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (h *HighAvailabilityOptionsProvider) setSystemDatabaseOptions() error {
 	val, err := h.secretSource.RequiredFieldValueFromRequiredSecret(
 		component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName)
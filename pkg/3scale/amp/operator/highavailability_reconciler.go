@@ -25,14 +25,20 @@ func (r *HighAvailabilityReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// Redis secrets are owned by the operator when the APIManager externalRedis section is set
+	redisSecretMutator := reconcilers.DefaultsOnlySecretMutator
+	if ha.Options.ExternalRedisManaged {
+		redisSecretMutator = reconcilers.SecretStringDataMutator
+	}
+
 	// Backend Redis Secret
-	err = r.ReconcileSecret(ha.BackendRedisSecret(), reconcilers.DefaultsOnlySecretMutator)
+	err = r.ReconcileSecret(ha.BackendRedisSecret(), redisSecretMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// System Redis Secret
-	err = r.ReconcileSecret(ha.SystemRedisSecret(), reconcilers.DefaultsOnlySecretMutator)
+	err = r.ReconcileSecret(ha.SystemRedisSecret(), redisSecretMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
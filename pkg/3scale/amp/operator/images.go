@@ -1,8 +1,12 @@
 package operator
 
 import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
+
+	imagev1 "github.com/openshift/api/image/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func ApicastImageURL() string {
@@ -44,3 +48,46 @@ func SystemPostgreSQLImageURL() string {
 func ZyncPostgreSQLImageURL() string {
 	return helper.GetEnvVar("ZYNC_POSTGRESQL_IMAGE", component.ZyncPostgreSQLImageURL())
 }
+
+// DeploymentImages returns the images the APIManager components are deployed
+// with, keyed by the "<imagestream>:<tag>" ImageStreamTag the DeploymentConfigs
+// reference
+func DeploymentImages(apimanager *appsv1alpha1.APIManager, client client.Client) (map[string]string, error) {
+	ampImages, err := AmpImages(apimanager)
+	if err != nil {
+		return nil, err
+	}
+
+	imageStreams := []*imagev1.ImageStream{
+		ampImages.BackendImageStream(),
+		ampImages.ZyncImageStream(),
+		ampImages.APICastImageStream(),
+		ampImages.SystemImageStream(),
+		ampImages.ZyncDatabasePostgreSQLImageStream(),
+		ampImages.SystemMemcachedImageStream(),
+	}
+
+	if !apimanager.IsExternalDatabaseEnabled() {
+		redis, err := Redis(apimanager, client)
+		if err != nil {
+			return nil, err
+		}
+		imageStreams = append(imageStreams, redis.BackendImageStream(), redis.SystemImageStream())
+
+		if apimanager.IsSystemPostgreSQLEnabled() {
+			systemPostgreSQLImage, err := SystemPostgreSQLImage(apimanager)
+			if err != nil {
+				return nil, err
+			}
+			imageStreams = append(imageStreams, systemPostgreSQLImage.ImageStream())
+		} else {
+			systemMySQLImage, err := SystemMySQLImage(apimanager)
+			if err != nil {
+				return nil, err
+			}
+			imageStreams = append(imageStreams, systemMySQLImage.ImageStream())
+		}
+	}
+
+	return component.ImageStreamTagImages(imageStreams...), nil
+}
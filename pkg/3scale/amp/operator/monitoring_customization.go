@@ -0,0 +1,119 @@
+package operator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// alertThresholdRegexp matches the comparison with a number, or with a division of numbers,
+// ending the expression of an alert
+var alertThresholdRegexp = regexp.MustCompile(`(==|!=|>=|<=|>|<)\s*(\(\s*[0-9.]+\s*/\s*[0-9.]+\s*\)|-?[0-9]+(\.[0-9]+)?)\s*$`)
+
+// alertOverrideErrors holds the last alert override errors reported per PrometheusRule,
+// so the warning events are only emitted when the errors change
+var alertOverrideErrors = struct {
+	sync.Mutex
+	messages map[string]string
+}{messages: map[string]string{}}
+
+// alertOverrideErrorsChanged stores the alert override errors of the PrometheusRule identified by key
+// and returns true when they are not empty and differ from the previously stored ones
+func alertOverrideErrorsChanged(key string, errs []error) bool {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	message := strings.Join(messages, "; ")
+
+	alertOverrideErrors.Lock()
+	defer alertOverrideErrors.Unlock()
+
+	if message == "" {
+		delete(alertOverrideErrors.messages, key)
+		return false
+	}
+
+	if alertOverrideErrors.messages[key] == message {
+		return false
+	}
+
+	alertOverrideErrors.messages[key] = message
+	return true
+}
+
+// monitoringExtraLabels adds the extra labels of the monitoring spec to the labels of a monitoring object.
+// Labels set by the operator are not overridden
+func monitoringExtraLabels(spec *appsv1alpha1.MonitoringSpec, labels map[string]string) map[string]string {
+	if spec == nil || len(spec.ExtraLabels) == 0 {
+		return labels
+	}
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range spec.ExtraLabels {
+		if _, ok := labels[key]; !ok {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// applyAlertOverrides applies the alert overrides of the monitoring spec to the rules of the PrometheusRule.
+// Overrides that cannot be applied are returned as errors, the rest of the overrides are applied anyway
+func applyAlertOverrides(spec *appsv1alpha1.MonitoringSpec, prometheusRule *monitoringv1.PrometheusRule) []error {
+	if spec == nil || len(spec.Alerts) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for groupIdx := range prometheusRule.Spec.Groups {
+		group := &prometheusRule.Spec.Groups[groupIdx]
+		rules := []monitoringv1.Rule{}
+		for _, rule := range group.Rules {
+			override, ok := spec.Alerts[rule.Alert]
+			if !ok || rule.Alert == "" {
+				rules = append(rules, rule)
+				continue
+			}
+
+			if override.Disabled {
+				continue
+			}
+
+			if override.Severity != nil {
+				labels := map[string]string{}
+				for key, value := range rule.Labels {
+					labels[key] = value
+				}
+				labels["severity"] = *override.Severity
+				rule.Labels = labels
+			}
+
+			if override.For != nil {
+				rule.For = *override.For
+			}
+
+			if override.Threshold != nil {
+				expr := rule.Expr.String()
+				if !alertThresholdRegexp.MatchString(expr) {
+					errs = append(errs, fmt.Errorf("alert %s threshold cannot be overridden: expression does not end comparing with a number", rule.Alert))
+				} else {
+					rule.Expr = intstr.FromString(alertThresholdRegexp.ReplaceAllString(expr, "$1 "+*override.Threshold))
+				}
+			}
+
+			rules = append(rules, rule)
+		}
+		group.Rules = rules
+	}
+
+	return errs
+}
@@ -0,0 +1,136 @@
+package operator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+func findAlert(prometheusRule *monitoringv1.PrometheusRule, alert string) *monitoringv1.Rule {
+	for groupIdx := range prometheusRule.Spec.Groups {
+		for ruleIdx := range prometheusRule.Spec.Groups[groupIdx].Rules {
+			if prometheusRule.Spec.Groups[groupIdx].Rules[ruleIdx].Alert == alert {
+				return &prometheusRule.Spec.Groups[groupIdx].Rules[ruleIdx]
+			}
+		}
+	}
+	return nil
+}
+
+func TestApplyAlertOverrides(t *testing.T) {
+	ns := "operator-unittest"
+	spec := &appsv1alpha1.MonitoringSpec{
+		Enabled: true,
+		Alerts: map[string]appsv1alpha1.AlertSpec{
+			"ThreescaleZyncQueScheduledJobCountHigh": {Disabled: true},
+			"ThreescaleZyncQueFailedJobCountHigh": {
+				Severity:  &[]string{"critical"}[0],
+				For:       &[]string{"10m"}[0],
+				Threshold: &[]string{"100"}[0],
+			},
+			"ThreescaleZyncQueJobDown": {Threshold: &[]string{"1"}[0]},
+		},
+	}
+
+	prometheusRule := component.ZyncQuePrometheusRules(ns)
+	errs := applyAlertOverrides(spec, prometheusRule)
+	if len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	if findAlert(prometheusRule, "ThreescaleZyncQueScheduledJobCountHigh") != nil {
+		t.Error("disabled alert found")
+	}
+
+	rule := findAlert(prometheusRule, "ThreescaleZyncQueFailedJobCountHigh")
+	if rule == nil {
+		t.Fatal("overridden alert not found")
+	}
+	if rule.Labels["severity"] != "critical" {
+		t.Errorf("severity does not match. got [%s], expected [critical]", rule.Labels["severity"])
+	}
+	if rule.For != "10m" {
+		t.Errorf("for does not match. got [%s], expected [10m]", rule.For)
+	}
+	expectedExpr := `max(que_jobs_scheduled_total{pod=~'zync-que.*',type='failed',namespace="operator-unittest"}) by (namespace,job,exported_job) > 100`
+	if rule.Expr.String() != expectedExpr {
+		t.Errorf("expr does not match. got [%s], expected [%s]", rule.Expr.String(), expectedExpr)
+	}
+
+	if findAlert(prometheusRule, "ThreescaleZyncQueReadyJobCountHigh") == nil {
+		t.Error("not overridden alert not found")
+	}
+}
+
+func TestApplyAlertOverridesInvalidThreshold(t *testing.T) {
+	spec := &appsv1alpha1.MonitoringSpec{
+		Alerts: map[string]appsv1alpha1.AlertSpec{
+			"ThreescaleReplicationControllerReplicasMismatch": {
+				Threshold: &[]string{"1"}[0],
+				Severity:  &[]string{"critical"}[0],
+			},
+			"ThreescaleContainerCPUThrottlingHigh": {Threshold: &[]string{"0.5"}[0]},
+		},
+	}
+
+	prometheusRule := component.KubeStateMetricsPrometheusRules("operator-unittest")
+	originalExpr := findAlert(prometheusRule, "ThreescaleReplicationControllerReplicasMismatch").Expr
+	errs := applyAlertOverrides(spec, prometheusRule)
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", errs)
+	}
+
+	rule := findAlert(prometheusRule, "ThreescaleReplicationControllerReplicasMismatch")
+	if !reflect.DeepEqual(rule.Expr, originalExpr) {
+		t.Errorf("expr has been modified: %s", rule.Expr.String())
+	}
+	if rule.Labels["severity"] != "critical" {
+		t.Errorf("severity does not match. got [%s], expected [critical]", rule.Labels["severity"])
+	}
+
+	throttled := findAlert(prometheusRule, "ThreescaleContainerCPUThrottlingHigh")
+	if expr := throttled.Expr.String(); expr[len(expr)-5:] != "> 0.5" {
+		t.Errorf("expr threshold not overridden: %s", expr)
+	}
+}
+
+func TestMonitoringExtraLabels(t *testing.T) {
+	spec := &appsv1alpha1.MonitoringSpec{
+		ExtraLabels: map[string]string{"team": "api", "prometheus": "other"},
+	}
+
+	labels := monitoringExtraLabels(spec, map[string]string{"prometheus": "application-monitoring"})
+	expected := map[string]string{"team": "api", "prometheus": "application-monitoring"}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("labels do not match. got %v, expected %v", labels, expected)
+	}
+}
+
+func TestAlertOverrideErrorsChanged(t *testing.T) {
+	key := "uid/rules"
+	errs := []error{errors.New("alert A threshold cannot be overridden")}
+
+	cases := []struct {
+		testName string
+		errs     []error
+		expected bool
+	}{
+		{"first", errs, true},
+		{"same", errs, false},
+		{"changed", append(errs, errors.New("alert B threshold cannot be overridden")), true},
+		{"fixed", nil, false},
+		{"again", errs, true},
+	}
+
+	// cases depend on the errors stored by the previous ones
+	for _, tc := range cases {
+		if changed := alertOverrideErrorsChanged(key, tc.errs); changed != tc.expected {
+			t.Errorf("%s: changed does not match. got [%t], expected [%t]", tc.testName, changed, tc.expected)
+		}
+	}
+}
@@ -26,7 +26,7 @@ func (r *RedisReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Backend redis DC
-	err = r.ReconcileDeploymentConfig(redis.BackendDeploymentConfig(), reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator)
+	err = r.ReconcileWorkload(redis.BackendDeploymentConfig(), reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator, reconcilers.DeploymentResourcesAndAffinityAndTolerationsMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -62,7 +62,7 @@ func (r *RedisReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// System redis DC
-	err = r.ReconcileDeploymentConfig(redis.SystemDeploymentConfig(), reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator)
+	err = r.ReconcileWorkload(redis.SystemDeploymentConfig(), reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator, reconcilers.DeploymentResourcesAndAffinityAndTolerationsMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
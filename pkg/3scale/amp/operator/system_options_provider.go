@@ -59,6 +59,11 @@ func (s *SystemOptionsProvider) GetSystemOptions() (*component.SystemOptions, er
 		return nil, fmt.Errorf("GetSystemOptions reading secret options: %w", err)
 	}
 
+	err = s.setRedisConnectionOptions()
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemOptions reading redis connection options: %w", err)
+	}
+
 	s.setResourceRequirementsOptions()
 	s.setNodeAffinityAndTolerationsOptions()
 	s.setFileStorageOptions()
@@ -421,6 +426,12 @@ func (s *SystemOptionsProvider) setReplicas() {
 	s.options.AppReplicas = &appSecReplicas
 	sidekiqReplicas := int32(*s.apimanager.Spec.System.SidekiqSpec.Replicas)
 	s.options.SidekiqReplicas = &sidekiqReplicas
+
+	// Autoscaled replicas start from the lower limit
+	s.options.AppAutoscaling = autoscalingOptions(s.apimanager.Spec.System.AppSpec.Autoscaling)
+	if s.options.AppAutoscaling != nil {
+		s.options.AppReplicas = &s.options.AppAutoscaling.MinReplicas
+	}
 }
 
 func (s *SystemOptionsProvider) commonLabels() map[string]string {
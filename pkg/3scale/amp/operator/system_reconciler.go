@@ -2,6 +2,7 @@ package operator
 
 import (
 	"fmt"
+	"reflect"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
@@ -17,6 +18,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// Containers connecting to redis, their env and volumes are reconciled
+var (
+	systemAppContainerNames = []string{
+		component.SystemAppMasterContainerName,
+		component.SystemAppProviderContainerName,
+		component.SystemAppDeveloperContainerName,
+	}
+	systemSidekiqContainerNames = []string{component.SystemSidekiqName, component.SystemSidekiqCheckSvcName}
+)
+
 type SystemReconciler struct {
 	*BaseAPIManagerLogicReconciler
 }
@@ -82,19 +93,25 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// SystemApp DC
-	err = r.ReconcileDeploymentConfig(system.AppDeploymentConfig(), r.systemAppDCMutator)
+	err = r.ReconcileAutoscaledWorkload(system.AppDeploymentConfig(), system.Options.AppAutoscaling,
+		reconcilers.DeploymentConfigEnvAndVolumesMutator(r.systemAppDCMutator, systemAppContainerNames...),
+		reconcilers.DeploymentEnvAndVolumesMutator(reconcilers.GenericDeploymentMutator, systemAppContainerNames...))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// Sidekiq DC
-	err = r.ReconcileDeploymentConfig(system.SidekiqDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileWorkload(system.SidekiqDeploymentConfig(),
+		reconcilers.DeploymentConfigEnvAndVolumesMutator(reconcilers.GenericDeploymentConfigMutator, systemSidekiqContainerNames...),
+		reconcilers.DeploymentEnvAndVolumesMutator(reconcilers.GenericDeploymentMutator, systemSidekiqContainerNames...))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// Sphinx DC
-	err = r.ReconcileDeploymentConfig(system.SphinxDeploymentConfig(), reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator)
+	err = r.ReconcileWorkload(system.SphinxDeploymentConfig(),
+		reconcilers.DeploymentConfigEnvAndVolumesMutator(reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator, component.SystemSphinxName),
+		reconcilers.DeploymentEnvAndVolumesMutator(reconcilers.DeploymentResourcesAndAffinityAndTolerationsMutator, component.SystemSphinxName))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -180,12 +197,12 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(component.SystemAppPrometheusRules(r.apiManager.Namespace), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(component.SystemAppPrometheusRules(r.apiManager.Namespace), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(component.SystemSidekiqPrometheusRules(r.apiManager.Namespace), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(component.SystemSidekiqPrometheusRules(r.apiManager.Namespace), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -281,9 +298,50 @@ func (r *SystemReconciler) systemAppDCMutator(existingObj, desiredObj common.Kub
 		}
 	}
 
+	//
+	// Check lifecycle hooks volumes
+	//
+
+	tmpUpdate = systemAppHookVolumesReconciler(desired, existing)
+	update = update || tmpUpdate
+
 	return update, nil
 }
 
+// systemAppHookVolumesReconciler reconciles the volumes of the hook pods,
+// they mount the redis TLS volumes
+func systemAppHookVolumesReconciler(desired, existing *appsv1.DeploymentConfig) bool {
+	if desired.Spec.Strategy.RollingParams == nil || existing.Spec.Strategy.RollingParams == nil {
+		return false
+	}
+
+	hooks := []struct {
+		desired  *appsv1.LifecycleHook
+		existing *appsv1.LifecycleHook
+	}{
+		{desired.Spec.Strategy.RollingParams.Pre, existing.Spec.Strategy.RollingParams.Pre},
+		{desired.Spec.Strategy.RollingParams.Post, existing.Spec.Strategy.RollingParams.Post},
+	}
+
+	update := false
+	for _, hook := range hooks {
+		if hook.desired == nil || hook.existing == nil || hook.desired.ExecNewPod == nil || hook.existing.ExecNewPod == nil {
+			continue
+		}
+		desiredVolumes := hook.desired.ExecNewPod.Volumes
+		existingVolumes := hook.existing.ExecNewPod.Volumes
+		if len(desiredVolumes) == 0 && len(existingVolumes) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(desiredVolumes, existingVolumes) {
+			hook.existing.ExecNewPod.Volumes = desiredVolumes
+			update = true
+		}
+	}
+
+	return update
+}
+
 func System(cr *appsv1alpha1.APIManager, client client.Client) (*component.System, error) {
 	optsProvider := NewSystemOptionsProvider(cr, cr.Namespace, client)
 	opts, err := optsProvider.GetSystemOptions()
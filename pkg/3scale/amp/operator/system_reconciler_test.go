@@ -13,13 +13,17 @@ import (
 	appsv1 "github.com/openshift/api/apps/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -113,3 +117,111 @@ func TestSystemReconcilerCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemReconcilerDeployments(t *testing.T) {
+	var (
+		log = logf.Log.WithName("operator_test")
+	)
+
+	ctx := context.TODO()
+
+	apimanager := basicApimanagerSpecTestSystemOptions()
+	deploymentKind := appsv1alpha1.DeploymentKindDeployment
+	apimanager.Spec.DeploymentKind = &deploymentKind
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	err := appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(cl, s, clientAPIReader, ctx, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	reconciler := NewSystemReconciler(baseAPIManagerLogicReconciler)
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// system-app is not deployed until its pre hook job succeeds
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "system-app", Namespace: namespace}, &k8sappsv1.Deployment{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("unexpected system-app deployment before the pre hook job succeeds: %v", err)
+	}
+
+	jobs := &batchv1.JobList{}
+	err = cl.List(context.TODO(), jobs, client.InNamespace(namespace), client.MatchingLabels{component.DeploymentPreHookLabel: "system-app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Items) != 1 {
+		t.Fatalf("expected one pre hook job, got %d", len(jobs.Items))
+	}
+	preHookJob := &jobs.Items[0]
+	podSpec := preHookJob.Spec.Template.Spec
+	if len(podSpec.InitContainers) != 0 || len(podSpec.Containers) != 1 || podSpec.Containers[0].Name != "system-app-pre-hook" {
+		t.Fatalf("expected pre hook container, got %v", podSpec.Containers)
+	}
+	if podSpec.Containers[0].Image != SystemImageURL() {
+		t.Errorf("expected pre hook image %s, got %s", SystemImageURL(), podSpec.Containers[0].Image)
+	}
+	if len(podSpec.Containers[0].VolumeMounts) != 1 || podSpec.Containers[0].VolumeMounts[0].Name != component.SystemFileStoragePVCName {
+		t.Errorf("expected pre hook file storage volume mount, got %v", podSpec.Containers[0].VolumeMounts)
+	}
+	if _, ok := preHookJob.Spec.Template.Labels["deploymentConfig"]; ok {
+		t.Errorf("pre hook pod must not be selected by the system-app services, got labels %v", preHookJob.Spec.Template.Labels)
+	}
+
+	preHookJob.Status.Succeeded = 1
+	err = cl.Update(context.TODO(), preHookJob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reconciler = NewSystemReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"system-app", "system-sidekiq", "system-sphinx"} {
+		t.Run(name, func(subT *testing.T) {
+			namespacedName := types.NamespacedName{Name: name, Namespace: namespace}
+			err := cl.Get(context.TODO(), namespacedName, &appsv1.DeploymentConfig{})
+			if !errors.IsNotFound(err) {
+				subT.Errorf("unexpected DeploymentConfig %s: %v", name, err)
+			}
+
+			deployment := &k8sappsv1.Deployment{}
+			err = cl.Get(context.TODO(), namespacedName, deployment)
+			if err != nil {
+				subT.Fatalf("error fetching deployment %s: %v", name, err)
+			}
+
+			containers := append(deployment.Spec.Template.Spec.InitContainers, deployment.Spec.Template.Spec.Containers...)
+			for _, container := range containers {
+				if container.Image != SystemImageURL() {
+					subT.Errorf("container %s: expected image %s, got %s", container.Name, SystemImageURL(), container.Image)
+				}
+			}
+		})
+	}
+
+	systemApp := &k8sappsv1.Deployment{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "system-app", Namespace: namespace}, systemApp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(systemApp.Spec.Template.Spec.InitContainers) != 0 {
+		t.Errorf("unexpected system-app init containers %v", systemApp.Spec.Template.Spec.InitContainers)
+	}
+}
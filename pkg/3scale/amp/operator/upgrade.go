@@ -34,6 +34,12 @@ func NewUpgradeApiManager(b *reconcilers.BaseReconciler, apiManager *appsv1alpha
 }
 
 func (u *UpgradeApiManager) Upgrade() (reconcile.Result, error) {
+	// Upgrade procedures target DeploymentConfigs and ImageStreams. Deployments
+	// are upgraded by the reconciliation of the container images
+	if u.apiManager.UsesDeployments() {
+		return reconcile.Result{}, nil
+	}
+
 	res, err := u.upgradeImages()
 	if err != nil {
 		return res, fmt.Errorf("Upgrading images: %w", err)
@@ -0,0 +1,143 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Reasons of the APIManager components health
+const (
+	ComponentRolloutFailedReason     = "RolloutFailed"
+	ComponentReplicaFailureReason    = "ReplicaFailure"
+	ComponentRolloutInProgressReason = "RolloutInProgress"
+	ComponentPodsNotReadyReason      = "PodsNotReady"
+	ComponentPodsReadyReason         = "PodsReady"
+)
+
+const (
+	// Progressing condition reasons set when the latest rollout is complete
+	deploymentConfigRolloutCompleteReason = "NewReplicationControllerAvailable"
+	deploymentRolloutCompleteReason       = "NewReplicaSetAvailable"
+)
+
+// workloadState is the rollout state common to DeploymentConfigs and Deployments
+type workloadState struct {
+	name               string
+	generation         int64
+	observedGeneration int64
+	replicas           int32
+	updatedReplicas    int32
+	readyReplicas      int32
+	progressingStatus  v1.ConditionStatus
+	progressingReason  string
+	progressingMessage string
+	replicaFailure     bool
+	replicaFailureMsg  string
+	rolloutComplete    bool
+}
+
+// DeploymentConfigHealth derives the health of a component from the
+// DeploymentConfig rollout status and the readiness of its pods
+func DeploymentConfigHealth(dc *appsv1.DeploymentConfig) appsv1alpha1.APIManagerComponentStatus {
+	state := workloadState{
+		name:               dc.Name,
+		generation:         dc.Generation,
+		observedGeneration: dc.Status.ObservedGeneration,
+		replicas:           dc.Spec.Replicas,
+		updatedReplicas:    dc.Status.UpdatedReplicas,
+		readyReplicas:      dc.Status.ReadyReplicas,
+	}
+
+	for _, condition := range dc.Status.Conditions {
+		switch condition.Type {
+		case appsv1.DeploymentProgressing:
+			state.progressingStatus = condition.Status
+			state.progressingReason = condition.Reason
+			state.progressingMessage = condition.Message
+		case appsv1.DeploymentReplicaFailure:
+			state.replicaFailure = condition.Status == v1.ConditionTrue
+			state.replicaFailureMsg = condition.Message
+		}
+	}
+
+	// DeploymentConfigs not rolled out yet, i.e. waiting for the image, have no latest version
+	state.rolloutComplete = dc.Status.LatestVersion > 0 &&
+		state.progressingStatus == v1.ConditionTrue &&
+		state.progressingReason == deploymentConfigRolloutCompleteReason
+
+	return workloadHealth(state)
+}
+
+// DeploymentHealth derives the health of a component from the
+// Deployment rollout status and the readiness of its pods
+func DeploymentHealth(deployment *k8sappsv1.Deployment) appsv1alpha1.APIManagerComponentStatus {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	state := workloadState{
+		name:               deployment.Name,
+		generation:         deployment.Generation,
+		observedGeneration: deployment.Status.ObservedGeneration,
+		replicas:           replicas,
+		updatedReplicas:    deployment.Status.UpdatedReplicas,
+		readyReplicas:      deployment.Status.ReadyReplicas,
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		switch condition.Type {
+		case k8sappsv1.DeploymentProgressing:
+			state.progressingStatus = condition.Status
+			state.progressingReason = condition.Reason
+			state.progressingMessage = condition.Message
+		case k8sappsv1.DeploymentReplicaFailure:
+			state.replicaFailure = condition.Status == v1.ConditionTrue
+			state.replicaFailureMsg = condition.Message
+		}
+	}
+
+	state.rolloutComplete = state.progressingStatus == v1.ConditionTrue &&
+		state.progressingReason == deploymentRolloutCompleteReason
+
+	return workloadHealth(state)
+}
+
+func workloadHealth(state workloadState) appsv1alpha1.APIManagerComponentStatus {
+	status := appsv1alpha1.APIManagerComponentStatus{
+		Name:          state.name,
+		Replicas:      state.replicas,
+		ReadyReplicas: state.readyReplicas,
+	}
+
+	switch {
+	case state.progressingStatus == v1.ConditionFalse:
+		// Rollout timed out or was cancelled
+		status.Health = appsv1alpha1.ComponentUnhealthy
+		status.Reason = ComponentRolloutFailedReason
+		status.Message = fmt.Sprintf("%s: %s", state.progressingReason, state.progressingMessage)
+	case state.replicaFailure:
+		status.Health = appsv1alpha1.ComponentUnhealthy
+		status.Reason = ComponentReplicaFailureReason
+		status.Message = state.replicaFailureMsg
+	case state.observedGeneration < state.generation || !state.rolloutComplete || state.updatedReplicas < state.replicas:
+		status.Health = appsv1alpha1.ComponentProgressing
+		status.Reason = ComponentRolloutInProgressReason
+		status.Message = fmt.Sprintf("%d of %d pods updated, %d ready", state.updatedReplicas, state.replicas, state.readyReplicas)
+	case state.readyReplicas < state.replicas:
+		status.Health = appsv1alpha1.ComponentUnhealthy
+		status.Reason = ComponentPodsNotReadyReason
+		status.Message = fmt.Sprintf("%d of %d pods ready", state.readyReplicas, state.replicas)
+	default:
+		status.Health = appsv1alpha1.ComponentHealthy
+		status.Reason = ComponentPodsReadyReason
+		status.Message = fmt.Sprintf("%d of %d pods ready", state.readyReplicas, state.replicas)
+	}
+
+	return status
+}
@@ -0,0 +1,140 @@
+package operator
+
+import (
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentConfigHealth(t *testing.T) {
+	dcFactory := func(status appsv1.DeploymentConfigStatus) *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend-listener", Generation: 2},
+			Spec:       appsv1.DeploymentConfigSpec{Replicas: 2},
+			Status:     status,
+		}
+	}
+
+	rolledOut := func(status appsv1.DeploymentConfigStatus) appsv1.DeploymentConfigStatus {
+		status.LatestVersion = 1
+		status.ObservedGeneration = 2
+		status.UpdatedReplicas = 2
+		status.Conditions = append(status.Conditions, appsv1.DeploymentCondition{
+			Type:   appsv1.DeploymentProgressing,
+			Status: v1.ConditionTrue,
+			Reason: deploymentConfigRolloutCompleteReason,
+		})
+		return status
+	}
+
+	cases := []struct {
+		testName       string
+		status         appsv1.DeploymentConfigStatus
+		expectedHealth appsv1alpha1.ComponentHealth
+		expectedReason string
+	}{
+		{"NotRolledOut", appsv1.DeploymentConfigStatus{}, appsv1alpha1.ComponentProgressing, ComponentRolloutInProgressReason},
+		{"GenerationNotObserved", func() appsv1.DeploymentConfigStatus {
+			status := rolledOut(appsv1.DeploymentConfigStatus{ReadyReplicas: 2})
+			status.ObservedGeneration = 1
+			return status
+		}(), appsv1alpha1.ComponentProgressing, ComponentRolloutInProgressReason},
+		{"RolloutFailed", appsv1.DeploymentConfigStatus{
+			LatestVersion: 2,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+			},
+		}, appsv1alpha1.ComponentUnhealthy, ComponentRolloutFailedReason},
+		{"ReplicaFailure", rolledOut(appsv1.DeploymentConfigStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentReplicaFailure, Status: v1.ConditionTrue, Message: "quota exceeded"},
+			},
+		}), appsv1alpha1.ComponentUnhealthy, ComponentReplicaFailureReason},
+		{"PodsNotReady", rolledOut(appsv1.DeploymentConfigStatus{ReadyReplicas: 1}), appsv1alpha1.ComponentUnhealthy, ComponentPodsNotReadyReason},
+		{"Healthy", rolledOut(appsv1.DeploymentConfigStatus{ReadyReplicas: 2}), appsv1alpha1.ComponentHealthy, ComponentPodsReadyReason},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			health := DeploymentConfigHealth(dcFactory(tc.status))
+			if health.Name != "backend-listener" {
+				subT.Errorf("unexpected name: %s", health.Name)
+			}
+			if health.Health != tc.expectedHealth {
+				subT.Errorf("expected health %s, got %s (%s)", tc.expectedHealth, health.Health, health.Message)
+			}
+			if health.Reason != tc.expectedReason {
+				subT.Errorf("expected reason %s, got %s", tc.expectedReason, health.Reason)
+			}
+		})
+	}
+}
+
+func TestDeploymentHealth(t *testing.T) {
+	deploymentFactory := func(status k8sappsv1.DeploymentStatus) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "system-app", Generation: 1},
+			Spec:       k8sappsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+			Status:     status,
+		}
+	}
+
+	completeCondition := k8sappsv1.DeploymentCondition{
+		Type:   k8sappsv1.DeploymentProgressing,
+		Status: v1.ConditionTrue,
+		Reason: deploymentRolloutCompleteReason,
+	}
+
+	cases := []struct {
+		testName       string
+		status         k8sappsv1.DeploymentStatus
+		expectedHealth appsv1alpha1.ComponentHealth
+		expectedReason string
+	}{
+		{"RolloutInProgress", k8sappsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			Conditions: []k8sappsv1.DeploymentCondition{
+				{Type: k8sappsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: "ReplicaSetUpdated"},
+			},
+		}, appsv1alpha1.ComponentProgressing, ComponentRolloutInProgressReason},
+		{"RolloutFailed", k8sappsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Conditions: []k8sappsv1.DeploymentCondition{
+				{Type: k8sappsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+			},
+		}, appsv1alpha1.ComponentUnhealthy, ComponentRolloutFailedReason},
+		{"PodsNotReady", k8sappsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    2,
+			Conditions:         []k8sappsv1.DeploymentCondition{completeCondition},
+		}, appsv1alpha1.ComponentUnhealthy, ComponentPodsNotReadyReason},
+		{"Healthy", k8sappsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+			Conditions:         []k8sappsv1.DeploymentCondition{completeCondition},
+		}, appsv1alpha1.ComponentHealthy, ComponentPodsReadyReason},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			health := DeploymentHealth(deploymentFactory(tc.status))
+			if health.Health != tc.expectedHealth {
+				subT.Errorf("expected health %s, got %s (%s)", tc.expectedHealth, health.Health, health.Message)
+			}
+			if health.Reason != tc.expectedReason {
+				subT.Errorf("expected reason %s, got %s", tc.expectedReason, health.Reason)
+			}
+			if health.Replicas != 2 {
+				subT.Errorf("expected 2 replicas, got %d", health.Replicas)
+			}
+		})
+	}
+}
@@ -44,13 +44,13 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 	}
 
 	// Zync DC
-	err = r.ReconcileDeploymentConfig(zync.DeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileWorkload(zync.DeploymentConfig(), reconcilers.GenericDeploymentConfigMutator, reconcilers.GenericDeploymentMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// Zync Que DC
-	err = r.ReconcileDeploymentConfig(zync.QueDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator)
+	err = r.ReconcileWorkload(zync.QueDeploymentConfig(), reconcilers.GenericDeploymentConfigMutator, reconcilers.GenericDeploymentMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -63,7 +63,7 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 
 	if !r.apiManager.IsZyncExternalDatabaseEnabled() {
 		// Zync DB DC
-		err = r.ReconcileDeploymentConfig(zync.DatabaseDeploymentConfig(), reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator)
+		err = r.ReconcileWorkload(zync.DatabaseDeploymentConfig(), reconcilers.DeploymentConfigResourcesAndAffinityAndTolerationsMutator, reconcilers.DeploymentResourcesAndAffinityAndTolerationsMutator)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -108,12 +108,12 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(component.ZyncPrometheusRules(r.apiManager.Namespace), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(component.ZyncPrometheusRules(r.apiManager.Namespace), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcilePrometheusRules(component.ZyncQuePrometheusRules(r.apiManager.Namespace), reconcilers.CreateOnlyMutator)
+	err = r.ReconcilePrometheusRules(component.ZyncQuePrometheusRules(r.apiManager.Namespace), reconcilers.GenericPrometheusRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
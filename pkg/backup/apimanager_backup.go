@@ -97,8 +97,18 @@ func (b *APIManagerBackup) BackupDestinationPVC() *v1.PersistentVolumeClaim {
 	return res
 }
 
+// BackupDestinationS3Location returns the location of the backup data in the
+// S3 backup destination. Empty when S3 is not the backup destination
+func (b *APIManagerBackup) BackupDestinationS3Location() string {
+	if b.options.APIManagerBackupS3Options == nil {
+		return ""
+	}
+
+	return b.options.APIManagerBackupS3Options.URL()
+}
+
 func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+	if b.options.APIManagerBackupPVCOptions == nil && b.options.APIManagerBackupS3Options == nil {
 		return nil
 	}
 
@@ -108,7 +118,7 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -123,7 +133,7 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
+						b.backupDestinationPodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
@@ -139,7 +149,7 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
+								b.backupDestinationContainerVolumeMount(),
 							},
 						},
 					},
@@ -149,10 +159,12 @@ func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
 			},
 		},
 	}
+
+	return b.withS3Upload(job)
 }
 
 func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+	if b.options.APIManagerBackupPVCOptions == nil && b.options.APIManagerBackupS3Options == nil {
 		return nil
 	}
 
@@ -162,7 +174,7 @@ func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -177,7 +189,7 @@ func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
+						b.backupDestinationPodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
@@ -193,7 +205,7 @@ func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
+								b.backupDestinationContainerVolumeMount(),
 							},
 						},
 					},
@@ -203,10 +215,12 @@ func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job
 			},
 		},
 	}
+
+	return b.withS3Upload(job)
 }
 
 func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+	if b.options.APIManagerBackupPVCOptions == nil && b.options.APIManagerBackupS3Options == nil {
 		return nil
 	}
 
@@ -216,7 +230,7 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -231,7 +245,7 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
+						b.backupDestinationPodVolume(),
 						b.systemFileStoragePodVolume(),
 					},
 					Containers: []v1.Container{
@@ -248,7 +262,7 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
+								b.backupDestinationContainerVolumeMount(),
 								b.systemFileStorageContainerVolumeMount(),
 							},
 						},
@@ -259,6 +273,69 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 			},
 		},
 	}
+
+	return b.withS3Upload(job)
+}
+
+// BackupDatabasesJob returns the job dumping the databases deployed by
+// the APIManager. Nil when external databases are enabled
+func (b *APIManagerBackup) BackupDatabasesJob() *batchv1.Job {
+	if b.options.APIManagerBackupPVCOptions == nil && b.options.APIManagerBackupS3Options == nil {
+		return nil
+	}
+
+	if len(InternalDatabases(b.APIManager())) == 0 {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("backup-databases", b.options.APIManagerBackupUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.backupDestinationPodVolume(),
+					},
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "backup-databases",
+							Image: b.options.OCCLIImageURL,
+							Command: []string{
+								"/bin/bash",
+							},
+							Args: []string{
+								"-c",
+								"-e",
+								b.backupDatabasesContainerArgs(),
+							},
+							VolumeMounts: []v1.VolumeMount{
+								b.backupDestinationContainerVolumeMount(),
+							},
+						},
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}
+
+	return b.withS3Upload(job)
 }
 
 func (b *APIManagerBackup) systemFileStoragePodVolume() v1.Volume {
@@ -280,7 +357,44 @@ func (b *APIManagerBackup) systemFileStorageContainerVolumeMount() v1.VolumeMoun
 	}
 }
 
-func (b *APIManagerBackup) pvcBackupDestinationPodVolume() v1.Volume {
+// withS3Upload turns the containers of the job into init containers
+// and adds a container uploading the backup data written by them to
+// the S3 backup destination. The job is not changed when S3 is not
+// the backup destination
+func (b *APIManagerBackup) withS3Upload(job *batchv1.Job) *batchv1.Job {
+	if b.options.APIManagerBackupS3Options == nil {
+		return job
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	podSpec.InitContainers = podSpec.Containers
+	podSpec.Containers = []v1.Container{
+		v1.Container{
+			Name:  "upload-to-s3",
+			Image: b.options.S3CLIImageURL,
+			Command: []string{
+				"/bin/bash",
+			},
+			Args: []string{
+				"-c",
+				"-e",
+				b.options.APIManagerBackupS3Options.UploadContainerArgs(),
+			},
+			Env: b.options.APIManagerBackupS3Options.UploadContainerEnv(BackupPVCMountPath),
+			VolumeMounts: []v1.VolumeMount{
+				b.backupDestinationContainerVolumeMount(),
+			},
+		},
+	}
+
+	return job
+}
+
+func (b *APIManagerBackup) backupDestinationPodVolume() v1.Volume {
+	if b.options.APIManagerBackupS3Options != nil {
+		return S3DataPodVolume()
+	}
+
 	return v1.Volume{
 		Name: b.BackupDestinationPVC().Name,
 		VolumeSource: v1.VolumeSource{
@@ -291,7 +405,14 @@ func (b *APIManagerBackup) pvcBackupDestinationPodVolume() v1.Volume {
 	}
 }
 
-func (b *APIManagerBackup) pvcBackupDestinationContainerVolumeMount() v1.VolumeMount {
+func (b *APIManagerBackup) backupDestinationContainerVolumeMount() v1.VolumeMount {
+	if b.options.APIManagerBackupS3Options != nil {
+		return v1.VolumeMount{
+			Name:      S3DataVolumeName,
+			MountPath: BackupPVCMountPath,
+		}
+	}
+
 	return v1.VolumeMount{
 		Name:      b.BackupDestinationPVC().Name,
 		MountPath: BackupPVCMountPath,
@@ -354,6 +475,21 @@ print(json.dumps(parsed, indent=4, sort_keys=True))
 }
 
 func (b *APIManagerBackup) backupSystemFilestoragePVCContainerArgs() string {
+	if b.options.APIManagerBackupS3Options != nil {
+		// Object storages are not filesystems. The file storage is
+		// archived to keep file attributes and reduce the number of objects
+		return fmt.Sprintf(`
+BASEPATH='%s';
+SYSTEM_FILESTORAGE_PVC_DIR='%s'
+SYSTEM_FILESTORAGE_ARCHIVE='%s'
+tar -czvf ${BASEPATH}/${SYSTEM_FILESTORAGE_ARCHIVE} -C ${SYSTEM_FILESTORAGE_PVC_DIR} .;
+`,
+			BackupPVCMountPath,
+			SystemFileStoragePVCMountPath,
+			SystemFileStorageArchiveFileName,
+		)
+	}
+
 	return fmt.Sprintf(`
 BASEPATH='%s';
 SYSTEM_FILESTORAGE_PVC_DIR='%s'
@@ -365,3 +501,32 @@ rsync -av ${SYSTEM_FILESTORAGE_PVC_DIR}/ ${PVC_BACKUP_FILESTORAGE_SUBDIR};
 		SystemFileStoragePVCMountPath,
 	)
 }
+
+func (b *APIManagerBackup) backupDatabasesContainerArgs() string {
+	var dumps strings.Builder
+	for _, database := range InternalDatabases(b.APIManager()) {
+		fmt.Fprintf(&dumps, `
+POD=$(database_pod %s);
+echo "Dumping database %s from pod ${POD}";
+oc exec ${POD} -c %s -- %s > ${DATABASES_SUBDIR}/%s;
+`,
+			database.Name,
+			database.Name,
+			database.Container,
+			database.dumpCommand(),
+			database.DumpFileName(),
+		)
+	}
+
+	return fmt.Sprintf(`
+BASEPATH='%s';
+DATABASES_SUBDIR="${BASEPATH}/%s";
+mkdir -p ${DATABASES_SUBDIR};
+%s
+%s`,
+		BackupPVCMountPath,
+		DatabaseDumpsSubdir,
+		DatabasePodShellFunction,
+		dumps.String(),
+	)
+}
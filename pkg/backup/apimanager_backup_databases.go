@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// DatabaseDumpsSubdir is the directory of the backup data where the
+// internal databases dumps are stored
+const DatabaseDumpsSubdir = "databases"
+
+type InternalDatabaseKind string
+
+const (
+	InternalDatabaseKindMySQL      InternalDatabaseKind = "mysql"
+	InternalDatabaseKindPostgreSQL InternalDatabaseKind = "postgresql"
+	InternalDatabaseKindRedis      InternalDatabaseKind = "redis"
+)
+
+// InternalDatabase is a database deployed by the APIManager
+// when external databases are not enabled
+type InternalDatabase struct {
+	// Name of the Deployment (or DeploymentConfig). Pods are
+	// labeled with it in the "deploymentConfig" label
+	Name      string
+	Container string
+	Kind      InternalDatabaseKind
+}
+
+var (
+	SystemMySQLDatabase      = InternalDatabase{Name: "system-mysql", Container: "system-mysql", Kind: InternalDatabaseKindMySQL}
+	SystemPostgreSQLDatabase = InternalDatabase{Name: "system-postgresql", Container: "system-postgresql", Kind: InternalDatabaseKindPostgreSQL}
+	SystemRedisDatabase      = InternalDatabase{Name: "system-redis", Container: "system-redis", Kind: InternalDatabaseKindRedis}
+	BackendRedisDatabase     = InternalDatabase{Name: "backend-redis", Container: "backend-redis", Kind: InternalDatabaseKindRedis}
+	ZyncDatabase             = InternalDatabase{Name: "zync-database", Container: "postgresql", Kind: InternalDatabaseKindPostgreSQL}
+)
+
+// AllInternalDatabases returns all the databases an APIManager can deploy
+func AllInternalDatabases() []InternalDatabase {
+	return []InternalDatabase{
+		SystemMySQLDatabase,
+		SystemPostgreSQLDatabase,
+		SystemRedisDatabase,
+		BackendRedisDatabase,
+		ZyncDatabase,
+	}
+}
+
+// InternalDatabases returns the databases deployed by the APIManager.
+// Empty when external databases are enabled
+func InternalDatabases(apimanager *appsv1alpha1.APIManager) []InternalDatabase {
+	if apimanager.IsExternalDatabaseEnabled() {
+		return []InternalDatabase{}
+	}
+
+	systemDatabase := SystemMySQLDatabase
+	if apimanager.Spec.System != nil && apimanager.IsSystemPostgreSQLEnabled() {
+		systemDatabase = SystemPostgreSQLDatabase
+	}
+
+	return []InternalDatabase{
+		systemDatabase,
+		SystemRedisDatabase,
+		BackendRedisDatabase,
+		ZyncDatabase,
+	}
+}
+
+// DumpFileName returns the name of the database dump file in
+// the DatabaseDumpsSubdir directory. SQL dumps for relational
+// databases, RDB snapshots for Redis
+func (d InternalDatabase) DumpFileName() string {
+	if d.Kind == InternalDatabaseKindRedis {
+		return fmt.Sprintf("%s.rdb", d.Name)
+	}
+	return fmt.Sprintf("%s.sql", d.Name)
+}
+
+// DatabasePodShellFunction is a shell function printing the name of a
+// running pod of the Deployment given as first argument
+const DatabasePodShellFunction = `
+database_pod() {
+	pods=$(oc get pods --ignore-not-found=true -l deploymentConfig=${1} --field-selector=status.phase=Running --no-headers=true -o custom-columns=:metadata.name)
+	if [ -z "${pods}" ]; then
+		echo "No running pods found for Deployment ${1}" >&2
+		return 1
+	fi
+	echo -n ${pods} | awk '{print $1}'
+}
+`
+
+// dumpCommand returns the command run in the database container
+// writing a consistent dump of the database to the standard output
+func (d InternalDatabase) dumpCommand() string {
+	switch d.Kind {
+	case InternalDatabaseKindMySQL:
+		// Same shell invocation as the readiness probe so the
+		// image environment is loaded
+		return `/bin/sh -i -c 'MYSQL_PWD="${MYSQL_ROOT_PASSWORD}" mysqldump -u root --single-transaction --routines --triggers --databases ${MYSQL_DATABASE}'`
+	case InternalDatabaseKindPostgreSQL:
+		return `/bin/sh -i -c 'PGPASSWORD="${POSTGRESQL_PASSWORD}" pg_dump -h 127.0.0.1 -U ${POSTGRESQL_USER} --clean --if-exists --no-owner --no-privileges ${POSTGRESQL_DATABASE}'`
+	default:
+		// The snapshot is requested over the replication protocol so
+		// the server keeps serving requests while it is taken
+		return `container-entrypoint bash -c 'set -e; redis-cli --rdb /var/lib/redis/data/backup.rdb > /dev/null; cat /var/lib/redis/data/backup.rdb; rm -f /var/lib/redis/data/backup.rdb'`
+	}
+}
@@ -0,0 +1,98 @@
+package backup
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInternalDatabases(t *testing.T) {
+	cases := []struct {
+		name     string
+		spec     appsv1alpha1.APIManagerSpec
+		expected []string
+	}{
+		{"defaults", appsv1alpha1.APIManagerSpec{System: &appsv1alpha1.SystemSpec{}},
+			[]string{"system-mysql", "system-redis", "backend-redis", "zync-database"}},
+		{"postgresql", appsv1alpha1.APIManagerSpec{System: &appsv1alpha1.SystemSpec{
+			DatabaseSpec: &appsv1alpha1.SystemDatabaseSpec{PostgreSQL: &appsv1alpha1.SystemPostgreSQLSpec{}},
+		}}, []string{"system-postgresql", "system-redis", "backend-redis", "zync-database"}},
+		{"external databases", appsv1alpha1.APIManagerSpec{
+			HighAvailability: &appsv1alpha1.HighAvailabilitySpec{Enabled: true},
+		}, []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			apimanager := &appsv1alpha1.APIManager{Spec: tc.spec}
+			names := []string{}
+			for _, database := range InternalDatabases(apimanager) {
+				names = append(names, database.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				subT.Errorf("got: %v; expected: %v", names, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBackupDatabasesJob(t *testing.T) {
+	options := &APIManagerBackupOptions{
+		Namespace:            "3scale",
+		APIManagerBackupName: "mybackup",
+		APIManagerBackupUID:  "fc9b0ae4-5e3d-4c47-a5b2-7c2a3d4bca5d",
+		APIManagerName:       "example-apimanager",
+		APIManager: &appsv1alpha1.APIManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager"},
+			Spec:       appsv1alpha1.APIManagerSpec{System: &appsv1alpha1.SystemSpec{}},
+		},
+		APIManagerBackupPVCOptions: &APIManagerBackupPVCOptions{
+			BackupDestinationPVC: BackupDestinationPVC{Name: "apimanager-backup-mybackup"},
+		},
+		OCCLIImageURL: "oc-cli",
+		S3CLIImageURL: "s3-cli",
+	}
+
+	job := NewAPIManagerBackup(options).BackupDatabasesJob()
+	if job == nil {
+		t.Fatal("expected databases backup job")
+	}
+
+	containers := job.Spec.Template.Spec.Containers
+	if len(containers) != 1 || containers[0].Image != "oc-cli" {
+		t.Fatalf("expected the backup container, got %v", containers)
+	}
+	script := containers[0].Args[2]
+	for _, expected := range []string{
+		"database_pod system-mysql",
+		"-c system-mysql -- /bin/sh -i -c 'MYSQL_PWD=\"${MYSQL_ROOT_PASSWORD}\" mysqldump",
+		"> ${DATABASES_SUBDIR}/system-mysql.sql",
+		"-c postgresql -- /bin/sh -i -c 'PGPASSWORD=\"${POSTGRESQL_PASSWORD}\" pg_dump",
+		"> ${DATABASES_SUBDIR}/zync-database.sql",
+		"-c backend-redis -- container-entrypoint bash -c 'set -e; redis-cli --rdb",
+		"> ${DATABASES_SUBDIR}/system-redis.rdb",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("backup script does not contain %q: %s", expected, script)
+		}
+	}
+	if strings.Contains(script, "system-postgresql") {
+		t.Errorf("unexpected system-postgresql dump in backup script: %s", script)
+	}
+
+	var destinationMounted bool
+	for _, volumeMount := range containers[0].VolumeMounts {
+		destinationMounted = destinationMounted || (volumeMount.Name == "mybackup" && volumeMount.MountPath == BackupPVCMountPath)
+	}
+	if !destinationMounted {
+		t.Errorf("expected backup destination PVC mount, got %v", containers[0].VolumeMounts)
+	}
+
+	options.APIManager.Spec.HighAvailability = &appsv1alpha1.HighAvailabilitySpec{Enabled: true}
+	if job := NewAPIManagerBackup(options).BackupDatabasesJob(); job != nil {
+		t.Errorf("unexpected databases backup job with external databases: %v", job.Spec.Template.Spec.Containers)
+	}
+}
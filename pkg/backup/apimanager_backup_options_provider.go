@@ -39,18 +39,28 @@ func (a *APIManagerBackupOptionsProvider) Options() (*APIManagerBackupOptions, e
 	res.APIManager = apiManager
 	res.APIManagerName = apiManager.Name
 	res.OCCLIImageURL = a.ocCLIImageURL()
+	res.S3CLIImageURL = a.s3CLIImageURL()
 
 	pvcOptions, err := a.pvcBackupOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.s3BackupOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerBackup struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one backup destination has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one backup destination can be specified")
+	}
 
 	res.APIManagerBackupPVCOptions = pvcOptions
+	res.APIManagerBackupS3Options = s3Options
 
 	return res, res.Validate()
 }
@@ -71,6 +81,17 @@ func (a *APIManagerBackupOptionsProvider) pvcBackupOptions() (*APIManagerBackupP
 	return res, res.Validate()
 }
 
+func (a *APIManagerBackupOptionsProvider) s3BackupOptions() (*S3ObjectStorageOptions, error) {
+	if a.APIManagerBackupCR.Spec.BackupDestination.S3 == nil {
+		return nil, nil
+	}
+
+	// Each backup is stored in its own directory under the prefix
+	res := NewS3ObjectStorageOptions(a.APIManagerBackupCR.Spec.BackupDestination.S3, a.APIManagerBackupCR.Name)
+
+	return res, res.Validate()
+}
+
 func (a *APIManagerBackupOptionsProvider) apiManager() (*appsv1alpha1.APIManager, error) {
 	return a.autodiscoveredAPIManager()
 }
@@ -98,3 +119,7 @@ func (a *APIManagerBackupOptionsProvider) autodiscoveredAPIManager() (*appsv1alp
 func (a *APIManagerBackupOptionsProvider) ocCLIImageURL() string {
 	return helper.GetEnvVar("OSE_CLI_IMAGE", component.OCCLIImageURL())
 }
+
+func (a *APIManagerBackupOptionsProvider) s3CLIImageURL() string {
+	return helper.GetEnvVar("S3_CLI_IMAGE", component.S3CLIImageURL())
+}
@@ -0,0 +1,117 @@
+package backup
+
+import (
+	"sort"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupFinished returns true when the backup is either completed or failed
+func BackupFinished(backup *appsv1alpha1.APIManagerBackup) bool {
+	return backup.BackupCompleted() || backup.BackupFailed()
+}
+
+// BackupsToPrune returns the finished backups exceeding the retention policy,
+// oldest first. Backups in progress are never pruned, neither is the most recent
+// completed backup, so a restore is always possible when recent backups failed
+func BackupsToPrune(backups []appsv1alpha1.APIManagerBackup, retention *appsv1alpha1.APIManagerBackupRetention, now time.Time) []appsv1alpha1.APIManagerBackup {
+	if retention == nil {
+		return nil
+	}
+
+	finished := []appsv1alpha1.APIManagerBackup{}
+	for idx := range backups {
+		if BackupFinished(&backups[idx]) {
+			finished = append(finished, backups[idx])
+		}
+	}
+
+	// Newest first
+	sort.Slice(finished, func(i, j int) bool {
+		ti, tj := finished[i].CreationTimestamp, finished[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return finished[i].Name > finished[j].Name
+		}
+		return tj.Before(&ti)
+	})
+
+	lastCompletedIdx := -1
+	for idx := range finished {
+		if finished[idx].BackupCompleted() {
+			lastCompletedIdx = idx
+			break
+		}
+	}
+
+	prune := []appsv1alpha1.APIManagerBackup{}
+	for idx := len(finished) - 1; idx >= 0; idx-- {
+		if idx == lastCompletedIdx {
+			continue
+		}
+
+		exceedsCount := retention.KeepLast != nil && idx >= int(*retention.KeepLast)
+		exceedsAge := retention.MaxAge != nil && now.Sub(finished[idx].CreationTimestamp.Time) > retention.MaxAge.Duration
+		if exceedsCount || exceedsAge {
+			prune = append(prune, finished[idx])
+		}
+	}
+
+	return prune
+}
+
+// S3BackupDataPruneJob returns the job removing the backup data of the backup
+// from the S3 backup destination. Nil when the backup data was not stored in S3
+func S3BackupDataPruneJob(backup *appsv1alpha1.APIManagerBackup, s3CLIImageURL string) (*batchv1.Job, error) {
+	if backup.Status.BackupS3Location == nil || backup.Spec.BackupDestination.S3 == nil {
+		return nil, nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("prune-backup-s3", backup.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same location the backup data was uploaded to, see APIManagerBackupOptionsProvider
+	s3Options := NewS3ObjectStorageOptions(backup.Spec.BackupDestination.S3, backup.Name)
+
+	var completions int32 = 1
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: backup.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "prune-s3",
+							Image: s3CLIImageURL,
+							Command: []string{
+								"/bin/bash",
+							},
+							Args: []string{
+								"-c",
+								"-e",
+								s3Options.PruneContainerArgs(),
+							},
+							Env: s3Options.Env(),
+						},
+					},
+					RestartPolicy: v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}, nil
+}
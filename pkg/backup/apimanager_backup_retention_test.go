@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackupsToPrune(t *testing.T) {
+	now := time.Date(2021, time.March, 17, 12, 0, 0, 0, time.UTC)
+	trueValue := true
+
+	backupFactory := func(name string, age time.Duration, completed, failed bool) appsv1alpha1.APIManagerBackup {
+		backup := appsv1alpha1.APIManagerBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+		}
+		if completed {
+			backup.Status.Completed = &trueValue
+		}
+		if failed {
+			backup.Status.Failed = &trueValue
+		}
+		return backup
+	}
+
+	day := 24 * time.Hour
+	backups := []appsv1alpha1.APIManagerBackup{
+		backupFactory("b1", 4*day, true, false),
+		backupFactory("b4", 1*day, false, true),
+		backupFactory("b2", 3*day, true, false),
+		backupFactory("b5", 0, false, false),
+		backupFactory("b3", 2*day, true, false),
+	}
+
+	keepLast := func(n int32) *int32 { return &n }
+	maxAge := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+	cases := []struct {
+		name      string
+		backups   []appsv1alpha1.APIManagerBackup
+		retention *appsv1alpha1.APIManagerBackupRetention
+		expected  []string
+	}{
+		{"noRetention", backups, nil, []string{}},
+		{"keepLast", backups, &appsv1alpha1.APIManagerBackupRetention{KeepLast: keepLast(2)}, []string{"b1", "b2"}},
+		{"keepLastKeepsLastCompleted", backups, &appsv1alpha1.APIManagerBackupRetention{KeepLast: keepLast(1)}, []string{"b1", "b2"}},
+		{"maxAge", backups, &appsv1alpha1.APIManagerBackupRetention{MaxAge: maxAge(36 * time.Hour)}, []string{"b1", "b2"}},
+		{"maxAgeKeepsLastCompleted", backups, &appsv1alpha1.APIManagerBackupRetention{MaxAge: maxAge(time.Hour)}, []string{"b1", "b2", "b4"}},
+		{"both", backups, &appsv1alpha1.APIManagerBackupRetention{KeepLast: keepLast(3), MaxAge: maxAge(90 * time.Hour)}, []string{"b1"}},
+		{"onlyFailed", []appsv1alpha1.APIManagerBackup{
+			backupFactory("f1", 2*day, false, true),
+			backupFactory("f2", 1*day, false, true),
+		}, &appsv1alpha1.APIManagerBackupRetention{KeepLast: keepLast(1)}, []string{"f1"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			names := []string{}
+			for _, backup := range BackupsToPrune(tc.backups, tc.retention, now) {
+				names = append(names, backup.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				subT.Errorf("got: %v; expected: %v", names, tc.expected)
+			}
+		})
+	}
+}
+
+func TestS3BackupDataPruneJob(t *testing.T) {
+	prefix := "backups"
+	location := "s3://mybucket/backups/mybackup"
+	s3 := &appsv1alpha1.S3ObjectStorage{
+		Bucket:               "mybucket",
+		Prefix:               &prefix,
+		CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+	}
+
+	cases := []struct {
+		testName    string
+		destination appsv1alpha1.APIManagerBackupDestination
+		location    *string
+		expectedJob bool
+	}{
+		{"s3", appsv1alpha1.APIManagerBackupDestination{S3: s3}, &location, true},
+		{"s3NotUploaded", appsv1alpha1.APIManagerBackupDestination{S3: s3}, nil, false},
+		{"pvc", appsv1alpha1.APIManagerBackupDestination{PersistentVolumeClaim: &appsv1alpha1.PersistentVolumeClaimBackupDestination{}}, nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			backup := &appsv1alpha1.APIManagerBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "mybackup", Namespace: "3scale", UID: "fc9b0ae4-5e3d-4c47-a5b2-7c2a3d4bca5d"},
+				Spec:       appsv1alpha1.APIManagerBackupSpec{BackupDestination: tc.destination},
+				Status:     appsv1alpha1.APIManagerBackupStatus{BackupS3Location: tc.location},
+			}
+
+			job, err := S3BackupDataPruneJob(backup, "s3-cli")
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if (job != nil) != tc.expectedJob {
+				subT.Fatalf("expected job %t, got %v", tc.expectedJob, job)
+			}
+			if job == nil {
+				return
+			}
+
+			if job.Namespace != "3scale" {
+				subT.Errorf("unexpected job namespace: %s", job.Namespace)
+			}
+			container := job.Spec.Template.Spec.Containers[0]
+			if container.Image != "s3-cli" {
+				subT.Errorf("unexpected image: %s", container.Image)
+			}
+			if value := envValue(container.Env, "S3_URL"); value != location {
+				subT.Errorf("unexpected prune location: %s", value)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package backup
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	validator "github.com/go-playground/validator/v10"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	DefaultS3Endpoint = "https://s3.amazonaws.com"
+	DefaultS3Region   = "us-east-1"
+
+	S3AccessKeyIDSecretField     = "AWS_ACCESS_KEY_ID"
+	S3SecretAccessKeySecretField = "AWS_SECRET_ACCESS_KEY"
+
+	// S3DataVolumeName is the name of the scratch volume where the backup
+	// data is staged before being uploaded to or after being downloaded
+	// from the object storage
+	S3DataVolumeName = "backup-data"
+
+	SystemFileStorageArchiveFileName = "system-filestorage.tar.gz"
+)
+
+// S3ObjectStorageOptions defines the location of the backup data in
+// a S3 API-compatible object storage
+type S3ObjectStorageOptions struct {
+	Endpoint              string `validate:"required"`
+	Region                string `validate:"required"`
+	Bucket                string `validate:"required"`
+	Path                  string // Path of the backup data inside the bucket
+	CredentialsSecretName string `validate:"required"`
+}
+
+// NewS3ObjectStorageOptions returns the options for the given object storage
+// location. The elements of subPath are appended to the object storage prefix
+func NewS3ObjectStorageOptions(s3 *appsv1alpha1.S3ObjectStorage, subPath ...string) *S3ObjectStorageOptions {
+	res := &S3ObjectStorageOptions{
+		Endpoint:              DefaultS3Endpoint,
+		Region:                DefaultS3Region,
+		Bucket:                s3.Bucket,
+		CredentialsSecretName: s3.CredentialsSecretRef.Name,
+	}
+
+	if s3.Endpoint != nil && *s3.Endpoint != "" {
+		res.Endpoint = *s3.Endpoint
+	}
+
+	if s3.Region != nil && *s3.Region != "" {
+		res.Region = *s3.Region
+	}
+
+	pathElems := []string{}
+	if s3.Prefix != nil {
+		pathElems = append(pathElems, *s3.Prefix)
+	}
+	pathElems = append(pathElems, subPath...)
+	res.Path = strings.Trim(path.Join(pathElems...), "/")
+
+	return res
+}
+
+func (s *S3ObjectStorageOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
+
+// URL returns the location of the backup data in s3://<bucket>/<path> form
+func (s *S3ObjectStorageOptions) URL() string {
+	if s.Path == "" {
+		return fmt.Sprintf("s3://%s", s.Bucket)
+	}
+	return fmt.Sprintf("s3://%s/%s", s.Bucket, s.Path)
+}
+
+// Env returns the environment of the containers accessing the object storage.
+// Credentials are read from the credentials secret
+func (s *S3ObjectStorageOptions) Env() []v1.EnvVar {
+	return []v1.EnvVar{
+		{
+			Name: S3AccessKeyIDSecretField,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: s.CredentialsSecretName},
+					Key:                  S3AccessKeyIDSecretField,
+				},
+			},
+		},
+		{
+			Name: S3SecretAccessKeySecretField,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: s.CredentialsSecretName},
+					Key:                  S3SecretAccessKeySecretField,
+				},
+			},
+		},
+		{Name: "AWS_DEFAULT_REGION", Value: s.Region},
+		// The CLI writes its cache in the home directory, which
+		// might not be writable for arbitrary user IDs
+		{Name: "HOME", Value: "/tmp"},
+		{Name: "S3_URL", Value: s.URL()},
+		{Name: "S3_ENDPOINT", Value: s.Endpoint},
+	}
+}
+
+// UploadContainerEnv returns the environment of the container uploading
+// the contents of the local directory to the object storage
+func (s *S3ObjectStorageOptions) UploadContainerEnv(localDir string) []v1.EnvVar {
+	return append(s.Env(), v1.EnvVar{Name: "LOCALDIR", Value: localDir})
+}
+
+// UploadContainerArgs returns the script that uploads the contents of the
+// local directory to the object storage. The locations are read from the
+// environment set by UploadContainerEnv, so they are never parsed by the shell
+func (s *S3ObjectStorageOptions) UploadContainerArgs() string {
+	return `
+aws s3 sync --no-progress --endpoint-url "${S3_ENDPOINT}" "${LOCALDIR}/" "${S3_URL}/";
+`
+}
+
+// DownloadContainerEnv returns the environment of the container downloading
+// the backup data to the local directory. Only the files matching the include
+// patterns, relative to the backup data path, are downloaded
+func (s *S3ObjectStorageOptions) DownloadContainerEnv(localDir string, includes ...string) []v1.EnvVar {
+	return append(s.Env(),
+		v1.EnvVar{Name: "LOCALDIR", Value: localDir},
+		v1.EnvVar{Name: "S3_INCLUDES", Value: strings.Join(includes, "\n")},
+	)
+}
+
+// DownloadContainerArgs returns the script that downloads the backup data
+// to the local directory. The locations and the include patterns are read from
+// the environment set by DownloadContainerEnv, so they are never parsed by the shell
+func (s *S3ObjectStorageOptions) DownloadContainerArgs() string {
+	return `
+INCLUDE_ARGS=();
+while IFS= read -r INCLUDE; do
+  if [ -n "${INCLUDE}" ]; then
+    INCLUDE_ARGS+=(--include "${INCLUDE}");
+  fi;
+done <<< "${S3_INCLUDES}";
+aws s3 sync --no-progress --endpoint-url "${S3_ENDPOINT}" --exclude '*' "${INCLUDE_ARGS[@]}" "${S3_URL}/" "${LOCALDIR}/";
+`
+}
+
+// PruneContainerArgs returns the script that removes the backup data from the
+// object storage. The location is read from the environment set by Env
+func (s *S3ObjectStorageOptions) PruneContainerArgs() string {
+	return `
+aws s3 rm --recursive --no-progress --endpoint-url "${S3_ENDPOINT}" "${S3_URL}/";
+`
+}
+
+// S3DataPodVolume returns the scratch volume used to stage the backup data
+func S3DataPodVolume() v1.Volume {
+	return v1.Volume{
+		Name: S3DataVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{},
+		},
+	}
+}
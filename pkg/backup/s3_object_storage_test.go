@@ -0,0 +1,172 @@
+package backup
+
+import (
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewS3ObjectStorageOptions(t *testing.T) {
+	endpoint := "http://minio.minio.svc:9000"
+	region := "eu-west-1"
+	prefix := "/3scale/backups/"
+	emptyString := ""
+
+	cases := []struct {
+		name             string
+		s3               *appsv1alpha1.S3ObjectStorage
+		subPath          []string
+		expectedEndpoint string
+		expectedRegion   string
+		expectedURL      string
+	}{
+		{
+			"defaults",
+			&appsv1alpha1.S3ObjectStorage{Bucket: "mybucket"},
+			nil,
+			DefaultS3Endpoint, DefaultS3Region, "s3://mybucket",
+		},
+		{
+			"empty values",
+			&appsv1alpha1.S3ObjectStorage{Bucket: "mybucket", Endpoint: &emptyString, Region: &emptyString, Prefix: &emptyString},
+			nil,
+			DefaultS3Endpoint, DefaultS3Region, "s3://mybucket",
+		},
+		{
+			"custom endpoint",
+			&appsv1alpha1.S3ObjectStorage{Bucket: "mybucket", Endpoint: &endpoint, Region: &region, Prefix: &prefix},
+			nil,
+			endpoint, region, "s3://mybucket/3scale/backups",
+		},
+		{
+			"subpath",
+			&appsv1alpha1.S3ObjectStorage{Bucket: "mybucket", Prefix: &prefix},
+			[]string{"mybackup"},
+			DefaultS3Endpoint, DefaultS3Region, "s3://mybucket/3scale/backups/mybackup",
+		},
+		{
+			"subpath without prefix",
+			&appsv1alpha1.S3ObjectStorage{Bucket: "mybucket"},
+			[]string{"mybackup"},
+			DefaultS3Endpoint, DefaultS3Region, "s3://mybucket/mybackup",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			opts := NewS3ObjectStorageOptions(tc.s3, tc.subPath...)
+			if opts.Endpoint != tc.expectedEndpoint {
+				subT.Errorf("endpoint: expected %s, got %s", tc.expectedEndpoint, opts.Endpoint)
+			}
+			if opts.Region != tc.expectedRegion {
+				subT.Errorf("region: expected %s, got %s", tc.expectedRegion, opts.Region)
+			}
+			if opts.URL() != tc.expectedURL {
+				subT.Errorf("url: expected %s, got %s", tc.expectedURL, opts.URL())
+			}
+		})
+	}
+}
+
+func TestS3DestinationBackupJobs(t *testing.T) {
+	s3 := &appsv1alpha1.S3ObjectStorage{
+		Bucket:               "mybucket",
+		CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+	}
+
+	options := &APIManagerBackupOptions{
+		Namespace:                 "3scale",
+		APIManagerBackupName:      "mybackup",
+		APIManagerBackupUID:       "fc9b0ae4-5e3d-4c47-a5b2-7c2a3d4bca5d",
+		APIManagerName:            "example-apimanager",
+		APIManager:                &appsv1alpha1.APIManager{ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager"}},
+		APIManagerBackupS3Options: NewS3ObjectStorageOptions(s3, "mybackup"),
+		OCCLIImageURL:             "oc-cli",
+		S3CLIImageURL:             "s3-cli",
+	}
+	if err := options.Validate(); err != nil {
+		t.Fatalf("unexpected options validation error: %v", err)
+	}
+
+	apiManagerBackup := NewAPIManagerBackup(options)
+
+	if pvc := apiManagerBackup.BackupDestinationPVC(); pvc != nil {
+		t.Errorf("unexpected backup destination PVC: %v", pvc)
+	}
+
+	if location := apiManagerBackup.BackupDestinationS3Location(); location != "s3://mybucket/mybackup" {
+		t.Errorf("unexpected backup destination location: %s", location)
+	}
+
+	jobs := map[string]*v1.PodSpec{
+		"secrets and configmaps": &apiManagerBackup.BackupSecretsAndConfigMapsToPVCJob().Spec.Template.Spec,
+		"apimanager":             &apiManagerBackup.BackupAPIManagerCustomResourceToPVCJob().Spec.Template.Spec,
+		"system file storage":    &apiManagerBackup.BackupSystemFileStoragePVCToPVCJob().Spec.Template.Spec,
+		"databases":              &apiManagerBackup.BackupDatabasesJob().Spec.Template.Spec,
+	}
+
+	for name, podSpec := range jobs {
+		t.Run(name, func(subT *testing.T) {
+			if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Image != "oc-cli" {
+				subT.Fatalf("expected the backup container as init container, got %v", podSpec.InitContainers)
+			}
+			if len(podSpec.Containers) != 1 || podSpec.Containers[0].Image != "s3-cli" {
+				subT.Fatalf("expected the upload container, got %v", podSpec.Containers)
+			}
+			if strings.Contains(podSpec.Containers[0].Args[2], "mybucket") {
+				subT.Errorf("upload script should read the backup location from the environment: %s", podSpec.Containers[0].Args[2])
+			}
+			if value := envValue(podSpec.Containers[0].Env, "S3_URL"); value != "s3://mybucket/mybackup" {
+				subT.Errorf("unexpected upload backup location: %s", value)
+			}
+			if value := envValue(podSpec.Containers[0].Env, "LOCALDIR"); value != BackupPVCMountPath {
+				subT.Errorf("unexpected upload local directory: %s", value)
+			}
+
+			var dataVolume *v1.Volume
+			for idx := range podSpec.Volumes {
+				if podSpec.Volumes[idx].Name == S3DataVolumeName {
+					dataVolume = &podSpec.Volumes[idx]
+				}
+			}
+			if dataVolume == nil || dataVolume.EmptyDir == nil {
+				subT.Errorf("expected backup data emptyDir volume, got %v", podSpec.Volumes)
+			}
+
+			for _, env := range podSpec.Containers[0].Env {
+				if env.Name == S3AccessKeyIDSecretField && env.ValueFrom.SecretKeyRef.Name != "s3-credentials" {
+					subT.Errorf("unexpected credentials secret: %s", env.ValueFrom.SecretKeyRef.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestS3DownloadContainerEnv(t *testing.T) {
+	opts := NewS3ObjectStorageOptions(&appsv1alpha1.S3ObjectStorage{Bucket: "mybucket"}, "mybackup")
+	env := opts.DownloadContainerEnv("/backup", "secrets/*", "configmaps/*")
+
+	expected := map[string]string{
+		"S3_URL":      "s3://mybucket/mybackup",
+		"S3_ENDPOINT": DefaultS3Endpoint,
+		"LOCALDIR":    "/backup",
+		"S3_INCLUDES": "secrets/*\nconfigmaps/*",
+	}
+	for name, value := range expected {
+		if envValue(env, name) != value {
+			t.Errorf("%s: expected %q, got %q", name, value, envValue(env, name))
+		}
+	}
+}
+
+func envValue(env []v1.EnvVar, name string) string {
+	for _, envVar := range env {
+		if envVar.Name == name {
+			return envVar.Value
+		}
+	}
+	return ""
+}
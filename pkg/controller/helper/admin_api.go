@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// AdminAPIError is returned when the 3scale admin API responds with an unexpected status code
+type AdminAPIError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Body       string
+}
+
+func (e *AdminAPIError) Error() string {
+	return fmt.Sprintf("%s %s: unexpected status code %d: %s", e.Method, e.Endpoint, e.StatusCode, e.Body)
+}
+
+// IsAdminAPINotFound returns true when the error is an admin API "not found" response
+func IsAdminAPINotFound(err error) bool {
+	apiErr := &AdminAPIError{}
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// adminAPIClient implements the plumbing to call 3scale admin API endpoints
+// not implemented by the porta client
+type adminAPIClient struct {
+	adminURL   *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+func newAdminAPIClient(providerAccount *ProviderAccount) (*adminAPIClient, error) {
+	adminURL, err := url.Parse(providerAccount.AdminURLStr)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := portaHTTPClient(adminURL, providerAccount.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminAPIClient{
+		adminURL:   adminURL,
+		token:      providerAccount.Token,
+		httpClient: httpClient,
+	}, nil
+}
+
+func (c *adminAPIClient) doForm(method, endpoint string, params threescaleapi.Params, decodeInto interface{}) error {
+	values := url.Values{}
+	for k, v := range params {
+		values.Add(k, v)
+	}
+
+	return c.do(method, endpoint, strings.NewReader(values.Encode()), decodeInto)
+}
+
+func (c *adminAPIClient) do(method, endpoint string, body io.Reader, decodeInto interface{}) error {
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.adminURL.String(), "/")+endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth("", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return &AdminAPIError{Method: method, Endpoint: endpoint, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	if decodeInto == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(decodeInto)
+}
@@ -0,0 +1,99 @@
+package helper
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strconv"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	// AdminAPICABundleSecretField is the secret field with PEM encoded CA certificates
+	// trusted to verify the 3scale admin API server certificate
+	AdminAPICABundleSecretField = "caBundle"
+
+	// AdminAPITLSCertSecretField is the secret field with the PEM encoded client certificate
+	AdminAPITLSCertSecretField = "tlsCert"
+
+	// AdminAPITLSKeySecretField is the secret field with the PEM encoded client certificate key
+	AdminAPITLSKeySecretField = "tlsKey"
+
+	// AdminAPIInsecureSkipVerifySecretField is the secret field to enable or disable
+	// the verification of the 3scale admin API server certificate
+	AdminAPIInsecureSkipVerifySecretField = "insecureSkipVerify"
+)
+
+// AdminAPITLSConfig holds the TLS settings of the http client used to reach the 3scale admin API
+type AdminAPITLSConfig struct {
+	// CABundle are PEM encoded CA certificates added to the system trusted certificates
+	CABundle []byte
+	// ClientCert and ClientKey are the PEM encoded client certificate and key for mutual TLS
+	ClientCert []byte
+	ClientKey  []byte
+	// InsecureSkipVerify disables server certificate verification
+	InsecureSkipVerify bool
+}
+
+// AdminAPITLSConfigFromSecretData reads the optional TLS settings from the credentials secret data.
+// Server certificate verification is skipped by default, unless a CA bundle is provided.
+// The insecureSkipVerify field, when set, takes precedence.
+func AdminAPITLSConfigFromSecretData(secretName string, data map[string][]byte) (*AdminAPITLSConfig, error) {
+	tlsConfig := &AdminAPITLSConfig{
+		CABundle:   data[AdminAPICABundleSecretField],
+		ClientCert: data[AdminAPITLSCertSecretField],
+		ClientKey:  data[AdminAPITLSKeySecretField],
+	}
+
+	if (len(tlsConfig.ClientCert) == 0) != (len(tlsConfig.ClientKey) == 0) {
+		return nil, fmt.Errorf("Secret fields '%s' and '%s' must be set together in secret '%s'",
+			AdminAPITLSCertSecretField, AdminAPITLSKeySecretField, secretName)
+	}
+
+	// Backwards compatible default: verification was always skipped
+	tlsConfig.InsecureSkipVerify = len(tlsConfig.CABundle) == 0
+
+	insecureSkipVerifyStr := helper.GetSecretDataValue(data, AdminAPIInsecureSkipVerifySecretField)
+	if insecureSkipVerifyStr != nil {
+		insecureSkipVerify, err := strconv.ParseBool(*insecureSkipVerifyStr)
+		if err != nil {
+			return nil, fmt.Errorf("Secret field '%s' in secret '%s' is not a boolean: %w",
+				AdminAPIInsecureSkipVerifySecretField, secretName, err)
+		}
+		tlsConfig.InsecureSkipVerify = insecureSkipVerify
+	}
+
+	return tlsConfig, nil
+}
+
+// TLSClientConfig builds the crypto/tls configuration.
+// Nil receiver returns the default configuration
+func (a *AdminAPITLSConfig) TLSClientConfig() (*tls.Config, error) {
+	if a == nil {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: a.InsecureSkipVerify}
+
+	if len(a.CABundle) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(a.CABundle) {
+			return nil, fmt.Errorf("no valid PEM certificates found in '%s'", AdminAPICABundleSecretField)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if len(a.ClientCert) > 0 {
+		clientCert, err := tls.X509KeyPair(a.ClientCert, a.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,135 @@
+package helper
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAdminAPITLSConfigFromSecretData(t *testing.T) {
+	cases := []struct {
+		name                       string
+		data                       map[string][]byte
+		expectedErr                bool
+		expectedInsecureSkipVerify bool
+	}{
+		{"noTLSFields", map[string][]byte{}, false, true},
+		{"caBundle", map[string][]byte{"caBundle": []byte("ca")}, false, false},
+		{"caBundleAndInsecure", map[string][]byte{"caBundle": []byte("ca"), "insecureSkipVerify": []byte("true")}, false, true},
+		{"verifyWithSystemCAs", map[string][]byte{"insecureSkipVerify": []byte("false")}, false, false},
+		{"invalidInsecure", map[string][]byte{"insecureSkipVerify": []byte("maybe")}, true, false},
+		{"certWithoutKey", map[string][]byte{"tlsCert": []byte("cert")}, true, false},
+		{"keyWithoutCert", map[string][]byte{"tlsKey": []byte("key")}, true, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			tlsConfig, err := AdminAPITLSConfigFromSecretData("mysecret", tc.data)
+			if tc.expectedErr {
+				if err == nil {
+					subT.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if tlsConfig.InsecureSkipVerify != tc.expectedInsecureSkipVerify {
+				subT.Errorf("unexpected insecureSkipVerify: %t", tlsConfig.InsecureSkipVerify)
+			}
+		})
+	}
+}
+
+func TestPortaClientMutualTLS(t *testing.T) {
+	clientCertPEM, clientKeyPEM, clientCert := testSelfSignedCert(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"services":[]}`)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	serverCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	cases := []struct {
+		name        string
+		tlsConfig   *AdminAPITLSConfig
+		expectedErr bool
+	}{
+		{"unknownServerCA", &AdminAPITLSConfig{ClientCert: clientCertPEM, ClientKey: clientKeyPEM}, true},
+		{"missingClientCert", &AdminAPITLSConfig{CABundle: serverCAPEM}, true},
+		{"caBundleAndClientCert", &AdminAPITLSConfig{CABundle: serverCAPEM, ClientCert: clientCertPEM, ClientKey: clientKeyPEM}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			client, err := PortaClient(&ProviderAccount{AdminURLStr: srv.URL, Token: "sometoken", TLS: tc.tlsConfig})
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			_, err = client.ListProducts()
+			if tc.expectedErr && err == nil {
+				subT.Error("expected error")
+			}
+			if !tc.expectedErr && err != nil {
+				subT.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func testSelfSignedCert(t *testing.T) ([]byte, []byte, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "3scale-operator"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, cert
+}
@@ -14,6 +14,7 @@ type ApplicationPlanEntity struct {
 	obj          threescaleapi.ApplicationPlanItem
 	limits       *threescaleapi.ApplicationPlanLimitList
 	pricingRules *threescaleapi.ApplicationPlanPricingRuleList
+	drift        *DriftRecorder
 	logger       logr.Logger
 }
 
@@ -26,6 +27,12 @@ func NewApplicationPlanEntity(productID int64, obj threescaleapi.ApplicationPlan
 	}
 }
 
+// SetDriftRecorder enables observe mode.
+// Mutating operations are recorded as drift and not sent to 3scale.
+func (b *ApplicationPlanEntity) SetDriftRecorder(drift *DriftRecorder) {
+	b.drift = drift
+}
+
 func (b *ApplicationPlanEntity) ID() int64 {
 	return b.obj.ID
 }
@@ -52,6 +59,10 @@ func (b *ApplicationPlanEntity) CostPerMonth() float64 {
 
 func (b *ApplicationPlanEntity) Update(params threescaleapi.Params) error {
 	b.logger.V(1).Info("Update", "params", params)
+	if b.drift != nil {
+		b.drift.Record("application plan [%s] update %s", b.obj.SystemName, FormatDriftParams(params))
+		return nil
+	}
 	updated, err := b.client.UpdateApplicationPlan(b.productID, b.obj.ID, params)
 	if err != nil {
 		return fmt.Errorf("product [%d] plan [%s] update: %w", b.productID, b.obj.SystemName, err)
@@ -85,6 +96,10 @@ func (b *ApplicationPlanEntity) getLimits() (*threescaleapi.ApplicationPlanLimit
 
 func (b *ApplicationPlanEntity) DeleteLimit(metricID, id int64) error {
 	b.logger.V(1).Info("DeleteLimit", "metricID", metricID, "ID", id)
+	if b.drift != nil {
+		b.drift.Record("application plan [%s] delete limit [%d] of metric [%d]", b.obj.SystemName, id, metricID)
+		return nil
+	}
 	err := b.client.DeleteApplicationPlanLimit(b.obj.ID, metricID, id)
 	if err != nil {
 		return fmt.Errorf("application plan [%s] delete limit: %w", b.obj.SystemName, err)
@@ -95,6 +110,10 @@ func (b *ApplicationPlanEntity) DeleteLimit(metricID, id int64) error {
 
 func (b *ApplicationPlanEntity) CreateLimit(metricID int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateLimit", "metricID", metricID, "params", params)
+	if b.drift != nil {
+		b.drift.Record("application plan [%s] create limit of metric [%d] %s", b.obj.SystemName, metricID, FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.CreateApplicationPlanLimit(b.obj.ID, metricID, params)
 	if err != nil {
 		return fmt.Errorf("application plan [%s] create limit: %w", b.obj.SystemName, err)
@@ -130,6 +149,10 @@ func (b *ApplicationPlanEntity) getPricingRules() (*threescaleapi.ApplicationPla
 
 func (b *ApplicationPlanEntity) DeletePricingRule(metricID, id int64) error {
 	b.logger.V(1).Info("DeletePricingRule", "metricID", metricID, "ID", id)
+	if b.drift != nil {
+		b.drift.Record("application plan [%s] delete pricing rule [%d] of metric [%d]", b.obj.SystemName, id, metricID)
+		return nil
+	}
 	err := b.client.DeleteApplicationPlanPricingRule(b.obj.ID, metricID, id)
 	if err != nil {
 		return fmt.Errorf("application plan [%s] delete pricing rule: %w", b.obj.SystemName, err)
@@ -140,6 +163,10 @@ func (b *ApplicationPlanEntity) DeletePricingRule(metricID, id int64) error {
 
 func (b *ApplicationPlanEntity) CreatePricingRule(metricID int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreatePricingRule", "metricID", metricID, "params", params)
+	if b.drift != nil {
+		b.drift.Record("application plan [%s] create pricing rule of metric [%d] %s", b.obj.SystemName, metricID, FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.CreateApplicationPlanPricingRule(b.obj.ID, metricID, params)
 	if err != nil {
 		return fmt.Errorf("application plan [%s] create pricing rule: %w", b.obj.SystemName, err)
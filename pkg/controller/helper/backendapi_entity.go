@@ -2,6 +2,7 @@ package helper
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -17,7 +18,10 @@ type BackendAPIEntity struct {
 	metricsAndMethods *threescaleapi.MetricJSONList
 	methods           *threescaleapi.MethodList
 	mappingRules      *threescaleapi.MappingRuleJSONList
-	logger            logr.Logger
+	drift             *DriftRecorder
+	// system names of the methods and metrics that would be created in observe mode
+	pendingMetrics map[string]bool
+	logger         logr.Logger
 }
 
 func NewBackendAPIEntity(backendAPIObj *threescaleapi.BackendApi, client *threescaleapi.ThreeScaleClient, logger logr.Logger) *BackendAPIEntity {
@@ -28,6 +32,12 @@ func NewBackendAPIEntity(backendAPIObj *threescaleapi.BackendApi, client *threes
 	}
 }
 
+// SetDriftRecorder enables observe mode.
+// Mutating operations are recorded as drift and not sent to 3scale.
+func (b *BackendAPIEntity) SetDriftRecorder(drift *DriftRecorder) {
+	b.drift = drift
+}
+
 func (b *BackendAPIEntity) ID() int64 {
 	return b.backendAPIObj.Element.ID
 }
@@ -50,6 +60,10 @@ func (b *BackendAPIEntity) PrivateEndpoint() string {
 
 func (b *BackendAPIEntity) Update(params threescaleapi.Params) error {
 	b.logger.V(1).Info("Update", "params", params)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] update %s", b.backendAPIObj.Element.SystemName, FormatDriftParams(params))
+		return nil
+	}
 	updatedBackendAPI, err := b.client.UpdateBackendApi(b.backendAPIObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("backend [%s] update request: %w", b.backendAPIObj.Element.SystemName, err)
@@ -60,6 +74,33 @@ func (b *BackendAPIEntity) Update(params threescaleapi.Params) error {
 	return nil
 }
 
+// ProductUsages returns the list of products using this backend
+func (b *BackendAPIEntity) ProductUsages() ([]threescaleapi.ProductItem, error) {
+	b.logger.V(1).Info("ProductUsages")
+	productList, err := b.client.ListProducts()
+	if err != nil {
+		return nil, fmt.Errorf("backend [%s] list products request: %w", b.backendAPIObj.Element.SystemName, err)
+	}
+
+	result := make([]threescaleapi.ProductItem, 0)
+	for _, product := range productList.Products {
+		backendUsageList, err := b.client.ListBackendapiUsages(product.Element.ID)
+		if err != nil {
+			return nil, fmt.Errorf("backend [%s] list backend usages for product [%s] request: %w",
+				b.backendAPIObj.Element.SystemName, product.Element.SystemName, err)
+		}
+
+		for _, backendUsage := range backendUsageList {
+			if backendUsage.Element.BackendAPIID == b.backendAPIObj.Element.ID {
+				result = append(result, product.Element)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
 func (b *BackendAPIEntity) Methods() (*threescaleapi.MethodList, error) {
 	b.logger.V(1).Info("Methods")
 	if b.methods == nil {
@@ -74,6 +115,11 @@ func (b *BackendAPIEntity) Methods() (*threescaleapi.MethodList, error) {
 
 func (b *BackendAPIEntity) CreateMethod(params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateMethod", "params", params)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] create method %s", b.backendAPIObj.Element.SystemName, FormatDriftParams(params))
+		b.addPendingMetric(params)
+		return nil
+	}
 	hitsID, err := b.getHitsID()
 	if err != nil {
 		return err
@@ -88,6 +134,10 @@ func (b *BackendAPIEntity) CreateMethod(params threescaleapi.Params) error {
 
 func (b *BackendAPIEntity) DeleteMethod(id int64) error {
 	b.logger.V(1).Info("DeleteMethod", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] delete method [%s]", b.backendAPIObj.Element.SystemName, b.methodMetricSystemName(id))
+		return nil
+	}
 	hitsID, err := b.getHitsID()
 	if err != nil {
 		return err
@@ -102,6 +152,10 @@ func (b *BackendAPIEntity) DeleteMethod(id int64) error {
 
 func (b *BackendAPIEntity) UpdateMethod(id int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateMethod", "ID", id, "params", params)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] update method [%s] %s", b.backendAPIObj.Element.SystemName, b.methodMetricSystemName(id), FormatDriftParams(params))
+		return nil
+	}
 	hitsID, err := b.getHitsID()
 	if err != nil {
 		return err
@@ -116,6 +170,11 @@ func (b *BackendAPIEntity) UpdateMethod(id int64, params threescaleapi.Params) e
 
 func (b *BackendAPIEntity) CreateMetric(params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateMetric", "params", params)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] create metric %s", b.backendAPIObj.Element.SystemName, FormatDriftParams(params))
+		b.addPendingMetric(params)
+		return nil
+	}
 	_, err := b.client.CreateBackendApiMetric(b.backendAPIObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("backend [%s] create metric: %w", b.backendAPIObj.Element.SystemName, err)
@@ -126,6 +185,10 @@ func (b *BackendAPIEntity) CreateMetric(params threescaleapi.Params) error {
 
 func (b *BackendAPIEntity) DeleteMetric(id int64) error {
 	b.logger.V(1).Info("DeleteMetric", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] delete metric [%s]", b.backendAPIObj.Element.SystemName, b.methodMetricSystemName(id))
+		return nil
+	}
 	err := b.client.DeleteBackendApiMetric(b.backendAPIObj.Element.ID, id)
 	if err != nil {
 		return fmt.Errorf("backend [%s] delete metric: %w", b.backendAPIObj.Element.SystemName, err)
@@ -136,6 +199,10 @@ func (b *BackendAPIEntity) DeleteMetric(id int64) error {
 
 func (b *BackendAPIEntity) UpdateMetric(id int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateMethod", "ID", id, "params", params)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] update metric [%s] %s", b.backendAPIObj.Element.SystemName, b.methodMetricSystemName(id), FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.UpdateBackendApiMetric(b.backendAPIObj.Element.ID, id, params)
 	if err != nil {
 		return fmt.Errorf("backend [%s] update metric: %w", b.backendAPIObj.Element.SystemName, err)
@@ -191,6 +258,10 @@ func (b *BackendAPIEntity) MappingRules() (*threescaleapi.MappingRuleJSONList, e
 
 func (b *BackendAPIEntity) DeleteMappingRule(id int64) error {
 	b.logger.V(1).Info("DeleteMappingRule", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] delete mapping rule [%s]", b.backendAPIObj.Element.SystemName, b.mappingRuleKey(id))
+		return nil
+	}
 	err := b.client.DeleteBackendapiMappingRule(b.backendAPIObj.Element.ID, id)
 	if err != nil {
 		return fmt.Errorf("backend [%s] delete mapping rule: %w", b.backendAPIObj.Element.SystemName, err)
@@ -201,6 +272,10 @@ func (b *BackendAPIEntity) DeleteMappingRule(id int64) error {
 
 func (b *BackendAPIEntity) CreateMappingRule(params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateMappingRule", "params", params)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] create mapping rule %s", b.backendAPIObj.Element.SystemName, FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.CreateBackendapiMappingRule(b.backendAPIObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("backend [%s] create mappingrule: %w", b.backendAPIObj.Element.SystemName, err)
@@ -211,6 +286,10 @@ func (b *BackendAPIEntity) CreateMappingRule(params threescaleapi.Params) error
 
 func (b *BackendAPIEntity) UpdateMappingRule(id int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateMappingRule", "ID", id, "params", params)
+	if b.drift != nil {
+		b.drift.Record("backend [%s] update mapping rule [%s] %s", b.backendAPIObj.Element.SystemName, b.mappingRuleKey(id), FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.UpdateBackendapiMappingRule(b.backendAPIObj.Element.ID, id, params)
 	if err != nil {
 		return fmt.Errorf("backend [%s] update mappingrule: %w", b.backendAPIObj.Element.SystemName, err)
@@ -220,7 +299,8 @@ func (b *BackendAPIEntity) UpdateMappingRule(id int64, params threescaleapi.Para
 }
 
 // FindMethodMetricIDBySystemName returns metric or method ID from system name.
-// -1 if metric and method is not found
+// -1 if metric and method is not found.
+// 0 if metric or method is only pending to be created in observe mode
 func (b *BackendAPIEntity) FindMethodMetricIDBySystemName(systemName string) (int64, error) {
 	metricsMethodList, err := b.MetricsAndMethods()
 	if err != nil {
@@ -233,6 +313,11 @@ func (b *BackendAPIEntity) FindMethodMetricIDBySystemName(systemName string) (in
 		}
 	}
 
+	if b.pendingMetrics[systemName] {
+		// observe mode: the method or metric would have been created
+		return 0, nil
+	}
+
 	return -1, nil
 }
 
@@ -241,6 +326,37 @@ func (b *BackendAPIEntity) FindMethodMetricIDBySystemName(systemName string) (in
 //
 //
 
+func (b *BackendAPIEntity) addPendingMetric(params threescaleapi.Params) {
+	if b.pendingMetrics == nil {
+		b.pendingMetrics = map[string]bool{}
+	}
+	b.pendingMetrics[params["system_name"]] = true
+}
+
+func (b *BackendAPIEntity) methodMetricSystemName(id int64) string {
+	list, err := b.MetricsAndMethods()
+	if err == nil {
+		for _, metric := range list.Metrics {
+			if metric.Element.ID == id {
+				return metric.Element.SystemName
+			}
+		}
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+func (b *BackendAPIEntity) mappingRuleKey(id int64) string {
+	list, err := b.MappingRules()
+	if err == nil {
+		for _, item := range list.MappingRules {
+			if item.Element.ID == id {
+				return fmt.Sprintf("%s:%s", item.Element.HTTPMethod, item.Element.Pattern)
+			}
+		}
+	}
+	return strconv.FormatInt(id, 10)
+}
+
 func (b *BackendAPIEntity) resetMethods() {
 	b.metricsAndMethods = nil
 	b.methods = nil
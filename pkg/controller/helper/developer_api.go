@@ -0,0 +1,211 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	developerAccountSignupEndpoint   = "/admin/api/signup.json"
+	developerAccountResourceEndpoint = "/admin/api/accounts/%d.json"
+	developerAccountApproveEndpoint  = "/admin/api/accounts/%d/approve.json"
+	applicationCreateEndpoint        = "/admin/api/accounts/%d/applications.json"
+	applicationListEndpoint          = "/admin/api/accounts/%d/applications.json"
+	applicationResourceEndpoint      = "/admin/api/accounts/%d/applications/%d.json"
+	applicationChangePlanEndpoint    = "/admin/api/accounts/%d/applications/%d/change_plan.json"
+	applicationKeysEndpoint          = "/admin/api/accounts/%d/applications/%d/keys.json"
+
+	// developer accounts signed up when account approval is required are pending
+	developerAccountPendingState = "pending"
+)
+
+// DeveloperAccountItem holds the 3scale developer account attributes
+type DeveloperAccountItem struct {
+	ID        int64  `json:"id"`
+	State     string `json:"state"`
+	OrgName   string `json:"org_name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type developerAccount struct {
+	Element DeveloperAccountItem `json:"account"`
+}
+
+// ApplicationItem holds the 3scale application attributes.
+// Depending on the product authentication mode, either UserKey or ApplicationID is set.
+type ApplicationItem struct {
+	ID            int64  `json:"id"`
+	State         string `json:"state"`
+	ServiceID     int64  `json:"service_id"`
+	PlanID        int64  `json:"plan_id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	UserKey       string `json:"user_key"`
+	ApplicationID string `json:"application_id"`
+}
+
+type application struct {
+	Element ApplicationItem `json:"application"`
+}
+
+type applicationList struct {
+	Applications []application `json:"applications"`
+}
+
+type applicationKey struct {
+	Element struct {
+		Value string `json:"value"`
+	} `json:"key"`
+}
+
+type applicationKeyList struct {
+	Keys []applicationKey `json:"keys"`
+}
+
+// DeveloperAPIClient implements the 3scale admin API endpoints for developer accounts and applications
+// not implemented by the porta client
+type DeveloperAPIClient struct {
+	*adminAPIClient
+}
+
+// NewDeveloperAPIClient instantiates DeveloperAPIClient from ProviderAccount object
+func NewDeveloperAPIClient(providerAccount *ProviderAccount) (*DeveloperAPIClient, error) {
+	client, err := newAdminAPIClient(providerAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeveloperAPIClient{client}, nil
+}
+
+// Signup creates a developer account together with its admin user.
+// Accounts pending of approval are approved.
+func (c *DeveloperAPIClient) Signup(params threescaleapi.Params) (*DeveloperAccountItem, error) {
+	obj := &developerAccount{}
+	err := c.doForm(http.MethodPost, developerAccountSignupEndpoint, params, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj.Element.State == developerAccountPendingState {
+		err = c.doForm(http.MethodPut, fmt.Sprintf(developerAccountApproveEndpoint, obj.Element.ID), threescaleapi.Params{}, obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &obj.Element, nil
+}
+
+// DeveloperAccount reads the developer account
+func (c *DeveloperAPIClient) DeveloperAccount(accountID int64) (*DeveloperAccountItem, error) {
+	obj := &developerAccount{}
+	err := c.do(http.MethodGet, fmt.Sprintf(developerAccountResourceEndpoint, accountID), nil, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// UpdateDeveloperAccount updates the developer account
+func (c *DeveloperAPIClient) UpdateDeveloperAccount(accountID int64, params threescaleapi.Params) (*DeveloperAccountItem, error) {
+	obj := &developerAccount{}
+	err := c.doForm(http.MethodPut, fmt.Sprintf(developerAccountResourceEndpoint, accountID), params, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// DeleteDeveloperAccount deletes the developer account and all its applications
+func (c *DeveloperAPIClient) DeleteDeveloperAccount(accountID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(developerAccountResourceEndpoint, accountID), nil, nil)
+}
+
+// CreateApplication creates an application for the developer account
+func (c *DeveloperAPIClient) CreateApplication(accountID int64, params threescaleapi.Params) (*ApplicationItem, error) {
+	obj := &application{}
+	err := c.doForm(http.MethodPost, fmt.Sprintf(applicationCreateEndpoint, accountID), params, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// ListApplications reads the applications of the developer account
+func (c *DeveloperAPIClient) ListApplications(accountID int64) ([]ApplicationItem, error) {
+	obj := &applicationList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(applicationListEndpoint, accountID), nil, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]ApplicationItem, 0, len(obj.Applications))
+	for _, app := range obj.Applications {
+		items = append(items, app.Element)
+	}
+
+	return items, nil
+}
+
+// Application reads the application of the developer account
+func (c *DeveloperAPIClient) Application(accountID, applicationID int64) (*ApplicationItem, error) {
+	obj := &application{}
+	err := c.do(http.MethodGet, fmt.Sprintf(applicationResourceEndpoint, accountID, applicationID), nil, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// UpdateApplication updates the application of the developer account
+func (c *DeveloperAPIClient) UpdateApplication(accountID, applicationID int64, params threescaleapi.Params) (*ApplicationItem, error) {
+	obj := &application{}
+	err := c.doForm(http.MethodPut, fmt.Sprintf(applicationResourceEndpoint, accountID, applicationID), params, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// ChangeApplicationPlan changes the application plan of the application
+func (c *DeveloperAPIClient) ChangeApplicationPlan(accountID, applicationID, planID int64) (*ApplicationItem, error) {
+	obj := &application{}
+	params := threescaleapi.Params{"plan_id": fmt.Sprintf("%d", planID)}
+	err := c.doForm(http.MethodPut, fmt.Sprintf(applicationChangePlanEndpoint, accountID, applicationID), params, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// DeleteApplication deletes the application of the developer account
+func (c *DeveloperAPIClient) DeleteApplication(accountID, applicationID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf(applicationResourceEndpoint, accountID, applicationID), nil, nil)
+}
+
+// ApplicationKeys reads the application keys of the application.
+// Only applicable to applications of products with app_id/app_key authentication mode
+func (c *DeveloperAPIClient) ApplicationKeys(accountID, applicationID int64) ([]string, error) {
+	obj := &applicationKeyList{}
+	err := c.do(http.MethodGet, fmt.Sprintf(applicationKeysEndpoint, accountID, applicationID), nil, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(obj.Keys))
+	for _, key := range obj.Keys {
+		keys = append(keys, key.Element.Value)
+	}
+
+	return keys, nil
+}
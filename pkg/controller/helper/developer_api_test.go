@@ -0,0 +1,142 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func TestDeveloperAPIClientSignup(t *testing.T) {
+	var accountID int64 = 3
+	approved := false
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, token, ok := r.BasicAuth(); !ok || token != "sometoken" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == developerAccountSignupEndpoint:
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"account":{"id":%d,"state":"pending","org_name":"%s"}}`, accountID, r.PostForm.Get("org_name"))
+		case r.Method == http.MethodPut && r.URL.Path == fmt.Sprintf(developerAccountApproveEndpoint, accountID):
+			approved = true
+			fmt.Fprintf(w, `{"account":{"id":%d,"state":"approved","org_name":"org"}}`, accountID)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewDeveloperAPIClient(&ProviderAccount{AdminURLStr: srv.URL, Token: "sometoken"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := client.Signup(threescaleapi.Params{"org_name": "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !approved {
+		t.Error("pending account was not approved")
+	}
+
+	if account.ID != accountID || account.State != "approved" || account.OrgName != "org" {
+		t.Errorf("unexpected account: %+v", account)
+	}
+
+	_, err = client.DeveloperAccount(accountID + 1)
+	if !IsAdminAPINotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestDeveloperAPIClientApplication(t *testing.T) {
+	var accountID int64 = 3
+	var applicationID int64 = 7
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == fmt.Sprintf(applicationCreateEndpoint, accountID):
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"application":{"id":%d,"state":"live","plan_id":%s,"name":"%s","application_id":"abc"}}`,
+				applicationID, r.PostForm.Get("plan_id"), r.PostForm.Get("name"))
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(applicationListEndpoint, accountID):
+			fmt.Fprintf(w, `{"applications":[{"application":{"id":%d,"service_id":5,"plan_id":11,"name":"app"}}]}`, applicationID)
+		case r.Method == http.MethodPut && r.URL.Path == fmt.Sprintf(applicationChangePlanEndpoint, accountID, applicationID):
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"application":{"id":%d,"state":"live","plan_id":%s,"name":"app","application_id":"abc"}}`,
+				applicationID, r.PostForm.Get("plan_id"))
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(applicationKeysEndpoint, accountID, applicationID):
+			fmt.Fprint(w, `{"keys":[{"key":{"value":"key1"}},{"key":{"value":"key2"}}]}`)
+		case r.Method == http.MethodDelete && r.URL.Path == fmt.Sprintf(applicationResourceEndpoint, accountID, applicationID):
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewDeveloperAPIClient(&ProviderAccount{AdminURLStr: srv.URL, Token: "sometoken"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app, err := client.CreateApplication(accountID, threescaleapi.Params{"plan_id": "11", "name": "app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.ID != applicationID || app.PlanID != 11 || app.Name != "app" || app.ApplicationID != "abc" {
+		t.Errorf("unexpected application: %+v", app)
+	}
+
+	apps, err := client.ListApplications(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].ID != applicationID || apps[0].ServiceID != 5 || apps[0].Name != "app" {
+		t.Errorf("unexpected applications: %+v", apps)
+	}
+
+	app, err = client.ChangeApplicationPlan(accountID, applicationID, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.PlanID != 12 {
+		t.Errorf("unexpected application plan: %d", app.PlanID)
+	}
+
+	keys, err := client.ApplicationKeys(accountID, applicationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != "key1" {
+		t.Errorf("unexpected application keys: %v", keys)
+	}
+
+	err = client.DeleteApplication(accountID, applicationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Application(accountID, applicationID+1)
+	if !IsAdminAPINotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
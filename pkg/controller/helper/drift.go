@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// driftRedactedParams are 3scale API params whose values must not be exposed
+// in status conditions or events
+var driftRedactedParams = map[string]bool{
+	"secret_token":         true,
+	"oidc_issuer_endpoint": true,
+}
+
+// DriftRecorder collects the changes that would be applied to 3scale
+// when a custom resource is reconciled in observe mode.
+// Entities with a drift recorder set do not send any mutating request to 3scale.
+type DriftRecorder struct {
+	changes []string
+}
+
+func NewDriftRecorder() *DriftRecorder {
+	return &DriftRecorder{}
+}
+
+// Record registers one change
+func (d *DriftRecorder) Record(format string, args ...interface{}) {
+	d.changes = append(d.changes, fmt.Sprintf(format, args...))
+}
+
+// Changes returns the recorded changes in order
+func (d *DriftRecorder) Changes() []string {
+	return d.changes
+}
+
+// HasDrift returns true when some change has been recorded.
+// Safe to call on a nil recorder
+func (d *DriftRecorder) HasDrift() bool {
+	return d != nil && len(d.changes) > 0
+}
+
+// Summary returns all the recorded changes in one line
+func (d *DriftRecorder) Summary() string {
+	return strings.Join(d.changes, "; ")
+}
+
+// FormatDriftParams returns a stable representation of API params.
+// Sensitive values are redacted.
+func FormatDriftParams(params threescaleapi.Params) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := params[key]
+		if driftRedactedParams[key] {
+			value = "<redacted>"
+		}
+		fields = append(fields, fmt.Sprintf("%s=%q", key, value))
+	}
+
+	return "{" + strings.Join(fields, ", ") + "}"
+}
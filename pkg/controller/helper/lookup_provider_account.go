@@ -6,12 +6,15 @@ import (
 	"fmt"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,6 +29,50 @@ var (
 	providerAccountSecretTokenFieldName = "token"
 )
 
+// watchNamespace is the namespace watched by the operator, empty when watching all namespaces
+var watchNamespace string
+
+// SetWatchNamespace sets the namespace watched by the operator, empty when watching all namespaces.
+// Meant to be called on startup
+func SetWatchNamespace(ns string) {
+	watchNamespace = ns
+}
+
+// ErrProviderAccountNotFound is returned when none of the provider account sources is available
+var ErrProviderAccountNotFound = errors.New("no provider account found")
+
+// ProviderAccountReferenceNotFoundError is returned when the provider account referenced by the custom resource,
+// either the providerAccountRef secret or the providerAccountCRRef custom resource, does not exist
+type ProviderAccountReferenceNotFoundError struct {
+	Err error
+}
+
+func (e *ProviderAccountReferenceNotFoundError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProviderAccountReferenceNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// IsProviderAccountNotFound returns true when the provider account lookup failed
+// because no provider account is available, or the referenced one does not exist.
+// Other missing objects, i.e. the credentials secret of a ProviderAccount custom resource, are not included
+func IsProviderAccountNotFound(err error) bool {
+	if errors.Is(err, ErrProviderAccountNotFound) {
+		return true
+	}
+
+	referenceNotFoundErr := &ProviderAccountReferenceNotFoundError{}
+	return errors.As(err, &referenceNotFoundErr)
+}
+
+// isNotFoundError returns true when some error in the chain is a kubernetes not found error
+func isNotFoundError(err error) bool {
+	var statusErr apierrors.APIStatus
+	return errors.As(err, &statusErr) && statusErr.Status().Reason == metav1.StatusReasonNotFound
+}
+
 type providerAccountSource func(cl client.Client, ns string, providerAccountRef *corev1.LocalObjectReference, logger logr.Logger) (*ProviderAccount, error)
 
 // LookupProviderAccount looks up for account provider url and credentials
@@ -35,7 +82,16 @@ type providerAccountSource func(cl client.Client, ns string, providerAccountRef
 // 3scale default provider account (3scale-admin) will be looked up using system-seed secret in the current namespace.
 // If nothing is successfully found, return error
 func LookupProviderAccount(cl client.Client, ns string, providerAccountRef *corev1.LocalObjectReference, logger logr.Logger) (*ProviderAccount, error) {
+	return LookupProviderAccountWithCRRef(cl, ns, nil, providerAccountRef, logger)
+}
+
+// LookupProviderAccountWithCRRef extends the LookupProviderAccount chain with the ProviderAccount custom resource.
+// If provider_account_cr_reference is provided, the custom resource must exist, may be in another namespace,
+// and must allow the namespace ns in the allowed namespaces list.
+// Otherwise, the LookupProviderAccount chain is followed.
+func LookupProviderAccountWithCRRef(cl client.Client, ns string, providerAccountCRRef *capabilitiesv1beta1.ProviderAccountCRReference, providerAccountRef *corev1.LocalObjectReference, logger logr.Logger) (*ProviderAccount, error) {
 	orderedSources := []providerAccountSource{
+		providerAccountFromCustomResourceSource(providerAccountCRRef),
 		providerAccountFromSecretReferenceSource,
 		providerAccountFromDefaultSecretSource,
 		providerAccountFromLocal3scaleSource,
@@ -54,28 +110,91 @@ func LookupProviderAccount(cl client.Client, ns string, providerAccountRef *core
 	}
 
 	// not found, return error
-	return nil, errors.New("LookupProviderAccount: no provider account found")
+	return nil, fmt.Errorf("LookupProviderAccount: %w", ErrProviderAccountNotFound)
+}
+
+// providerAccountFromCustomResourceSource returns the source reading the referenced ProviderAccount custom resource
+func providerAccountFromCustomResourceSource(providerAccountCRRef *capabilitiesv1beta1.ProviderAccountCRReference) providerAccountSource {
+	return func(cl client.Client, ns string, _ *corev1.LocalObjectReference, logger logr.Logger) (*ProviderAccount, error) {
+		if providerAccountCRRef == nil {
+			return nil, nil
+		}
+
+		providerAccountCRKey := types.NamespacedName{Name: providerAccountCRRef.Name, Namespace: providerAccountCRRef.Namespace}
+		if providerAccountCRKey.Namespace == "" {
+			providerAccountCRKey.Namespace = ns
+		}
+		logger.Info("LookupProviderAccount", "ns", ns, "providerAccountCRRef", providerAccountCRKey)
+
+		// Objects of namespaces not watched are never found in the cache
+		if watchNamespace != "" && providerAccountCRKey.Namespace != watchNamespace {
+			return nil, fmt.Errorf("providerAccountFromCustomResourceSource: provider account '%s' is in a namespace not watched by the operator. "+
+				"Cross namespace references require the operator installed in all namespaces", providerAccountCRKey)
+		}
+
+		providerAccountCR := &capabilitiesv1beta1.ProviderAccount{}
+		err := cl.Get(context.TODO(), providerAccountCRKey, providerAccountCR)
+		if err != nil {
+			if isNotFoundError(err) {
+				err = &ProviderAccountReferenceNotFoundError{Err: err}
+			}
+			return nil, fmt.Errorf("providerAccountFromCustomResourceSource: %w", err)
+		}
+
+		if !providerAccountCR.IsNamespaceAllowed(ns) {
+			return nil, fmt.Errorf("providerAccountFromCustomResourceSource: namespace '%s' is not allowed to use provider account '%s'", ns, providerAccountCRKey)
+		}
+
+		providerAccount, err := ProviderAccountFromSecret(cl, providerAccountCR.Namespace, providerAccountCR.Spec.CredentialsRef.Name)
+		if err != nil {
+			return nil, fmt.Errorf("providerAccountFromCustomResourceSource: %w", err)
+		}
+
+		return providerAccount, nil
+	}
 }
 
 func providerAccountFromSecretReferenceSource(cl client.Client, ns string, providerAccountRef *corev1.LocalObjectReference, logger logr.Logger) (*ProviderAccount, error) {
 	if providerAccountRef != nil {
 		logger.Info("LookupProviderAccount", "ns", ns, "providerAccountRef", providerAccountRef)
-		secretSource := helper.NewSecretSource(cl, ns)
-		adminURLStr, err := secretSource.RequiredFieldValueFromRequiredSecret(providerAccountRef.Name, providerAccountSecretURLFieldName)
-		if err != nil {
-			return nil, fmt.Errorf("providerAccountFromSecretReferenceSource: %w", err)
-		}
-		token, err := secretSource.RequiredFieldValueFromRequiredSecret(providerAccountRef.Name, providerAccountSecretTokenFieldName)
+		providerAccount, err := ProviderAccountFromSecret(cl, ns, providerAccountRef.Name)
 		if err != nil {
+			if isNotFoundError(err) {
+				err = &ProviderAccountReferenceNotFoundError{Err: err}
+			}
 			return nil, fmt.Errorf("providerAccountFromSecretReferenceSource: %w", err)
 		}
 
-		return &ProviderAccount{AdminURLStr: adminURLStr, Token: token}, nil
+		return providerAccount, nil
 	}
 
 	return nil, nil
 }
 
+// ProviderAccountFromSecret reads the provider account credentials from the secret.
+// adminURL and token fields are required. TLS settings are optional
+func ProviderAccountFromSecret(cl client.Client, ns, secretName string) (*ProviderAccount, error) {
+	secretSource := helper.NewSecretSource(cl, ns)
+	adminURLStr, err := secretSource.RequiredFieldValueFromRequiredSecret(secretName, providerAccountSecretURLFieldName)
+	if err != nil {
+		return nil, err
+	}
+	token, err := secretSource.RequiredFieldValueFromRequiredSecret(secretName, providerAccountSecretTokenFieldName)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := secretSource.CachedSecret(secretName)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := AdminAPITLSConfigFromSecretData(secret.Name, secret.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProviderAccount{AdminURLStr: adminURLStr, Token: token, TLS: tlsConfig}, nil
+}
+
 func providerAccountFromDefaultSecretSource(cl client.Client, ns string, providerAccountRef *corev1.LocalObjectReference, logger logr.Logger) (*ProviderAccount, error) {
 	// if exists, fiels are required.
 	defaulSecret, err := helper.GetSecret(providerAccountDefaultSecretName, ns, cl)
@@ -89,7 +208,12 @@ func providerAccountFromDefaultSecretSource(cl client.Client, ns string, provide
 			return nil, fmt.Errorf("providerAccountFromDefaultSecretSource: Secret field '%s' is required in secret '%s'", providerAccountSecretTokenFieldName, defaulSecret.Name)
 		}
 
-		return &ProviderAccount{AdminURLStr: *adminURLStr, Token: *token}, nil
+		tlsConfig, err := AdminAPITLSConfigFromSecretData(defaulSecret.Name, defaulSecret.Data)
+		if err != nil {
+			return nil, fmt.Errorf("providerAccountFromDefaultSecretSource: %w", err)
+		}
+
+		return &ProviderAccount{AdminURLStr: *adminURLStr, Token: *token, TLS: tlsConfig}, nil
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return nil, fmt.Errorf("providerAccountFromDefaultSecretSource: %w", err)
 	}
@@ -0,0 +1,116 @@
+package helper
+
+import (
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestLookupProviderAccountWithCRRef(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := scheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mytenant", Namespace: "threescale"},
+		Data: map[string][]byte{
+			"adminURL": []byte("https://tenant-admin.example.com"),
+			"token":    []byte("sometoken"),
+		},
+	}
+	providerAccountCR := &capabilitiesv1beta1.ProviderAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "threescale"},
+		Spec: capabilitiesv1beta1.ProviderAccountSpec{
+			CredentialsRef:    corev1.LocalObjectReference{Name: "mytenant"},
+			AllowedNamespaces: []string{"team-a"},
+		},
+	}
+	providerAccountCRWithoutCredentials := &capabilitiesv1beta1.ProviderAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "nocredentials", Namespace: "threescale"},
+		Spec: capabilitiesv1beta1.ProviderAccountSpec{
+			CredentialsRef:    corev1.LocalObjectReference{Name: "unknown"},
+			AllowedNamespaces: []string{"team-a"},
+		},
+	}
+	localCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "team-a"},
+		Data: map[string][]byte{
+			"adminURL": []byte("https://local-admin.example.com"),
+			"token":    []byte("othertoken"),
+		},
+	}
+
+	cl := fake.NewFakeClientWithScheme(s, credentials, providerAccountCR, providerAccountCRWithoutCredentials, localCredentials)
+
+	cases := []struct {
+		name             string
+		ns               string
+		crRef            *capabilitiesv1beta1.ProviderAccountCRReference
+		ref              *corev1.LocalObjectReference
+		expectedURL      string
+		expectedError    bool
+		expectedNotFound bool
+	}{
+		{"allowedNamespace", "team-a", &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant", Namespace: "threescale"}, nil, "https://tenant-admin.example.com", false, false},
+		{"sameNamespace", "threescale", &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant"}, nil, "https://tenant-admin.example.com", false, false},
+		{"notAllowedNamespace", "team-b", &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant", Namespace: "threescale"}, nil, "", true, false},
+		{"notFound", "team-a", &capabilitiesv1beta1.ProviderAccountCRReference{Name: "unknown", Namespace: "threescale"}, nil, "", true, true},
+		// Only the referenced objects missing count as provider account not found
+		{"credentialsNotFound", "team-a", &capabilitiesv1beta1.ProviderAccountCRReference{Name: "nocredentials", Namespace: "threescale"}, nil, "", true, false},
+		{"secretNotFound", "team-a", nil, &corev1.LocalObjectReference{Name: "unknown"}, "", true, true},
+		{"secretReference", "team-a", nil, &corev1.LocalObjectReference{Name: "local"}, "https://local-admin.example.com", false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			providerAccount, err := LookupProviderAccountWithCRRef(cl, tc.ns, tc.crRef, tc.ref, logf.Log)
+			if tc.expectedError {
+				if err == nil {
+					subT.Fatal("expected error")
+				}
+				if IsProviderAccountNotFound(err) != tc.expectedNotFound {
+					subT.Errorf("expected provider account not found %t, got error %v", tc.expectedNotFound, err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if providerAccount.AdminURLStr != tc.expectedURL {
+				subT.Errorf("unexpected admin URL: %s", providerAccount.AdminURLStr)
+			}
+		})
+	}
+}
+
+func TestLookupProviderAccountWithCRRefNotWatchedNamespace(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewFakeClientWithScheme(s)
+
+	SetWatchNamespace("team-a")
+	defer SetWatchNamespace("")
+
+	crRef := &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant", Namespace: "threescale"}
+	_, err := LookupProviderAccountWithCRRef(cl, "team-a", crRef, nil, logf.Log)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	// Not reported as not found, the provider account might exist
+	if IsProviderAccountNotFound(err) {
+		t.Errorf("expected error other than not found, got %v", err)
+	}
+}
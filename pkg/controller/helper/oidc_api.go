@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	productProxyOIDCResourceEndpoint         = "/admin/api/services/%d/proxy.json"
+	productOIDCConfigurationResourceEndpoint = "/admin/api/services/%d/proxy/oidc_configuration.json"
+)
+
+// ProxyOIDCItem holds the OpenID Connect settings of the product proxy.
+// Those settings are not available in the porta client proxy object.
+type ProxyOIDCItem struct {
+	OidcIssuerEndpoint       string `json:"oidc_issuer_endpoint"`
+	OidcIssuerType           string `json:"oidc_issuer_type"`
+	JwtClaimWithClientID     string `json:"jwt_claim_with_client_id"`
+	JwtClaimWithClientIDType string `json:"jwt_claim_with_client_id_type"`
+}
+
+type proxyOIDC struct {
+	Element ProxyOIDCItem `json:"proxy"`
+}
+
+// OIDCConfigurationItem holds the OAuth2.0 authorization flows enabled for the product
+type OIDCConfigurationItem struct {
+	ID                        int64 `json:"id"`
+	StandardFlowEnabled       bool  `json:"standard_flow_enabled"`
+	ImplicitFlowEnabled       bool  `json:"implicit_flow_enabled"`
+	ServiceAccountsEnabled    bool  `json:"service_accounts_enabled"`
+	DirectAccessGrantsEnabled bool  `json:"direct_access_grants_enabled"`
+}
+
+type oidcConfiguration struct {
+	Element OIDCConfigurationItem `json:"oidc_configuration"`
+}
+
+// OIDCAPIClient implements the 3scale admin API endpoints for the product's OpenID Connect settings
+// not implemented by the porta client
+type OIDCAPIClient struct {
+	*adminAPIClient
+}
+
+// NewOIDCAPIClient instantiates OIDCAPIClient from ProviderAccount object
+func NewOIDCAPIClient(providerAccount *ProviderAccount) (*OIDCAPIClient, error) {
+	client, err := newAdminAPIClient(providerAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCAPIClient{client}, nil
+}
+
+// ProxyOIDC reads the OpenID Connect settings of the product proxy
+func (c *OIDCAPIClient) ProxyOIDC(productID int64) (*ProxyOIDCItem, error) {
+	obj := &proxyOIDC{}
+	err := c.do(http.MethodGet, fmt.Sprintf(productProxyOIDCResourceEndpoint, productID), nil, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// OIDCConfiguration reads the OAuth2.0 authorization flows of the product
+func (c *OIDCAPIClient) OIDCConfiguration(productID int64) (*OIDCConfigurationItem, error) {
+	obj := &oidcConfiguration{}
+	err := c.do(http.MethodGet, fmt.Sprintf(productOIDCConfigurationResourceEndpoint, productID), nil, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
+
+// UpdateOIDCConfiguration updates the OAuth2.0 authorization flows of the product
+func (c *OIDCAPIClient) UpdateOIDCConfiguration(productID int64, params threescaleapi.Params) (*OIDCConfigurationItem, error) {
+	obj := &oidcConfiguration{}
+	err := c.doForm(http.MethodPatch, fmt.Sprintf(productOIDCConfigurationResourceEndpoint, productID), params, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &obj.Element, nil
+}
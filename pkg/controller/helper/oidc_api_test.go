@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+func TestOIDCAPIClient(t *testing.T) {
+	var productID int64 = 5
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, token, ok := r.BasicAuth(); !ok || token != "sometoken" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(productProxyOIDCResourceEndpoint, productID):
+			fmt.Fprint(w, `{"proxy":{"oidc_issuer_endpoint":"https://a:b@sso.example.com","oidc_issuer_type":"keycloak","jwt_claim_with_client_id":"azp","jwt_claim_with_client_id_type":"plain"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(productOIDCConfigurationResourceEndpoint, productID):
+			fmt.Fprint(w, `{"oidc_configuration":{"id":1,"standard_flow_enabled":true}}`)
+		case r.Method == http.MethodPatch && r.URL.Path == fmt.Sprintf(productOIDCConfigurationResourceEndpoint, productID):
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"oidc_configuration":{"id":1,"standard_flow_enabled":true,"implicit_flow_enabled":%s}}`, r.PostForm.Get("implicit_flow_enabled"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewOIDCAPIClient(&ProviderAccount{AdminURLStr: srv.URL, Token: "sometoken"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyOIDC, err := client.ProxyOIDC(productID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxyOIDC.OidcIssuerType != "keycloak" || proxyOIDC.JwtClaimWithClientID != "azp" {
+		t.Errorf("unexpected proxy oidc settings: %+v", proxyOIDC)
+	}
+
+	oidcConf, err := client.OIDCConfiguration(productID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !oidcConf.StandardFlowEnabled || oidcConf.ImplicitFlowEnabled {
+		t.Errorf("unexpected oidc configuration: %+v", oidcConf)
+	}
+
+	oidcConf, err = client.UpdateOIDCConfiguration(productID, threescaleapi.Params{"implicit_flow_enabled": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !oidcConf.ImplicitFlowEnabled {
+		t.Errorf("unexpected updated oidc configuration: %+v", oidcConf)
+	}
+
+	_, err = client.ProxyOIDC(productID + 1)
+	if err == nil {
+		t.Error("expected error reading unknown product")
+	}
+}
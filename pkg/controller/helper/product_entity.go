@@ -3,6 +3,7 @@ package helper
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -11,6 +12,14 @@ import (
 	"github.com/go-logr/logr"
 )
 
+const (
+	// ProxyConfigStagingEnvironment is the 3scale API name of the staging environment
+	ProxyConfigStagingEnvironment = "sandbox"
+
+	// ProxyConfigProductionEnvironment is the 3scale API name of the production environment
+	ProxyConfigProductionEnvironment = "production"
+)
+
 type ProductEntity struct {
 	client            *threescaleapi.ThreeScaleClient
 	productObj        *threescaleapi.Product
@@ -22,7 +31,10 @@ type ProductEntity struct {
 	proxy             *threescaleapi.ProxyJSON
 	plans             *threescaleapi.ApplicationPlanJSONList
 	policies          *threescaleapi.PoliciesConfigList
-	logger            logr.Logger
+	drift             *DriftRecorder
+	// system names of the methods and metrics that would be created in observe mode
+	pendingMetrics map[string]bool
+	logger         logr.Logger
 }
 
 func NewProductEntity(obj *threescaleapi.Product, cl *threescaleapi.ThreeScaleClient, logger logr.Logger) *ProductEntity {
@@ -33,6 +45,12 @@ func NewProductEntity(obj *threescaleapi.Product, cl *threescaleapi.ThreeScaleCl
 	}
 }
 
+// SetDriftRecorder enables observe mode.
+// Mutating operations are recorded as drift and not sent to 3scale.
+func (b *ProductEntity) SetDriftRecorder(drift *DriftRecorder) {
+	b.drift = drift
+}
+
 func (b *ProductEntity) ID() int64 {
 	return b.productObj.Element.ID
 }
@@ -54,11 +72,15 @@ func (b *ProductEntity) DeploymentOption() string {
 }
 
 func (b *ProductEntity) BackendVersion() string {
-	return b.productObj.Element.DeploymentOption
+	return b.productObj.Element.BackendVersion
 }
 
 func (b *ProductEntity) Update(params threescaleapi.Params) error {
 	b.logger.V(1).Info("Update", "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] update %s", b.productObj.Element.SystemName, FormatDriftParams(params))
+		return nil
+	}
 	updated, err := b.client.UpdateProduct(b.productObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] update request: %w", b.productObj.Element.SystemName, err)
@@ -83,6 +105,11 @@ func (b *ProductEntity) Methods() (*threescaleapi.MethodList, error) {
 
 func (b *ProductEntity) CreateMethod(params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateMethod", "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] create method %s", b.productObj.Element.SystemName, FormatDriftParams(params))
+		b.addPendingMetric(params)
+		return nil
+	}
 	hitsID, err := b.getHitsID()
 	if err != nil {
 		return err
@@ -97,6 +124,10 @@ func (b *ProductEntity) CreateMethod(params threescaleapi.Params) error {
 
 func (b *ProductEntity) DeleteMethod(id int64) error {
 	b.logger.V(1).Info("DeleteMethod", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("product [%s] delete method [%s]", b.productObj.Element.SystemName, b.methodMetricSystemName(id))
+		return nil
+	}
 	hitsID, err := b.getHitsID()
 	if err != nil {
 		return err
@@ -111,6 +142,10 @@ func (b *ProductEntity) DeleteMethod(id int64) error {
 
 func (b *ProductEntity) UpdateMethod(id int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateMethod", "ID", id, "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] update method [%s] %s", b.productObj.Element.SystemName, b.methodMetricSystemName(id), FormatDriftParams(params))
+		return nil
+	}
 	hitsID, err := b.getHitsID()
 	if err != nil {
 		return err
@@ -125,6 +160,11 @@ func (b *ProductEntity) UpdateMethod(id int64, params threescaleapi.Params) erro
 
 func (b *ProductEntity) CreateMetric(params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateMetric", "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] create metric %s", b.productObj.Element.SystemName, FormatDriftParams(params))
+		b.addPendingMetric(params)
+		return nil
+	}
 	_, err := b.client.CreateProductMetric(b.productObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] create metric: %w", b.productObj.Element.SystemName, err)
@@ -135,6 +175,10 @@ func (b *ProductEntity) CreateMetric(params threescaleapi.Params) error {
 
 func (b *ProductEntity) DeleteMetric(id int64) error {
 	b.logger.V(1).Info("DeleteMetric", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("product [%s] delete metric [%s]", b.productObj.Element.SystemName, b.methodMetricSystemName(id))
+		return nil
+	}
 	err := b.client.DeleteProductMetric(b.productObj.Element.ID, id)
 	if err != nil {
 		return fmt.Errorf("product [%s] delete metric: %w", b.productObj.Element.SystemName, err)
@@ -145,6 +189,10 @@ func (b *ProductEntity) DeleteMetric(id int64) error {
 
 func (b *ProductEntity) UpdateMetric(id int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateMethod", "ID", id, "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] update metric [%s] %s", b.productObj.Element.SystemName, b.methodMetricSystemName(id), FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.UpdateProductMetric(b.productObj.Element.ID, id, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] update metric: %w", b.productObj.Element.SystemName, err)
@@ -197,6 +245,10 @@ func (b *ProductEntity) MappingRules() (*threescaleapi.MappingRuleJSONList, erro
 
 func (b *ProductEntity) DeleteMappingRule(id int64) error {
 	b.logger.V(1).Info("DeleteMappingRule", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("product [%s] delete mapping rule [%s]", b.productObj.Element.SystemName, b.mappingRuleKey(id))
+		return nil
+	}
 	err := b.client.DeleteProductMappingRule(b.productObj.Element.ID, id)
 	if err != nil {
 		return fmt.Errorf("product [%s] delete mapping rule: %w", b.productObj.Element.SystemName, err)
@@ -207,6 +259,10 @@ func (b *ProductEntity) DeleteMappingRule(id int64) error {
 
 func (b *ProductEntity) CreateMappingRule(params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateMappingRule", "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] create mapping rule %s", b.productObj.Element.SystemName, FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.CreateProductMappingRule(b.productObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] create mappingrule: %w", b.productObj.Element.SystemName, err)
@@ -217,6 +273,10 @@ func (b *ProductEntity) CreateMappingRule(params threescaleapi.Params) error {
 
 func (b *ProductEntity) UpdateMappingRule(id int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateMappingRule", "ID", id, "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] update mapping rule [%s] %s", b.productObj.Element.SystemName, b.mappingRuleKey(id), FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.UpdateProductMappingRule(b.productObj.Element.ID, id, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] update mappingrule: %w", b.productObj.Element.SystemName, err)
@@ -226,7 +286,8 @@ func (b *ProductEntity) UpdateMappingRule(id int64, params threescaleapi.Params)
 }
 
 // FindMethodMetricIDBySystemName returns metric or method ID from system name.
-// -1 if metric and method is not found
+// -1 if metric and method is not found.
+// 0 if metric or method is only pending to be created in observe mode
 func (b *ProductEntity) FindMethodMetricIDBySystemName(systemName string) (int64, error) {
 	metricsMethodList, err := b.MetricsAndMethods()
 	if err != nil {
@@ -239,6 +300,11 @@ func (b *ProductEntity) FindMethodMetricIDBySystemName(systemName string) (int64
 		}
 	}
 
+	if b.pendingMetrics[systemName] {
+		// observe mode: the method or metric would have been created
+		return 0, nil
+	}
+
 	return -1, nil
 }
 
@@ -256,6 +322,10 @@ func (b *ProductEntity) BackendUsages() (threescaleapi.BackendAPIUsageList, erro
 
 func (b *ProductEntity) DeleteBackendUsage(id int64) error {
 	b.logger.V(1).Info("DeleteBackendUsage", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("product [%s] delete backend usage [%d]", b.productObj.Element.SystemName, id)
+		return nil
+	}
 	err := b.client.DeleteBackendapiUsage(b.productObj.Element.ID, id)
 	if err != nil {
 		return fmt.Errorf("product [%s] delete backendusage: %w", b.productObj.Element.SystemName, err)
@@ -266,6 +336,10 @@ func (b *ProductEntity) DeleteBackendUsage(id int64) error {
 
 func (b *ProductEntity) UpdateBackendUsage(id int64, params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateBackendUsage", "ID", id, "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] update backend usage [%d] %s", b.productObj.Element.SystemName, id, FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.UpdateBackendapiUsage(b.productObj.Element.ID, id, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] update backendusage: %w", b.productObj.Element.SystemName, err)
@@ -276,6 +350,10 @@ func (b *ProductEntity) UpdateBackendUsage(id int64, params threescaleapi.Params
 
 func (b *ProductEntity) CreateBackendUsage(params threescaleapi.Params) error {
 	b.logger.V(1).Info("CreateBackendUsage", "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] create backend usage %s", b.productObj.Element.SystemName, FormatDriftParams(params))
+		return nil
+	}
 	_, err := b.client.CreateBackendapiUsage(b.productObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] update backendusage: %w", b.productObj.Element.SystemName, err)
@@ -298,6 +376,10 @@ func (b *ProductEntity) Proxy() (*threescaleapi.ProxyJSON, error) {
 
 func (b *ProductEntity) UpdateProxy(params threescaleapi.Params) error {
 	b.logger.V(1).Info("UpdateProxy", "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] update proxy %s", b.productObj.Element.SystemName, FormatDriftParams(params))
+		return nil
+	}
 	updated, err := b.client.UpdateProductProxy(b.productObj.Element.ID, params)
 	if err != nil {
 		return fmt.Errorf("product [%s] update proxy: %w", b.productObj.Element.SystemName, err)
@@ -321,6 +403,10 @@ func (b *ProductEntity) ApplicationPlans() (*threescaleapi.ApplicationPlanJSONLi
 
 func (b *ProductEntity) DeleteApplicationPlan(id int64) error {
 	b.logger.V(1).Info("DeleteApplicationPlan", "ID", id)
+	if b.drift != nil {
+		b.drift.Record("product [%s] delete application plan [%s]", b.productObj.Element.SystemName, b.applicationPlanSystemName(id))
+		return nil
+	}
 	err := b.client.DeleteApplicationPlan(b.productObj.Element.ID, id)
 	if err != nil {
 		return fmt.Errorf("product [%s] delete applicationPlan: %w", b.productObj.Element.SystemName, err)
@@ -331,6 +417,10 @@ func (b *ProductEntity) DeleteApplicationPlan(id int64) error {
 
 func (b *ProductEntity) CreateApplicationPlan(params threescaleapi.Params) (*threescaleapi.ApplicationPlan, error) {
 	b.logger.V(1).Info("CreateApplicationPlan", "params", params)
+	if b.drift != nil {
+		b.drift.Record("product [%s] create application plan %s", b.productObj.Element.SystemName, FormatDriftParams(params))
+		return nil, nil
+	}
 	obj, err := b.client.CreateApplicationPlan(b.productObj.Element.ID, params)
 	if err != nil {
 		return nil, fmt.Errorf("product [%s] create plan: %w", b.productObj.Element.SystemName, err)
@@ -341,6 +431,10 @@ func (b *ProductEntity) CreateApplicationPlan(params threescaleapi.Params) (*thr
 
 func (b *ProductEntity) PromoteProxyToStaging() error {
 	b.logger.V(1).Info("PromoteProxyToStaging")
+	if b.drift != nil {
+		b.drift.Record("product [%s] promote proxy to staging", b.productObj.Element.SystemName)
+		return nil
+	}
 	proxyObj, err := b.client.DeployProductProxy(b.productObj.Element.ID)
 	if err != nil {
 		return fmt.Errorf("product [%s] promote proxy to staging: %w", b.productObj.Element.SystemName, err)
@@ -350,6 +444,43 @@ func (b *ProductEntity) PromoteProxyToStaging() error {
 	return nil
 }
 
+// LatestProxyConfigVersion returns the version of the latest proxy configuration of the environment.
+// Returns nil when the environment does not have any proxy configuration
+func (b *ProductEntity) LatestProxyConfigVersion(env string) (*int64, error) {
+	b.logger.V(1).Info("LatestProxyConfigVersion", "env", env)
+	obj, err := b.client.GetLatestProxyConfig(strconv.FormatInt(b.productObj.Element.ID, 10), env)
+	if err != nil {
+		if threescaleapi.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("product [%s] get latest %s proxy config: %w", b.productObj.Element.SystemName, env, err)
+	}
+
+	version := int64(obj.ProxyConfig.Version)
+	return &version, nil
+}
+
+// PromoteProxyToProduction promotes the staging proxy configuration version to production.
+// Returns the version of the new production proxy configuration
+func (b *ProductEntity) PromoteProxyToProduction(stagingVersion int64) (int64, error) {
+	b.logger.V(1).Info("PromoteProxyToProduction", "stagingVersion", stagingVersion)
+	if b.drift != nil {
+		b.drift.Record("product [%s] promote staging proxy config version %d to production", b.productObj.Element.SystemName, stagingVersion)
+		return 0, nil
+	}
+	obj, err := b.client.PromoteProxyConfig(
+		strconv.FormatInt(b.productObj.Element.ID, 10),
+		ProxyConfigStagingEnvironment,
+		strconv.FormatInt(stagingVersion, 10),
+		ProxyConfigProductionEnvironment,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("product [%s] promote proxy to production: %w", b.productObj.Element.SystemName, err)
+	}
+
+	return int64(obj.ProxyConfig.Version), nil
+}
+
 func (b *ProductEntity) Policies() (*threescaleapi.PoliciesConfigList, error) {
 	b.logger.V(1).Info("Policies")
 	if b.policies == nil {
@@ -365,6 +496,15 @@ func (b *ProductEntity) Policies() (*threescaleapi.PoliciesConfigList, error) {
 func (b *ProductEntity) UpdatePolicies(policies *threescaleapi.PoliciesConfigList) error {
 	policiesJSON, _ := json.Marshal(policies)
 	b.logger.V(1).Info("UpdatePolicies", "policies", string(policiesJSON))
+	if b.drift != nil {
+		// policy configurations may hold credentials, only names are recorded
+		names := make([]string, 0, len(policies.Policies))
+		for _, policy := range policies.Policies {
+			names = append(names, policy.Name)
+		}
+		b.drift.Record("product [%s] update policy chain [%s]", b.productObj.Element.SystemName, strings.Join(names, ", "))
+		return nil
+	}
 	_, err := b.client.UpdatePolicies(b.productObj.Element.ID, policies)
 	if err != nil {
 		return fmt.Errorf("product [%s] update policies: %w", b.productObj.Element.SystemName, err)
@@ -377,6 +517,49 @@ func (b *ProductEntity) UpdatePolicies(policies *threescaleapi.PoliciesConfigLis
 //
 //
 
+func (b *ProductEntity) addPendingMetric(params threescaleapi.Params) {
+	if b.pendingMetrics == nil {
+		b.pendingMetrics = map[string]bool{}
+	}
+	b.pendingMetrics[params["system_name"]] = true
+}
+
+func (b *ProductEntity) methodMetricSystemName(id int64) string {
+	list, err := b.MetricsAndMethods()
+	if err == nil {
+		for _, metric := range list.Metrics {
+			if metric.Element.ID == id {
+				return metric.Element.SystemName
+			}
+		}
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+func (b *ProductEntity) mappingRuleKey(id int64) string {
+	list, err := b.MappingRules()
+	if err == nil {
+		for _, item := range list.MappingRules {
+			if item.Element.ID == id {
+				return fmt.Sprintf("%s:%s", item.Element.HTTPMethod, item.Element.Pattern)
+			}
+		}
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+func (b *ProductEntity) applicationPlanSystemName(id int64) string {
+	list, err := b.ApplicationPlans()
+	if err == nil {
+		for _, item := range list.Plans {
+			if item.Element.ID == id {
+				return item.Element.SystemName
+			}
+		}
+	}
+	return strconv.FormatInt(id, 10)
+}
+
 func (b *ProductEntity) resetBackendUsages() {
 	b.backendUsages = nil
 }
@@ -0,0 +1,170 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestProductEntityPromoteProxyToProduction(t *testing.T) {
+	var productID int64 = 5
+	promoted := false
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/admin/api/services/%d/proxy/configs/%s/latest.json", productID, ProxyConfigStagingEnvironment):
+			fmt.Fprint(w, `{"proxy_config":{"id":10,"version":4,"environment":"sandbox"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == fmt.Sprintf("/admin/api/services/%d/proxy/configs/%s/3/promote.json", productID, ProxyConfigStagingEnvironment):
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("to") != ProxyConfigProductionEnvironment {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			promoted = true
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"proxy_config":{"id":11,"version":2,"environment":"production"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"Not found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := PortaClientFromURLString(srv.URL, "sometoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entity := NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: productID}}, client, logf.Log)
+
+	stagingVersion, err := entity.LatestProxyConfigVersion(ProxyConfigStagingEnvironment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stagingVersion == nil || *stagingVersion != 4 {
+		t.Errorf("unexpected staging version: %v", stagingVersion)
+	}
+
+	productionVersion, err := entity.LatestProxyConfigVersion(ProxyConfigProductionEnvironment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if productionVersion != nil {
+		t.Errorf("expected no production version, got: %d", *productionVersion)
+	}
+
+	newProductionVersion, err := entity.PromoteProxyToProduction(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !promoted {
+		t.Error("staging version was not promoted")
+	}
+	if newProductionVersion != 2 {
+		t.Errorf("unexpected production version: %d", newProductionVersion)
+	}
+}
+
+func TestProductEntityObserveMode(t *testing.T) {
+	var productID int64 = 5
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/admin/api/services/%d/metrics.json", productID):
+			fmt.Fprint(w, `{"metrics":[{"metric":{"id":1,"system_name":"hits","friendly_name":"Hits"}}]}`)
+		case r.Method != http.MethodGet:
+			t.Errorf("unexpected mutating request in observe mode: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"Not found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := PortaClientFromURLString(srv.URL, "sometoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entity := NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{ID: productID, SystemName: "myproduct"}}, client, logf.Log)
+	drift := NewDriftRecorder()
+	entity.SetDriftRecorder(drift)
+
+	if err := entity.CreateMethod(threescaleapi.Params{"system_name": "method01", "friendly_name": "Method 01"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.DeleteMetric(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.UpdateProxy(threescaleapi.Params{"secret_token": "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	plan, err := entity.CreateApplicationPlan(threescaleapi.Params{"system_name": "plan01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan != nil {
+		t.Errorf("expected no plan in observe mode, got: %v", plan)
+	}
+
+	expectedChanges := []string{
+		`product [myproduct] create method {friendly_name="Method 01", system_name="method01"}`,
+		`product [myproduct] delete metric [hits]`,
+		`product [myproduct] update proxy {secret_token="<redacted>"}`,
+		`product [myproduct] create application plan {system_name="plan01"}`,
+	}
+	if !reflect.DeepEqual(drift.Changes(), expectedChanges) {
+		t.Errorf("unexpected drift: %v", drift.Changes())
+	}
+
+	// pending methods are resolved, so that dependent mapping rules and limits can be diffed
+	id, err := entity.FindMethodMetricIDBySystemName("method01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 0 {
+		t.Errorf("unexpected pending method ID: %d", id)
+	}
+
+	id, err = entity.FindMethodMetricIDBySystemName("unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != -1 {
+		t.Errorf("unexpected unknown method ID: %d", id)
+	}
+}
+
+func TestProductEntityBackendVersion(t *testing.T) {
+	// backend_version holds the authentication mode, unrelated to the deployment option
+	cases := []struct {
+		testName         string
+		deploymentOption string
+		backendVersion   string
+	}{
+		{"userKey", "hosted", "1"},
+		{"appKeyAppID", "hosted", "2"},
+		{"oidc", "self_managed", "oidc"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			entity := NewProductEntity(&threescaleapi.Product{Element: threescaleapi.ProductItem{
+				DeploymentOption: tc.deploymentOption,
+				BackendVersion:   tc.backendVersion,
+			}}, nil, logf.Log)
+
+			if entity.BackendVersion() != tc.backendVersion {
+				subT.Errorf("expected backend version %s, got %s", tc.backendVersion, entity.BackendVersion())
+			}
+			if entity.DeploymentOption() != tc.deploymentOption {
+				subT.Errorf("expected deployment option %s, got %s", tc.deploymentOption, entity.DeploymentOption())
+			}
+		})
+	}
+}
@@ -0,0 +1,477 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ImportedProduct holds the Product spec equivalent to an existing 3scale product
+type ImportedProduct struct {
+	Spec capabilitiesv1beta1.ProductSpec
+
+	// OIDCIssuerEndpoint is the issuer endpoint of products with OpenID Connect authentication.
+	// The spec reads it from a secret, so the issuer endpoint reference is left empty
+	OIDCIssuerEndpoint string
+}
+
+// ImportProduct reads the 3scale product and builds the equivalent Product spec.
+// Backends used by the product are looked up in the backend remote index.
+// The OpenID Connect API client is only used for products with OpenID Connect authentication
+func ImportProduct(productEntity *ProductEntity, backendRemoteIndex *BackendAPIRemoteIndex, oidcAPIClient *OIDCAPIClient) (*ImportedProduct, error) {
+	imported := &ImportedProduct{
+		Spec: capabilitiesv1beta1.ProductSpec{
+			Name:        productEntity.Name(),
+			SystemName:  productEntity.productObj.Element.SystemName,
+			Description: productEntity.Description(),
+		},
+	}
+
+	err := importProductDeployment(imported, productEntity, oidcAPIClient)
+	if err != nil {
+		return nil, err
+	}
+
+	imported.Spec.Metrics, err = importProductMetrics(productEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	imported.Spec.Methods, err = importProductMethods(productEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	metricRefs, err := productMetricMethodRefs(productEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	mappingRules, err := productEntity.MappingRules()
+	if err != nil {
+		return nil, err
+	}
+	imported.Spec.MappingRules, err = importMappingRules(mappingRules, metricRefs)
+	if err != nil {
+		return nil, fmt.Errorf("product [%s] import mapping rules: %w", imported.Spec.SystemName, err)
+	}
+
+	usedBackends, err := importProductBackendUsages(imported, productEntity, backendRemoteIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// limits and pricing rules may reference backend metrics and methods
+	for _, backendEntity := range usedBackends {
+		backendRefs, err := backendMetricMethodRefs(backendEntity)
+		if err != nil {
+			return nil, err
+		}
+		for id, ref := range backendRefs {
+			metricRefs[id] = ref
+		}
+	}
+
+	imported.Spec.ApplicationPlans, err = importApplicationPlans(productEntity, metricRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	imported.Spec.Policies, err = importPolicies(productEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return imported, nil
+}
+
+// ImportBackendSpec reads the 3scale backend and builds the equivalent Backend spec
+func ImportBackendSpec(backendEntity *BackendAPIEntity) (*capabilitiesv1beta1.BackendSpec, error) {
+	spec := &capabilitiesv1beta1.BackendSpec{
+		Name:           backendEntity.Name(),
+		SystemName:     backendEntity.SystemName(),
+		Description:    backendEntity.Description(),
+		PrivateBaseURL: backendEntity.PrivateEndpoint(),
+	}
+
+	metrics, err := backendEntity.Metrics()
+	if err != nil {
+		return nil, err
+	}
+	spec.Metrics = map[string]capabilitiesv1beta1.MetricSpec{}
+	for _, metric := range metrics.Metrics {
+		spec.Metrics[metric.Element.SystemName] = capabilitiesv1beta1.MetricSpec{
+			Name:        metric.Element.Name,
+			Unit:        metric.Element.Unit,
+			Description: metric.Element.Description,
+		}
+	}
+
+	methods, err := backendEntity.Methods()
+	if err != nil {
+		return nil, err
+	}
+	if len(methods.Methods) > 0 {
+		spec.Methods = map[string]capabilitiesv1beta1.MethodSpec{}
+	}
+	for _, method := range methods.Methods {
+		spec.Methods[method.Element.SystemName] = capabilitiesv1beta1.MethodSpec{
+			Name:        method.Element.Name,
+			Description: method.Element.Description,
+		}
+	}
+
+	metricRefs, err := backendMetricMethodRefs(backendEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	mappingRules, err := backendEntity.MappingRules()
+	if err != nil {
+		return nil, err
+	}
+	spec.MappingRules, err = importMappingRules(mappingRules, metricRefs)
+	if err != nil {
+		return nil, fmt.Errorf("backend [%s] import mapping rules: %w", spec.SystemName, err)
+	}
+
+	return spec, nil
+}
+
+func importProductDeployment(imported *ImportedProduct, productEntity *ProductEntity, oidcAPIClient *OIDCAPIClient) error {
+	proxy, err := productEntity.Proxy()
+	if err != nil {
+		return err
+	}
+
+	authentication := &capabilitiesv1beta1.AuthenticationSpec{}
+	switch productEntity.BackendVersion() {
+	case "1":
+		authentication.UserKeyAuthentication = &capabilitiesv1beta1.UserKeyAuthenticationSpec{
+			Key:             optionalString(proxy.Element.AuthUserKey),
+			CredentialsLoc:  optionalString(proxy.Element.CredentialsLocation),
+			Security:        importSecurity(proxy),
+			GatewayResponse: importGatewayResponse(proxy),
+		}
+	case "2":
+		authentication.AppKeyAppIDAuthentication = &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{
+			AppID:           optionalString(proxy.Element.AuthAppID),
+			AppKey:          optionalString(proxy.Element.AuthAppKey),
+			CredentialsLoc:  optionalString(proxy.Element.CredentialsLocation),
+			Security:        importSecurity(proxy),
+			GatewayResponse: importGatewayResponse(proxy),
+		}
+	case "oidc":
+		proxyOIDC, err := oidcAPIClient.ProxyOIDC(productEntity.ID())
+		if err != nil {
+			return err
+		}
+		oidcConfiguration, err := oidcAPIClient.OIDCConfiguration(productEntity.ID())
+		if err != nil {
+			return err
+		}
+		authentication.OIDC = &capabilitiesv1beta1.OIDCSpec{
+			IssuerType: proxyOIDC.OidcIssuerType,
+			AuthenticationFlow: &capabilitiesv1beta1.OIDCAuthenticationFlowSpec{
+				StandardFlowEnabled:       oidcConfiguration.StandardFlowEnabled,
+				ImplicitFlowEnabled:       oidcConfiguration.ImplicitFlowEnabled,
+				ServiceAccountsEnabled:    oidcConfiguration.ServiceAccountsEnabled,
+				DirectAccessGrantsEnabled: oidcConfiguration.DirectAccessGrantsEnabled,
+			},
+			JwtClaimWithClientID:     optionalString(proxyOIDC.JwtClaimWithClientID),
+			JwtClaimWithClientIDType: optionalString(proxyOIDC.JwtClaimWithClientIDType),
+			CredentialsLoc:           optionalString(proxy.Element.CredentialsLocation),
+			Security:                 importSecurity(proxy),
+			GatewayResponse:          importGatewayResponse(proxy),
+		}
+		imported.OIDCIssuerEndpoint = proxyOIDC.OidcIssuerEndpoint
+	default:
+		// Unknown authentication mode, 3scale defaults are kept
+		authentication = nil
+	}
+
+	switch productEntity.DeploymentOption() {
+	case "hosted":
+		imported.Spec.Deployment = &capabilitiesv1beta1.ProductDeploymentSpec{
+			ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{Authentication: authentication},
+		}
+	case "self_managed":
+		imported.Spec.Deployment = &capabilitiesv1beta1.ProductDeploymentSpec{
+			ApicastSelfManaged: &capabilitiesv1beta1.ApicastSelfManagedSpec{
+				Authentication:          authentication,
+				StagingPublicBaseURL:    optionalString(proxy.Element.SandboxEndpoint),
+				ProductionPublicBaseURL: optionalString(proxy.Element.Endpoint),
+			},
+		}
+	}
+	// Other deployment options are not supported by the Product spec
+
+	return nil
+}
+
+func importSecurity(proxy *threescaleapi.ProxyJSON) *capabilitiesv1beta1.SecuritySpec {
+	if proxy.Element.HostnameRewrite == "" && proxy.Element.SecretToken == "" {
+		return nil
+	}
+
+	return &capabilitiesv1beta1.SecuritySpec{
+		HostHeader:  optionalString(proxy.Element.HostnameRewrite),
+		SecretToken: optionalString(proxy.Element.SecretToken),
+	}
+}
+
+func importGatewayResponse(proxy *threescaleapi.ProxyJSON) *capabilitiesv1beta1.GatewayResponseSpec {
+	return &capabilitiesv1beta1.GatewayResponseSpec{
+		ErrorStatusAuthFailed:      optionalInt32(proxy.Element.ErrorStatusAuthFailed),
+		ErrorHeadersAuthFailed:     optionalString(proxy.Element.ErrorHeadersAuthFailed),
+		ErrorAuthFailed:            optionalString(proxy.Element.ErrorAuthFailed),
+		ErrorStatusAuthMissing:     optionalInt32(proxy.Element.ErrorStatusAuthMissing),
+		ErrorHeadersAuthMissing:    optionalString(proxy.Element.ErrorHeadersAuthMissing),
+		ErrorAuthMissing:           optionalString(proxy.Element.ErrorAuthMissing),
+		ErrorStatusNoMatch:         optionalInt32(proxy.Element.ErrorStatusNoMatch),
+		ErrorHeadersNoMatch:        optionalString(proxy.Element.ErrorHeadersNoMatch),
+		ErrorNoMatch:               optionalString(proxy.Element.ErrorNoMatch),
+		ErrorStatusLimitsExceeded:  optionalInt32(proxy.Element.ErrorStatusLimitsExceeded),
+		ErrorHeadersLimitsExceeded: optionalString(proxy.Element.ErrorHeadersLimitsExceeded),
+		ErrorLimitsExceeded:        optionalString(proxy.Element.ErrorLimitsExceeded),
+	}
+}
+
+func importProductMetrics(productEntity *ProductEntity) (map[string]capabilitiesv1beta1.MetricSpec, error) {
+	metrics, err := productEntity.Metrics()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]capabilitiesv1beta1.MetricSpec{}
+	for _, metric := range metrics.Metrics {
+		result[metric.Element.SystemName] = capabilitiesv1beta1.MetricSpec{
+			Name:        metric.Element.Name,
+			Unit:        metric.Element.Unit,
+			Description: metric.Element.Description,
+		}
+	}
+
+	return result, nil
+}
+
+func importProductMethods(productEntity *ProductEntity) (map[string]capabilitiesv1beta1.MethodSpec, error) {
+	methods, err := productEntity.Methods()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(methods.Methods) == 0 {
+		return nil, nil
+	}
+
+	result := map[string]capabilitiesv1beta1.MethodSpec{}
+	for _, method := range methods.Methods {
+		result[method.Element.SystemName] = capabilitiesv1beta1.MethodSpec{
+			Name:        method.Element.Name,
+			Description: method.Element.Description,
+		}
+	}
+
+	return result, nil
+}
+
+// importMappingRules returns the mapping rules sorted by position
+func importMappingRules(list *threescaleapi.MappingRuleJSONList, metricRefs map[int64]capabilitiesv1beta1.MetricMethodRefSpec) ([]capabilitiesv1beta1.MappingRuleSpec, error) {
+	items := make([]threescaleapi.MappingRuleItem, 0, len(list.MappingRules))
+	for _, item := range list.MappingRules {
+		items = append(items, item.Element)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+
+	var result []capabilitiesv1beta1.MappingRuleSpec
+	for _, item := range items {
+		ref, ok := metricRefs[item.MetricID]
+		if !ok {
+			return nil, fmt.Errorf("mapping rule [%s:%s] references unknown metric [%d]", item.HTTPMethod, item.Pattern, item.MetricID)
+		}
+
+		mappingRule := capabilitiesv1beta1.MappingRuleSpec{
+			HTTPMethod:      item.HTTPMethod,
+			Pattern:         item.Pattern,
+			MetricMethodRef: ref.SystemName,
+			Increment:       item.Delta,
+		}
+		if item.Last {
+			last := true
+			mappingRule.Last = &last
+		}
+		result = append(result, mappingRule)
+	}
+
+	return result, nil
+}
+
+// importProductBackendUsages returns the backends used by the product
+func importProductBackendUsages(imported *ImportedProduct, productEntity *ProductEntity, backendRemoteIndex *BackendAPIRemoteIndex) ([]*BackendAPIEntity, error) {
+	backendUsages, err := productEntity.BackendUsages()
+	if err != nil {
+		return nil, err
+	}
+
+	var usedBackends []*BackendAPIEntity
+	for _, backendUsage := range backendUsages {
+		backendEntity, ok := backendRemoteIndex.FindByID(backendUsage.Element.BackendAPIID)
+		if !ok {
+			return nil, fmt.Errorf("product [%s] uses unknown backend [%d]", imported.Spec.SystemName, backendUsage.Element.BackendAPIID)
+		}
+
+		if imported.Spec.BackendUsages == nil {
+			imported.Spec.BackendUsages = map[string]capabilitiesv1beta1.BackendUsageSpec{}
+		}
+		imported.Spec.BackendUsages[backendEntity.SystemName()] = capabilitiesv1beta1.BackendUsageSpec{
+			Path: backendUsage.Element.Path,
+		}
+		usedBackends = append(usedBackends, backendEntity)
+	}
+
+	return usedBackends, nil
+}
+
+func importApplicationPlans(productEntity *ProductEntity, metricRefs map[int64]capabilitiesv1beta1.MetricMethodRefSpec) (map[string]capabilitiesv1beta1.ApplicationPlanSpec, error) {
+	planList, err := productEntity.ApplicationPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(planList.Plans) == 0 {
+		return nil, nil
+	}
+
+	result := map[string]capabilitiesv1beta1.ApplicationPlanSpec{}
+	for _, plan := range planList.Plans {
+		planEntity := NewApplicationPlanEntity(productEntity.ID(), plan.Element, productEntity.client, productEntity.logger)
+
+		name := plan.Element.Name
+		approvalRequired := plan.Element.ApprovalRequired
+		trialPeriod := plan.Element.TrialPeriodDays
+		setupFee := strconv.FormatFloat(plan.Element.SetupFee, 'f', 2, 64)
+		costMonth := strconv.FormatFloat(plan.Element.CostPerMonth, 'f', 2, 64)
+		planSpec := capabilitiesv1beta1.ApplicationPlanSpec{
+			Name:                &name,
+			AppsRequireApproval: &approvalRequired,
+			TrialPeriod:         &trialPeriod,
+			SetupFee:            &setupFee,
+			CostMonth:           &costMonth,
+		}
+
+		limits, err := planEntity.Limits()
+		if err != nil {
+			return nil, err
+		}
+		for _, limit := range limits.Limits {
+			ref, ok := metricRefs[limit.Element.MetricID]
+			if !ok {
+				return nil, fmt.Errorf("plan [%s] limit references unknown metric [%d]", plan.Element.SystemName, limit.Element.MetricID)
+			}
+			planSpec.Limits = append(planSpec.Limits, capabilitiesv1beta1.LimitSpec{
+				Period:          limit.Element.Period,
+				Value:           limit.Element.Value,
+				MetricMethodRef: ref,
+			})
+		}
+
+		pricingRules, err := planEntity.PricingRules()
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range pricingRules.Rules {
+			ref, ok := metricRefs[rule.Element.MetricID]
+			if !ok {
+				return nil, fmt.Errorf("plan [%s] pricing rule references unknown metric [%d]", plan.Element.SystemName, rule.Element.MetricID)
+			}
+			planSpec.PricingRules = append(planSpec.PricingRules, capabilitiesv1beta1.PricingRuleSpec{
+				From:            rule.Element.Min,
+				To:              rule.Element.Max,
+				PricePerUnit:    rule.Element.CostPerUnit,
+				MetricMethodRef: ref,
+			})
+		}
+
+		result[plan.Element.SystemName] = planSpec
+	}
+
+	return result, nil
+}
+
+func importPolicies(productEntity *ProductEntity) ([]capabilitiesv1beta1.PolicyConfig, error) {
+	policies, err := productEntity.Policies()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []capabilitiesv1beta1.PolicyConfig
+	for _, policy := range policies.Policies {
+		configuration, err := json.Marshal(policy.Configuration)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, capabilitiesv1beta1.PolicyConfig{
+			Name:          policy.Name,
+			Version:       policy.Version,
+			Enabled:       policy.Enabled,
+			Configuration: runtime.RawExtension{Raw: configuration},
+		})
+	}
+
+	return result, nil
+}
+
+// productMetricMethodRefs indexes product metrics and methods by ID
+func productMetricMethodRefs(productEntity *ProductEntity) (map[int64]capabilitiesv1beta1.MetricMethodRefSpec, error) {
+	list, err := productEntity.MetricsAndMethods()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[int64]capabilitiesv1beta1.MetricMethodRefSpec{}
+	for _, metric := range list.Metrics {
+		result[metric.Element.ID] = capabilitiesv1beta1.MetricMethodRefSpec{SystemName: metric.Element.SystemName}
+	}
+
+	return result, nil
+}
+
+// backendMetricMethodRefs indexes backend metrics and methods by ID
+func backendMetricMethodRefs(backendEntity *BackendAPIEntity) (map[int64]capabilitiesv1beta1.MetricMethodRefSpec, error) {
+	list, err := backendEntity.MetricsAndMethods()
+	if err != nil {
+		return nil, err
+	}
+
+	backendSystemName := backendEntity.SystemName()
+	result := map[int64]capabilitiesv1beta1.MetricMethodRefSpec{}
+	for _, metric := range list.Metrics {
+		result[metric.Element.ID] = capabilitiesv1beta1.MetricMethodRefSpec{
+			SystemName:        metric.Element.SystemName,
+			BackendSystemName: &backendSystemName,
+		}
+	}
+
+	return result, nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func optionalInt32(value int) *int32 {
+	if value == 0 {
+		return nil
+	}
+	tmp := int32(value)
+	return &tmp
+}
@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestImportBackendSpec(t *testing.T) {
+	var backendID int64 = 3
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/admin/api/backend_apis/%d/metrics.json", backendID):
+			fmt.Fprint(w, `{"metrics":[`+
+				`{"metric":{"id":1,"system_name":"hits.3","friendly_name":"Hits","unit":"hit"}},`+
+				`{"metric":{"id":2,"system_name":"mymetric.3","friendly_name":"My Metric","unit":"call"}},`+
+				`{"metric":{"id":4,"system_name":"mymethod.3","friendly_name":"My Method"}}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/admin/api/backend_apis/%d/metrics/1/methods.json", backendID):
+			fmt.Fprint(w, `{"methods":[{"method":{"id":4,"system_name":"mymethod.3","friendly_name":"My Method"}}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/admin/api/backend_apis/%d/mapping_rules.json", backendID):
+			fmt.Fprint(w, `{"mapping_rules":[`+
+				`{"mapping_rule":{"id":11,"metric_id":4,"pattern":"/v1/method","http_method":"POST","delta":2,"position":2,"last":true}},`+
+				`{"mapping_rule":{"id":10,"metric_id":1,"pattern":"/","http_method":"GET","delta":1,"position":1}}]}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"Not found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := PortaClientFromURLString(srv.URL, "sometoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backendEntity := NewBackendAPIEntity(&threescaleapi.BackendApi{
+		Element: threescaleapi.BackendApiItem{
+			ID:              backendID,
+			Name:            "Backend 3",
+			SystemName:      "backend3",
+			PrivateEndpoint: "https://api.example.com",
+		},
+	}, client, logf.Log)
+
+	spec, err := ImportBackendSpec(backendEntity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.SystemName != "backend3" || spec.Name != "Backend 3" || spec.PrivateBaseURL != "https://api.example.com" {
+		t.Errorf("unexpected backend fields: %+v", spec)
+	}
+
+	expectedMetrics := map[string]capabilitiesv1beta1.MetricSpec{
+		"hits":     {Name: "Hits", Unit: "hit"},
+		"mymetric": {Name: "My Metric", Unit: "call"},
+	}
+	if !reflect.DeepEqual(spec.Metrics, expectedMetrics) {
+		t.Errorf("unexpected metrics: %+v", spec.Metrics)
+	}
+
+	expectedMethods := map[string]capabilitiesv1beta1.MethodSpec{
+		"mymethod": {Name: "My Method"},
+	}
+	if !reflect.DeepEqual(spec.Methods, expectedMethods) {
+		t.Errorf("unexpected methods: %+v", spec.Methods)
+	}
+
+	last := true
+	expectedMappingRules := []capabilitiesv1beta1.MappingRuleSpec{
+		{HTTPMethod: "GET", Pattern: "/", MetricMethodRef: "hits", Increment: 1},
+		{HTTPMethod: "POST", Pattern: "/v1/method", MetricMethodRef: "mymethod", Increment: 2, Last: &last},
+	}
+	if !reflect.DeepEqual(spec.MappingRules, expectedMappingRules) {
+		t.Errorf("unexpected mapping rules: %+v", spec.MappingRules)
+	}
+}
@@ -33,7 +33,7 @@ func ProductList(ns string, cl client.Client, providerAccount *ProviderAccount,
 			continue
 		}
 
-		productProviderAccount, err := LookupProviderAccount(cl, ns, productList.Items[idx].Spec.ProviderAccountRef, logger)
+		productProviderAccount, err := LookupProviderAccountWithCRRef(cl, ns, productList.Items[idx].Spec.ProviderAccountCRRef, productList.Items[idx].Spec.ProviderAccountRef, logger)
 		if err != nil {
 			return nil, fmt.Errorf("ProductList: %w", err)
 		}
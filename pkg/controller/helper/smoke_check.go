@@ -0,0 +1,104 @@
+package helper
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSmokeCheckPath is requested when the smoke check does not set a path
+	DefaultSmokeCheckPath = "/"
+
+	// DefaultSmokeCheckExpectedStatusCode is the status code expected when the smoke check does not set one
+	DefaultSmokeCheckExpectedStatusCode = http.StatusOK
+
+	// DefaultSmokeCheckTimeout is the request timeout when the smoke check does not set one
+	DefaultSmokeCheckTimeout = 10 * time.Second
+)
+
+// SmokeCheck is a GET request checking a gateway responds as expected
+type SmokeCheck struct {
+	// BaseURL of the gateway
+	BaseURL string
+	// Path appended to the base URL
+	Path string
+	// Headers sent in the request
+	Headers map[string]string
+	// ExpectedStatusCode for the check to pass
+	ExpectedStatusCode int
+	// Timeout of the request
+	Timeout time.Duration
+	// InsecureSkipVerify skips the verification of the server certificate
+	InsecureSkipVerify bool
+}
+
+// SmokeCheckResult is the outcome of a smoke check
+type SmokeCheckResult struct {
+	Passed     bool
+	StatusCode int
+	Message    string
+}
+
+// URL returns the smoke check request URL
+func (s *SmokeCheck) URL() string {
+	path := s.Path
+	if path == "" {
+		path = DefaultSmokeCheckPath
+	}
+	return strings.TrimSuffix(s.BaseURL, "/") + path
+}
+
+// Run sends the smoke check request.
+// The server certificate is verified unless InsecureSkipVerify is set.
+// Transport errors are reported as a failed check
+func (s *SmokeCheck) Run() *SmokeCheckResult {
+	expectedStatusCode := s.ExpectedStatusCode
+	if expectedStatusCode == 0 {
+		expectedStatusCode = DefaultSmokeCheckExpectedStatusCode
+	}
+
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultSmokeCheckTimeout
+	}
+
+	// Each check gets its own transport. Keep-alives are disabled so no
+	// idle connection is left open once the check finishes
+	httpClient := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: s.InsecureSkipVerify},
+			DisableKeepAlives: true,
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, s.URL(), nil)
+	if err != nil {
+		return &SmokeCheckResult{Message: err.Error()}
+	}
+
+	for name, value := range s.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return &SmokeCheckResult{Message: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	result := &SmokeCheckResult{
+		Passed:     resp.StatusCode == expectedStatusCode,
+		StatusCode: resp.StatusCode,
+	}
+
+	if !result.Passed {
+		result.Message = fmt.Sprintf("GET %s returned status code %d, expected %d", s.URL(), resp.StatusCode, expectedStatusCode)
+	}
+
+	return result
+}
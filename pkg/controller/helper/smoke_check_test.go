@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSmokeCheckRun(t *testing.T) {
+	// local stand-in for the staging gateway
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status/ready":
+			w.WriteHeader(http.StatusOK)
+		case "/pets":
+			if r.Header.Get("user_key") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer gateway.Close()
+
+	cases := []struct {
+		name               string
+		smokeCheck         SmokeCheck
+		expectedPassed     bool
+		expectedStatusCode int
+	}{
+		{"ready", SmokeCheck{BaseURL: gateway.URL, Path: "/status/ready"}, true, http.StatusOK},
+		{"baseURLTrailingSlash", SmokeCheck{BaseURL: gateway.URL + "/", Path: "/status/ready"}, true, http.StatusOK},
+		{"defaultPath", SmokeCheck{BaseURL: gateway.URL}, false, http.StatusNotFound},
+		{"expectedStatusCode", SmokeCheck{BaseURL: gateway.URL, ExpectedStatusCode: http.StatusNotFound}, true, http.StatusNotFound},
+		{"missingCredentials", SmokeCheck{BaseURL: gateway.URL, Path: "/pets"}, false, http.StatusForbidden},
+		{"credentials", SmokeCheck{BaseURL: gateway.URL, Path: "/pets", Headers: map[string]string{"user_key": "secret"}}, true, http.StatusOK},
+		{"timeout", SmokeCheck{BaseURL: gateway.URL, Path: "/slow", Timeout: 50 * time.Millisecond}, false, 0},
+		{"unreachable", SmokeCheck{BaseURL: "http://127.0.0.1:1"}, false, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			result := tc.smokeCheck.Run()
+			if result.Passed != tc.expectedPassed {
+				subT.Errorf("expected passed %t, got %t (%s)", tc.expectedPassed, result.Passed, result.Message)
+			}
+			if result.StatusCode != tc.expectedStatusCode {
+				subT.Errorf("expected status code %d, got %d", tc.expectedStatusCode, result.StatusCode)
+			}
+			if !result.Passed && result.Message == "" {
+				subT.Error("expected failure message")
+			}
+		})
+	}
+}
+
+func TestSmokeCheckRunTLS(t *testing.T) {
+	// staging gateway exposed with a self-signed certificate
+	gateway := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	result := (&SmokeCheck{BaseURL: gateway.URL}).Run()
+	if result.Passed {
+		t.Error("expected certificate verification to fail")
+	}
+
+	result = (&SmokeCheck{BaseURL: gateway.URL, InsecureSkipVerify: true}).Run()
+	if !result.Passed {
+		t.Errorf("expected check to pass skipping the certificate verification: %s", result.Message)
+	}
+}
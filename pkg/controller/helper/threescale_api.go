@@ -1,11 +1,13 @@
 package helper
 
 import (
-	"crypto/tls"
 	"net/http"
 	"net/url"
+	"sync/atomic"
+	"time"
 
 	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/metrics"
 
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 )
@@ -17,37 +19,131 @@ const (
 type ProviderAccount struct {
 	AdminURLStr string
 	Token       string
+	// TLS is optional. When nil, server certificate verification is skipped
+	TLS *AdminAPITLSConfig
+}
+
+// RemoteChangesCounter counts the successful requests changing 3scale
+type RemoteChangesCounter struct {
+	count int64
+}
+
+// Count returns the number of 3scale changes. Safe to call on a nil counter
+func (c *RemoteChangesCounter) Count() int64 {
+	if c == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&c.count)
+}
+
+// changesCountingTransport counts the successful requests not using safe methods
+type changesCountingTransport struct {
+	transport http.RoundTripper
+	counter   *RemoteChangesCounter
+}
+
+func (t *changesCountingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		if resp.StatusCode < http.StatusBadRequest {
+			atomic.AddInt64(&t.counter.count, 1)
+		}
+	}
+
+	return resp, nil
+}
+
+// metricsTransport records the latency and status code of the admin API requests
+type metricsTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+
+	code := 0
+	if err == nil {
+		code = resp.StatusCode
+	}
+	metrics.ObserveAdminAPIRequest(req.URL.Path, req.Method, code, time.Since(start))
+
+	return resp, err
 }
 
 // PortaClient instantiate porta_client.ThreeScaleClient from ProviderAccount object
 func PortaClient(providerAccount *ProviderAccount) (*threescaleapi.ThreeScaleClient, error) {
-	return PortaClientFromURLString(providerAccount.AdminURLStr, providerAccount.Token)
+	return PortaClientWithChangesCounter(providerAccount, nil)
 }
 
-func PortaClientFromURLString(adminURLStr, token string) (*threescaleapi.ThreeScaleClient, error) {
-	adminURL, err := url.Parse(adminURLStr)
+// PortaClientWithChangesCounter instantiates porta_client.ThreeScaleClient from ProviderAccount object.
+// The changes done in 3scale by the client are counted in the counter, when not nil
+func PortaClientWithChangesCounter(providerAccount *ProviderAccount, counter *RemoteChangesCounter) (*threescaleapi.ThreeScaleClient, error) {
+	adminURL, err := url.Parse(providerAccount.AdminURLStr)
 	if err != nil {
 		return nil, err
 	}
-	return PortaClientFromURL(adminURL, token)
+
+	httpClient, err := portaHTTPClient(adminURL, providerAccount.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	if counter != nil {
+		httpClient.Transport = &changesCountingTransport{transport: httpClient.Transport, counter: counter}
+	}
+
+	return portaClientFromURL(adminURL, providerAccount.Token, httpClient)
+}
+
+func PortaClientFromURLString(adminURLStr, token string) (*threescaleapi.ThreeScaleClient, error) {
+	return PortaClient(&ProviderAccount{AdminURLStr: adminURLStr, Token: token})
 }
 
 // PortaClientFromURL instantiates porta_client.ThreeScaleClient from admin url object
 func PortaClientFromURL(url *url.URL, token string) (*threescaleapi.ThreeScaleClient, error) {
+	httpClient, err := portaHTTPClient(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return portaClientFromURL(url, token, httpClient)
+}
+
+func portaClientFromURL(url *url.URL, token string, httpClient *http.Client) (*threescaleapi.ThreeScaleClient, error) {
 	adminPortal, err := threescaleapi.NewAdminPortal(url.Scheme, url.Hostname(), helper.PortFromURL(url))
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO By default should not skip verification
-	// Activated by some env var or Spec param
-	var transport http.RoundTripper = &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	return threescaleapi.NewThreeScale(adminPortal, token, httpClient), nil
+}
+
+// portaHTTPClient returns the http client used to reach the 3scale admin API.
+// Requests are guarded by the guard shared by all the clients of the admin URL host
+func portaHTTPClient(adminURL *url.URL, tlsConfig *AdminAPITLSConfig) (*http.Client, error) {
+	tlsClientConfig, err := tlsConfig.TLSClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = &metricsTransport{
+		transport: &http.Transport{
+			TLSClientConfig: tlsClientConfig,
+		},
 	}
 
 	if helper.GetEnvVar(HTTP_VERBOSE_ENVVAR, "0") == "1" {
 		transport = &helper.Transport{Transport: transport}
 	}
 
-	return threescaleapi.NewThreeScale(adminPortal, token, &http.Client{Transport: transport}), nil
+	transport = AdminAPIGuardFor(adminURL.Host).Transport(transport)
+
+	return &http.Client{Transport: transport}, nil
 }
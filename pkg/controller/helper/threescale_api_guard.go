@@ -0,0 +1,358 @@
+package helper
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// AdminAPIThrottlingReasonRateLimited means 3scale responded 429 Too Many Requests
+	AdminAPIThrottlingReasonRateLimited = "RateLimited"
+	// AdminAPIThrottlingReasonCircuitOpen means requests are rejected without reaching 3scale
+	// after too many consecutive failures
+	AdminAPIThrottlingReasonCircuitOpen = "CircuitOpen"
+)
+
+// AdminAPIGuardOptions configures the limits applied to the requests to the admin API of each provider account
+type AdminAPIGuardOptions struct {
+	// QPS and Burst configure the token bucket shared by all the requests to the provider account
+	QPS   float32
+	Burst int
+	// MaxRetries is the number of retries of the requests rate limited or failed with server errors
+	MaxRetries int
+	// RetryBaseDelay is doubled on each retry up to RetryMaxDelay.
+	// The Retry-After header of rate limited responses takes precedence
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// FailureThreshold consecutive failed requests open the circuit breaker for OpenPeriod
+	FailureThreshold int
+	OpenPeriod       time.Duration
+	// ThrottledPeriod is the time the provider account is reported as throttled after a rate limited response
+	ThrottledPeriod time.Duration
+}
+
+var DefaultAdminAPIGuardOptions = AdminAPIGuardOptions{
+	QPS:              10,
+	Burst:            20,
+	MaxRetries:       3,
+	RetryBaseDelay:   500 * time.Millisecond,
+	RetryMaxDelay:    10 * time.Second,
+	FailureThreshold: 5,
+	OpenPeriod:       30 * time.Second,
+	ThrottledPeriod:  time.Minute,
+}
+
+// AdminAPICircuitOpenError is returned when a request is rejected by the circuit breaker
+type AdminAPICircuitOpenError struct {
+	Host       string
+	RetryAfter time.Duration
+	// LastFailure describes the last failure before the circuit breaker opened
+	LastFailure string
+}
+
+func (e *AdminAPICircuitOpenError) Error() string {
+	return fmt.Sprintf("3scale admin API %s requests rejected after too many consecutive failures, retry after %s. Last failure: %s",
+		e.Host, e.RetryAfter.Round(time.Second), e.LastFailure)
+}
+
+// IsAdminAPICircuitOpen returns true when the error is a request rejected by the circuit breaker
+func IsAdminAPICircuitOpen(err error) bool {
+	circuitOpenErr := &AdminAPICircuitOpenError{}
+	return errors.As(err, &circuitOpenErr)
+}
+
+// AdminAPIThrottling describes why the requests to a provider account are being delayed or rejected
+type AdminAPIThrottling struct {
+	Reason     string
+	Message    string
+	RetryAfter time.Duration
+}
+
+// AdminAPIGuard rate limits, retries and circuit breaks the requests to the admin API of one provider account.
+// It is shared by all the clients of the provider account and safe for concurrent use
+type AdminAPIGuard struct {
+	host    string
+	options AdminAPIGuardOptions
+	limiter flowcontrol.RateLimiter
+
+	mutex               sync.Mutex
+	consecutiveFailures int
+	lastFailure         string
+	openUntil           time.Time
+	throttledUntil      time.Time
+}
+
+func NewAdminAPIGuard(host string, options AdminAPIGuardOptions) *AdminAPIGuard {
+	return &AdminAPIGuard{
+		host:    host,
+		options: options,
+		limiter: flowcontrol.NewTokenBucketRateLimiter(options.QPS, options.Burst),
+	}
+}
+
+var adminAPIGuards = struct {
+	sync.Mutex
+	options AdminAPIGuardOptions
+	guards  map[string]*AdminAPIGuard
+}{
+	options: DefaultAdminAPIGuardOptions,
+	guards:  map[string]*AdminAPIGuard{},
+}
+
+// SetAdminAPIGuardOptions sets the options of the provider account guards.
+// Meant to be called on startup, existing guards are discarded
+func SetAdminAPIGuardOptions(options AdminAPIGuardOptions) {
+	adminAPIGuards.Lock()
+	defer adminAPIGuards.Unlock()
+	adminAPIGuards.options = options
+	adminAPIGuards.guards = map[string]*AdminAPIGuard{}
+}
+
+// AdminAPIGuardFor returns the guard shared by the requests to the admin API host
+func AdminAPIGuardFor(host string) *AdminAPIGuard {
+	adminAPIGuards.Lock()
+	defer adminAPIGuards.Unlock()
+	guard, ok := adminAPIGuards.guards[host]
+	if !ok {
+		guard = NewAdminAPIGuard(host, adminAPIGuards.options)
+		adminAPIGuards.guards[host] = guard
+	}
+	return guard
+}
+
+// AdminAPIThrottlingFor returns the throttling of the provider account admin URL,
+// nil when the provider account is not being throttled
+func AdminAPIThrottlingFor(adminURLStr string) *AdminAPIThrottling {
+	adminURL, err := url.Parse(adminURLStr)
+	if err != nil || adminURL.Host == "" {
+		return nil
+	}
+
+	adminAPIGuards.Lock()
+	guard, ok := adminAPIGuards.guards[adminURL.Host]
+	adminAPIGuards.Unlock()
+	if !ok {
+		return nil
+	}
+
+	return guard.Throttling()
+}
+
+// Throttling returns why the requests are being delayed or rejected, nil when they are not
+func (g *AdminAPIGuard) Throttling() *AdminAPIThrottling {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := time.Now()
+	if now.Before(g.openUntil) {
+		return &AdminAPIThrottling{
+			Reason:     AdminAPIThrottlingReasonCircuitOpen,
+			Message:    fmt.Sprintf("3scale admin API %s requests rejected after %d consecutive failures", g.host, g.consecutiveFailures),
+			RetryAfter: g.openUntil.Sub(now),
+		}
+	}
+
+	if now.Before(g.throttledUntil) {
+		return &AdminAPIThrottling{
+			Reason:     AdminAPIThrottlingReasonRateLimited,
+			Message:    fmt.Sprintf("3scale admin API %s is rate limiting the requests", g.host),
+			RetryAfter: g.throttledUntil.Sub(now),
+		}
+	}
+
+	return nil
+}
+
+// Transport wraps the transport with the guard
+func (g *AdminAPIGuard) Transport(transport http.RoundTripper) http.RoundTripper {
+	return &adminAPIGuardTransport{transport: transport, guard: g}
+}
+
+// allow returns an error while the circuit breaker is open.
+// Once the open period expires, requests are let through again,
+// and a new failure opens the circuit breaker again
+func (g *AdminAPIGuard) allow() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if now := time.Now(); now.Before(g.openUntil) {
+		return &AdminAPICircuitOpenError{Host: g.host, RetryAfter: g.openUntil.Sub(now), LastFailure: g.lastFailure}
+	}
+	return nil
+}
+
+// record updates the guard state with the outcome of a request.
+// Returns whether the request should be retried
+func (g *AdminAPIGuard) record(req *http.Request, resp *http.Response, err error) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	switch {
+	case err == nil && resp.StatusCode == http.StatusTooManyRequests:
+		// 3scale is alive, rate limited responses do not count as failures
+		throttledPeriod := g.options.ThrottledPeriod
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > throttledPeriod {
+			throttledPeriod = retryAfter
+		}
+		g.throttledUntil = time.Now().Add(throttledPeriod)
+		return true
+	case err != nil && !isTransientError(err):
+		return false
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		g.consecutiveFailures++
+		if err != nil {
+			g.lastFailure = err.Error()
+		} else {
+			g.lastFailure = fmt.Sprintf("%s %s: %s", req.Method, req.URL.Path, resp.Status)
+		}
+		if g.consecutiveFailures >= g.options.FailureThreshold {
+			g.openUntil = time.Now().Add(g.options.OpenPeriod)
+		}
+		// Non idempotent requests might have been processed
+		return isIdempotentMethod(req.Method)
+	default:
+		g.consecutiveFailures = 0
+		g.openUntil = time.Time{}
+		return false
+	}
+}
+
+// retryDelay returns the delay before the retry. Returns false when the delay exceeds the maximum
+func (g *AdminAPIGuard) retryDelay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter, retryAfter <= g.options.RetryMaxDelay
+		}
+	}
+
+	delay := g.options.RetryBaseDelay << uint(attempt)
+	if delay <= 0 || delay > g.options.RetryMaxDelay {
+		delay = g.options.RetryMaxDelay
+	}
+	return delay, true
+}
+
+type adminAPIGuardTransport struct {
+	transport http.RoundTripper
+	guard     *AdminAPIGuard
+}
+
+func (t *adminAPIGuardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.guard.allow(); err != nil {
+			return nil, err
+		}
+
+		if err := t.guard.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.transport.RoundTrip(attemptReq)
+		retry := t.guard.record(req, resp, err)
+		if !retry || attempt >= t.guard.options.MaxRetries || !isReplayable(req) {
+			return resp, err
+		}
+
+		delay, ok := t.guard.retryDelay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rewindRequest returns the request to send on the attempt with a fresh copy of the body
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	newReq := req.Clone(req.Context())
+	newReq.Body = body
+	return newReq, nil
+}
+
+// isTransientError returns false for the errors retrying does not fix, i.e. certificate verification errors
+func isTransientError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var certificateInvalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &unknownAuthorityErr) || errors.As(err, &certificateInvalidErr) || errors.As(err, &hostnameErr) {
+		return false
+	}
+
+	// TLS alerts sent by the server, i.e. client certificate rejected
+	opErr := &net.OpError{}
+	if errors.As(err, &opErr) && opErr.Op == "remote error" {
+		return false
+	}
+
+	return true
+}
+
+func isReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the Retry-After header, either seconds or HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
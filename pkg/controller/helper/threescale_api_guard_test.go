@@ -0,0 +1,212 @@
+package helper
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testAdminAPIGuardOptions() AdminAPIGuardOptions {
+	return AdminAPIGuardOptions{
+		QPS:              1000,
+		Burst:            1000,
+		MaxRetries:       2,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    10 * time.Millisecond,
+		FailureThreshold: 10,
+		OpenPeriod:       time.Minute,
+		ThrottledPeriod:  time.Minute,
+	}
+}
+
+// statusSequenceServer responds the status codes in sequence, then 200
+func statusSequenceServer(codes ...int) (*httptest.Server, *int32, *[]string) {
+	var requests int32
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		idx := int(atomic.AddInt32(&requests, 1)) - 1
+		if idx < len(codes) {
+			if codes[idx] == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(codes[idx])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &requests, &bodies
+}
+
+func guardedClient(options AdminAPIGuardOptions) (*http.Client, *AdminAPIGuard) {
+	guard := NewAdminAPIGuard("test", options)
+	return &http.Client{Transport: guard.Transport(http.DefaultTransport)}, guard
+}
+
+func TestAdminAPIGuardRetries(t *testing.T) {
+	cases := []struct {
+		name             string
+		method           string
+		codes            []int
+		expectedCode     int
+		expectedRequests int32
+	}{
+		{"getServerError", http.MethodGet, []int{503, 502}, 200, 3},
+		{"getRetriesExhausted", http.MethodGet, []int{500, 500, 500, 500}, 500, 3},
+		{"postServerError", http.MethodPost, []int{500}, 500, 1},
+		{"postRateLimited", http.MethodPost, []int{429, 429}, 200, 3},
+		{"notFound", http.MethodGet, []int{404}, 404, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			server, requests, bodies := statusSequenceServer(tc.codes...)
+			defer server.Close()
+
+			client, _ := guardedClient(testAdminAPIGuardOptions())
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader("name=foo"))
+			if err != nil {
+				subT.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedCode {
+				subT.Errorf("expected status %d, got %d", tc.expectedCode, resp.StatusCode)
+			}
+			if *requests != tc.expectedRequests {
+				subT.Errorf("expected %d requests, got %d", tc.expectedRequests, *requests)
+			}
+			for _, body := range *bodies {
+				if body != "name=foo" {
+					subT.Errorf("expected body to be replayed, got %q", body)
+				}
+			}
+		})
+	}
+}
+
+func TestAdminAPIGuardThrottling(t *testing.T) {
+	server, _, _ := statusSequenceServer(http.StatusTooManyRequests)
+	defer server.Close()
+
+	client, guard := guardedClient(testAdminAPIGuardOptions())
+	if guard.Throttling() != nil {
+		t.Fatal("expected no throttling before requests")
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	throttling := guard.Throttling()
+	if throttling == nil || throttling.Reason != AdminAPIThrottlingReasonRateLimited {
+		t.Fatalf("expected rate limited throttling, got %v", throttling)
+	}
+}
+
+func TestAdminAPIGuardCircuitBreaker(t *testing.T) {
+	server, requests, _ := statusSequenceServer(500, 500)
+	defer server.Close()
+
+	options := testAdminAPIGuardOptions()
+	options.MaxRetries = 0
+	options.FailureThreshold = 2
+	options.OpenPeriod = 50 * time.Millisecond
+	client, guard := guardedClient(options)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(server.URL)
+	if !IsAdminAPICircuitOpen(err) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+	if *requests != 2 {
+		t.Errorf("expected rejected request not to reach the server, got %d requests", *requests)
+	}
+	if throttling := guard.Throttling(); throttling == nil || throttling.Reason != AdminAPIThrottlingReasonCircuitOpen {
+		t.Fatalf("expected circuit open throttling, got %v", throttling)
+	}
+
+	// After the open period requests reach the server again
+	time.Sleep(options.OpenPeriod)
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if guard.Throttling() != nil {
+		t.Error("expected circuit breaker closed after a successful request")
+	}
+}
+
+func TestAdminAPIGuardRateLimit(t *testing.T) {
+	server, _, _ := statusSequenceServer()
+	defer server.Close()
+
+	options := testAdminAPIGuardOptions()
+	options.QPS = 20
+	options.Burst = 1
+	client, _ := guardedClient(options)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	// first request uses the burst, the other two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, took %s", elapsed)
+	}
+}
+
+func TestPortaClientRateLimitedRetry(t *testing.T) {
+	var requests int32
+	adminAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"services":[]}`))
+	}))
+	defer adminAPI.Close()
+
+	client, err := PortaClient(&ProviderAccount{AdminURLStr: adminAPI.URL, Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.ListProducts(); err != nil {
+		t.Fatal(err)
+	}
+
+	throttling := AdminAPIThrottlingFor(adminAPI.URL)
+	if throttling == nil || throttling.Reason != AdminAPIThrottlingReasonRateLimited {
+		t.Fatalf("expected provider account to be reported as rate limited, got %v", throttling)
+	}
+}
@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPortaClientWithChangesCounter(t *testing.T) {
+	adminAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin/api/services/2.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"services":[]}`))
+		default:
+			w.Write([]byte(`{"service":{"id":1}}`))
+		}
+	}))
+	defer adminAPI.Close()
+
+	counter := &RemoteChangesCounter{}
+	client, err := PortaClientWithChangesCounter(&ProviderAccount{AdminURLStr: adminAPI.URL, Token: "token"}, counter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.ListProducts(); err != nil {
+		t.Fatal(err)
+	}
+	if counter.Count() != 0 {
+		t.Errorf("expected no changes after reads, got %d", counter.Count())
+	}
+
+	if _, err := client.UpdateProduct(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if counter.Count() != 1 {
+		t.Errorf("expected 1 change, got %d", counter.Count())
+	}
+
+	// failed requests do not change 3scale
+	if err := client.DeleteProduct(2); err == nil {
+		t.Fatal("expected error deleting missing product")
+	}
+	if counter.Count() != 1 {
+		t.Errorf("expected 1 change, got %d", counter.Count())
+	}
+
+	var nilCounter *RemoteChangesCounter
+	if nilCounter.Count() != 0 {
+		t.Error("expected no changes on nil counter")
+	}
+}
@@ -0,0 +1,269 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// SecretRefsIndexKey indexes capabilities resources by the secrets they read,
+	// in "namespace/name" form
+	SecretRefsIndexKey = ".spec.secretRefs"
+
+	// ProviderAccountCRRefIndexKey indexes capabilities resources by the ProviderAccount
+	// custom resource they reference, in "namespace/name" form
+	ProviderAccountCRRefIndexKey = ".spec.providerAccountCRRef"
+
+	// ProductBackendUsagesIndexKey indexes products by the system names of the backends they use
+	ProductBackendUsagesIndexKey = ".spec.backendUsages"
+)
+
+// providerAccountSecretRefs returns the provider account secrets read by a resource.
+// When no reference is set, the secrets of the default lookup sources are returned
+func providerAccountSecretRefs(ns string, providerAccountCRRef *capabilitiesv1beta1.ProviderAccountCRReference, providerAccountRef *corev1.LocalObjectReference) []string {
+	if providerAccountCRRef != nil {
+		// Secrets are read through the ProviderAccount custom resource
+		return []string{}
+	}
+
+	if providerAccountRef != nil {
+		return []string{types.NamespacedName{Namespace: ns, Name: providerAccountRef.Name}.String()}
+	}
+
+	return []string{
+		types.NamespacedName{Namespace: ns, Name: providerAccountDefaultSecretName}.String(),
+		types.NamespacedName{Namespace: ns, Name: component.SystemSecretSystemSeedSecretName}.String(),
+	}
+}
+
+func providerAccountCRRefIndexValues(ns string, providerAccountCRRef *capabilitiesv1beta1.ProviderAccountCRReference) []string {
+	if providerAccountCRRef == nil {
+		return []string{}
+	}
+
+	key := types.NamespacedName{Name: providerAccountCRRef.Name, Namespace: providerAccountCRRef.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = ns
+	}
+
+	return []string{key.String()}
+}
+
+// ProductSecretRefs is the SecretRefsIndexKey indexer of products
+func ProductSecretRefs(obj runtime.Object) []string {
+	product, ok := obj.(*capabilitiesv1beta1.Product)
+	if !ok {
+		return nil
+	}
+
+	res := providerAccountSecretRefs(product.Namespace, product.Spec.ProviderAccountCRRef, product.Spec.ProviderAccountRef)
+
+	var authentication *capabilitiesv1beta1.AuthenticationSpec
+	if product.Spec.Deployment != nil && product.Spec.Deployment.ApicastHosted != nil {
+		authentication = product.Spec.Deployment.ApicastHosted.Authentication
+	}
+	if product.Spec.Deployment != nil && product.Spec.Deployment.ApicastSelfManaged != nil {
+		authentication = product.Spec.Deployment.ApicastSelfManaged.Authentication
+	}
+	if authentication != nil && authentication.OIDC != nil {
+		res = append(res, types.NamespacedName{Namespace: product.Namespace, Name: authentication.OIDC.IssuerEndpointRef.Name}.String())
+	}
+
+	if product.Spec.ProxyConfigPromotion != nil && product.Spec.ProxyConfigPromotion.SmokeCheck != nil &&
+		product.Spec.ProxyConfigPromotion.SmokeCheck.HeadersSecretRef != nil {
+		res = append(res, types.NamespacedName{Namespace: product.Namespace, Name: product.Spec.ProxyConfigPromotion.SmokeCheck.HeadersSecretRef.Name}.String())
+	}
+
+	return res
+}
+
+// ProductProviderAccountCRRef is the ProviderAccountCRRefIndexKey indexer of products
+func ProductProviderAccountCRRef(obj runtime.Object) []string {
+	product, ok := obj.(*capabilitiesv1beta1.Product)
+	if !ok {
+		return nil
+	}
+
+	return providerAccountCRRefIndexValues(product.Namespace, product.Spec.ProviderAccountCRRef)
+}
+
+// ProductBackendUsages is the ProductBackendUsagesIndexKey indexer of products
+func ProductBackendUsages(obj runtime.Object) []string {
+	product, ok := obj.(*capabilitiesv1beta1.Product)
+	if !ok {
+		return nil
+	}
+
+	res := make([]string, 0, len(product.Spec.BackendUsages))
+	for systemName := range product.Spec.BackendUsages {
+		res = append(res, systemName)
+	}
+
+	return res
+}
+
+// BackendSecretRefs is the SecretRefsIndexKey indexer of backends
+func BackendSecretRefs(obj runtime.Object) []string {
+	backend, ok := obj.(*capabilitiesv1beta1.Backend)
+	if !ok {
+		return nil
+	}
+
+	return providerAccountSecretRefs(backend.Namespace, backend.Spec.ProviderAccountCRRef, backend.Spec.ProviderAccountRef)
+}
+
+// BackendProviderAccountCRRef is the ProviderAccountCRRefIndexKey indexer of backends
+func BackendProviderAccountCRRef(obj runtime.Object) []string {
+	backend, ok := obj.(*capabilitiesv1beta1.Backend)
+	if !ok {
+		return nil
+	}
+
+	return providerAccountCRRefIndexValues(backend.Namespace, backend.Spec.ProviderAccountCRRef)
+}
+
+// OpenAPISecretRefs is the SecretRefsIndexKey indexer of openapis
+func OpenAPISecretRefs(obj runtime.Object) []string {
+	openapi, ok := obj.(*capabilitiesv1beta1.OpenAPI)
+	if !ok {
+		return nil
+	}
+
+	res := providerAccountSecretRefs(openapi.Namespace, openapi.Spec.ProviderAccountCRRef, openapi.Spec.ProviderAccountRef)
+
+	if secretRef := openapi.Spec.OpenAPIRef.SecretRef; secretRef != nil {
+		key := types.NamespacedName{Namespace: secretRef.Namespace, Name: secretRef.Name}
+		if key.Namespace == "" {
+			key.Namespace = openapi.Namespace
+		}
+		res = append(res, key.String())
+	}
+
+	return res
+}
+
+// OpenAPIProviderAccountCRRef is the ProviderAccountCRRefIndexKey indexer of openapis
+func OpenAPIProviderAccountCRRef(obj runtime.Object) []string {
+	openapi, ok := obj.(*capabilitiesv1beta1.OpenAPI)
+	if !ok {
+		return nil
+	}
+
+	return providerAccountCRRefIndexValues(openapi.Namespace, openapi.Spec.ProviderAccountCRRef)
+}
+
+// SecretToRequestsMapper returns the mapper enqueueing the resources of the list type
+// reading the secret, either directly or through a ProviderAccount custom resource.
+// The resources must be indexed by SecretRefsIndexKey and ProviderAccountCRRefIndexKey
+func SecretToRequestsMapper(cl client.Client, newList func() runtime.Object, logger logr.Logger) handler.ToRequestsFunc {
+	return func(a handler.MapObject) []reconcile.Request {
+		secretKey := types.NamespacedName{Namespace: a.Meta.GetNamespace(), Name: a.Meta.GetName()}
+
+		requests, err := indexedRequests(cl, newList(), client.MatchingFields{SecretRefsIndexKey: secretKey.String()})
+		if err != nil {
+			logger.Error(err, "listing resources referencing secret", "secret", secretKey)
+			return nil
+		}
+
+		providerAccountList := &capabilitiesv1beta1.ProviderAccountList{}
+		err = cl.List(context.TODO(), providerAccountList, client.InNamespace(secretKey.Namespace))
+		if err != nil {
+			logger.Error(err, "listing provider accounts", "namespace", secretKey.Namespace)
+			return requests
+		}
+
+		for _, providerAccount := range providerAccountList.Items {
+			if providerAccount.Spec.CredentialsRef.Name != secretKey.Name {
+				continue
+			}
+
+			providerAccountKey := types.NamespacedName{Namespace: providerAccount.Namespace, Name: providerAccount.Name}
+			providerAccountRequests, err := indexedRequests(cl, newList(), client.MatchingFields{ProviderAccountCRRefIndexKey: providerAccountKey.String()})
+			if err != nil {
+				logger.Error(err, "listing resources referencing provider account", "providerAccount", providerAccountKey)
+				continue
+			}
+			requests = append(requests, providerAccountRequests...)
+		}
+
+		return requests
+	}
+}
+
+// BackendToProductRequestsMapper returns the mapper enqueueing the products using the backend.
+// Products must be indexed by ProductBackendUsagesIndexKey
+func BackendToProductRequestsMapper(cl client.Client, logger logr.Logger) handler.ToRequestsFunc {
+	return func(a handler.MapObject) []reconcile.Request {
+		backend, ok := a.Object.(*capabilitiesv1beta1.Backend)
+		if !ok || backend.Spec.SystemName == "" {
+			return nil
+		}
+
+		requests, err := indexedRequests(cl, &capabilitiesv1beta1.ProductList{},
+			client.InNamespace(backend.Namespace), client.MatchingFields{ProductBackendUsagesIndexKey: backend.Spec.SystemName})
+		if err != nil {
+			logger.Error(err, "listing products using backend", "backend", types.NamespacedName{Namespace: backend.Namespace, Name: backend.Name})
+			return nil
+		}
+
+		return requests
+	}
+}
+
+// BackendUsageChangedPredicate filters backend updates not affecting the products using it.
+// Products only use synced backends, so updates changing the synced condition pass as well
+var BackendUsageChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldBackend, ok := e.ObjectOld.(*capabilitiesv1beta1.Backend)
+		if !ok {
+			return true
+		}
+		newBackend, ok := e.ObjectNew.(*capabilitiesv1beta1.Backend)
+		if !ok {
+			return true
+		}
+
+		return oldBackend.GetGeneration() != newBackend.GetGeneration() ||
+			oldBackend.IsSynced() != newBackend.IsSynced()
+	},
+}
+
+// indexedRequests returns the requests of the listed resources
+func indexedRequests(cl client.Client, list runtime.Object, opts ...client.ListOption) ([]reconcile.Request, error) {
+	err := cl.List(context.TODO(), list, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return nil, fmt.Errorf("indexed resource: %w", err)
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: accessor.GetNamespace(), Name: accessor.GetName()},
+		})
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,183 @@
+package helper
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestProductIndexers(t *testing.T) {
+	cases := []struct {
+		name                 string
+		spec                 capabilitiesv1beta1.ProductSpec
+		expectedSecretRefs   []string
+		expectedCRRef        []string
+		expectedBackendUsage []string
+	}{
+		{
+			"defaultProviderAccount",
+			capabilitiesv1beta1.ProductSpec{},
+			[]string{"ns/system-seed", "ns/threescale-provider-account"},
+			[]string{},
+			[]string{},
+		},
+		{
+			"providerAccountRef",
+			capabilitiesv1beta1.ProductSpec{
+				ProviderAccountRef: &corev1.LocalObjectReference{Name: "mytenant"},
+				BackendUsages: map[string]capabilitiesv1beta1.BackendUsageSpec{
+					"backend1": {Path: "/"},
+					"backend2": {Path: "/v2"},
+				},
+			},
+			[]string{"ns/mytenant"},
+			[]string{},
+			[]string{"backend1", "backend2"},
+		},
+		{
+			"providerAccountCRRef",
+			capabilitiesv1beta1.ProductSpec{
+				ProviderAccountCRRef: &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant", Namespace: "tenants"},
+			},
+			[]string{},
+			[]string{"tenants/tenant"},
+			[]string{},
+		},
+		{
+			"specSecrets",
+			capabilitiesv1beta1.ProductSpec{
+				ProviderAccountCRRef: &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant"},
+				Deployment: &capabilitiesv1beta1.ProductDeploymentSpec{
+					ApicastSelfManaged: &capabilitiesv1beta1.ApicastSelfManagedSpec{
+						Authentication: &capabilitiesv1beta1.AuthenticationSpec{
+							OIDC: &capabilitiesv1beta1.OIDCSpec{
+								IssuerEndpointRef: corev1.LocalObjectReference{Name: "issuer"},
+							},
+						},
+					},
+				},
+				ProxyConfigPromotion: &capabilitiesv1beta1.ProxyConfigPromotionSpec{
+					SmokeCheck: &capabilitiesv1beta1.ProxyConfigSmokeCheckSpec{
+						HeadersSecretRef: &corev1.LocalObjectReference{Name: "headers"},
+					},
+				},
+			},
+			[]string{"ns/headers", "ns/issuer"},
+			[]string{"ns/tenant"},
+			[]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			product := &capabilitiesv1beta1.Product{
+				ObjectMeta: metav1.ObjectMeta{Name: "product", Namespace: "ns"},
+				Spec:       tc.spec,
+			}
+
+			secretRefs := ProductSecretRefs(product)
+			sort.Strings(secretRefs)
+			if !reflect.DeepEqual(secretRefs, tc.expectedSecretRefs) {
+				subT.Errorf("expected secret refs %v, got %v", tc.expectedSecretRefs, secretRefs)
+			}
+
+			crRef := ProductProviderAccountCRRef(product)
+			if !reflect.DeepEqual(crRef, tc.expectedCRRef) {
+				subT.Errorf("expected provider account CR ref %v, got %v", tc.expectedCRRef, crRef)
+			}
+
+			backendUsages := ProductBackendUsages(product)
+			sort.Strings(backendUsages)
+			if !reflect.DeepEqual(backendUsages, tc.expectedBackendUsage) {
+				subT.Errorf("expected backend usages %v, got %v", tc.expectedBackendUsage, backendUsages)
+			}
+		})
+	}
+}
+
+func TestOpenAPISecretRefs(t *testing.T) {
+	cases := []struct {
+		name     string
+		spec     capabilitiesv1beta1.OpenAPISpec
+		expected []string
+	}{
+		{
+			"url",
+			capabilitiesv1beta1.OpenAPISpec{
+				ProviderAccountRef: &corev1.LocalObjectReference{Name: "mytenant"},
+			},
+			[]string{"ns/mytenant"},
+		},
+		{
+			"secretRef",
+			capabilitiesv1beta1.OpenAPISpec{
+				ProviderAccountCRRef: &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant"},
+				OpenAPIRef:           capabilitiesv1beta1.OpenAPIRefSpec{SecretRef: &corev1.ObjectReference{Name: "spec"}},
+			},
+			[]string{"ns/spec"},
+		},
+		{
+			"secretRefNamespace",
+			capabilitiesv1beta1.OpenAPISpec{
+				ProviderAccountCRRef: &capabilitiesv1beta1.ProviderAccountCRReference{Name: "tenant"},
+				OpenAPIRef:           capabilitiesv1beta1.OpenAPIRefSpec{SecretRef: &corev1.ObjectReference{Name: "spec", Namespace: "other"}},
+			},
+			[]string{"other/spec"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			openapi := &capabilitiesv1beta1.OpenAPI{
+				ObjectMeta: metav1.ObjectMeta{Name: "openapi", Namespace: "ns"},
+				Spec:       tc.spec,
+			}
+
+			secretRefs := OpenAPISecretRefs(openapi)
+			if !reflect.DeepEqual(secretRefs, tc.expected) {
+				subT.Errorf("expected secret refs %v, got %v", tc.expected, secretRefs)
+			}
+		})
+	}
+}
+
+func TestBackendUsageChangedPredicate(t *testing.T) {
+	newBackend := func(generation int64, synced bool) *capabilitiesv1beta1.Backend {
+		backend := &capabilitiesv1beta1.Backend{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "ns", Generation: generation},
+		}
+		if synced {
+			backend.Status.Conditions.SetCondition(common.Condition{Type: capabilitiesv1beta1.BackendSyncedConditionType, Status: corev1.ConditionTrue})
+		}
+		return backend
+	}
+
+	cases := []struct {
+		name     string
+		old      *capabilitiesv1beta1.Backend
+		new      *capabilitiesv1beta1.Backend
+		expected bool
+	}{
+		{"statusOnly", newBackend(1, true), newBackend(1, true), false},
+		{"generation", newBackend(1, true), newBackend(2, true), true},
+		{"synced", newBackend(1, false), newBackend(1, true), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			res := BackendUsageChangedPredicate.Update(event.UpdateEvent{
+				MetaOld: tc.old, ObjectOld: tc.old, MetaNew: tc.new, ObjectNew: tc.new,
+			})
+			if res != tc.expected {
+				subT.Errorf("expected %t, got %t", tc.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	cases := []struct {
+		name        string
+		schedule    string
+		expectedErr bool
+	}{
+		{"daily", "0 2 * * *", false},
+		{"descriptor", "@weekly", false},
+		{"lists", "0,30 8-18 * * 1-5", false},
+		{"steps", "*/15 */2 1/7 * *", false},
+		{"sunday7", "0 0 * * 7", false},
+		{"missingField", "0 2 * *", true},
+		{"tooManyFields", "0 0 2 * * *", true},
+		{"outOfRange", "60 * * * *", true},
+		{"invertedRange", "0 5-1 * * *", true},
+		{"invalidStep", "*/0 * * * *", true},
+		{"invalidValue", "0 0 * JAN *", true},
+		{"empty", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			_, err := ParseCronSchedule(tc.schedule)
+			if tc.expectedErr && err == nil {
+				subT.Fatal("expected error")
+			}
+			if !tc.expectedErr && err != nil {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2021, time.March, 17, 10, 20, 30, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		schedule string
+		expected time.Time
+	}{
+		{"everyMinute", "* * * * *", time.Date(2021, time.March, 17, 10, 21, 0, 0, time.UTC)},
+		{"dailyLaterToday", "0 22 * * *", time.Date(2021, time.March, 17, 22, 0, 0, 0, time.UTC)},
+		{"dailyTomorrow", "0 2 * * *", time.Date(2021, time.March, 18, 2, 0, 0, 0, time.UTC)},
+		{"hourly", "@hourly", time.Date(2021, time.March, 17, 11, 0, 0, 0, time.UTC)},
+		{"quarterHour", "*/15 * * * *", time.Date(2021, time.March, 17, 10, 30, 0, 0, time.UTC)},
+		{"weekly", "@weekly", time.Date(2021, time.March, 21, 0, 0, 0, 0, time.UTC)},
+		{"sunday7", "0 0 * * 7", time.Date(2021, time.March, 21, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"nextYear", "0 0 1 1 *", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"leapDay", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"domOrDow", "0 0 20 * 5", time.Date(2021, time.March, 19, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			schedule, err := ParseCronSchedule(tc.schedule)
+			if err != nil {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+			next := schedule.Next(from)
+			if !next.Equal(tc.expected) {
+				subT.Errorf("got: %s; expected: %s", next, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var managedResourcesDesc = prometheus.NewDesc(
+	"threescale_operator_managed_resources",
+	"Products and backends managed by the operator by kind and provider account host",
+	[]string{"kind", "provider_account"}, nil,
+)
+
+// ManagedResourcesCollector counts the products and backends per provider account when scraped.
+// Resources not synced yet have an empty provider account
+type ManagedResourcesCollector struct {
+	client client.Reader
+	logger logr.Logger
+}
+
+var _ prometheus.Collector = &ManagedResourcesCollector{}
+
+func NewManagedResourcesCollector(cl client.Reader, logger logr.Logger) *ManagedResourcesCollector {
+	return &ManagedResourcesCollector{client: cl, logger: logger}
+}
+
+func (c *ManagedResourcesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedResourcesDesc
+}
+
+func (c *ManagedResourcesCollector) Collect(ch chan<- prometheus.Metric) {
+	productList := &capabilitiesv1beta1.ProductList{}
+	if err := c.client.List(context.TODO(), productList); err != nil {
+		c.logger.Error(err, "listing products")
+	} else {
+		hosts := []string{}
+		for idx := range productList.Items {
+			hosts = append(hosts, productList.Items[idx].Status.ProviderAccountHost)
+		}
+		c.collectKind(ch, capabilitiesv1beta1.ProductKind, hosts)
+	}
+
+	backendList := &capabilitiesv1beta1.BackendList{}
+	if err := c.client.List(context.TODO(), backendList); err != nil {
+		c.logger.Error(err, "listing backends")
+	} else {
+		hosts := []string{}
+		for idx := range backendList.Items {
+			hosts = append(hosts, backendList.Items[idx].Status.ProviderAccountHost)
+		}
+		c.collectKind(ch, capabilitiesv1beta1.BackendKind, hosts)
+	}
+}
+
+func (c *ManagedResourcesCollector) collectKind(ch chan<- prometheus.Metric, kind string, hosts []string) {
+	counts := map[string]int{}
+	for _, host := range hosts {
+		counts[host]++
+	}
+
+	for host, count := range counts {
+		ch <- prometheus.MustNewConstMetric(managedResourcesDesc, prometheus.GaugeValue, float64(count), kind, host)
+	}
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ReconcileConditionSynced  = "Synced"
+	ReconcileConditionDrifted = "Drifted"
+	ReconcileConditionInvalid = "Invalid"
+	ReconcileConditionOrphan  = "Orphan"
+	ReconcileConditionFailed  = "Failed"
+
+	BackupKindBackup  = "backup"
+	BackupKindRestore = "restore"
+
+	BackupResultSucceeded = "succeeded"
+	BackupResultFailed    = "failed"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "threescale_operator_reconcile_total",
+			Help: "Reconciliations of custom resources against 3scale by kind and resulting condition",
+		},
+		[]string{"kind", "condition"},
+	)
+
+	adminAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "threescale_operator_admin_api_request_duration_seconds",
+			Help:    "Latency of the requests to the 3scale admin API by endpoint, method and status code",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"endpoint", "method", "code"},
+	)
+
+	backupDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "threescale_operator_backup_duration_seconds",
+			Help: "Duration of the APIManager backups and restores by kind and result",
+			// 30 seconds to 4 hours
+			Buckets: prometheus.ExponentialBuckets(30, 2, 10),
+		},
+		[]string{"kind", "result"},
+	)
+)
+
+// MustRegister registers the operator metrics in the registry
+func MustRegister(registry prometheus.Registerer) {
+	registry.MustRegister(reconcileTotal, adminAPIRequestDuration, backupDuration)
+}
+
+// ReconcileCondition returns the condition of the custom resource resulting from the reconciliation
+func ReconcileCondition(reconcileErr error, drifted bool) string {
+	switch {
+	case helper.IsInvalidSpecError(reconcileErr):
+		return ReconcileConditionInvalid
+	case helper.IsOrphanSpecError(reconcileErr):
+		return ReconcileConditionOrphan
+	case reconcileErr != nil:
+		return ReconcileConditionFailed
+	case drifted:
+		return ReconcileConditionDrifted
+	default:
+		return ReconcileConditionSynced
+	}
+}
+
+// ObserveReconcile counts one reconciliation of a custom resource of the kind
+func ObserveReconcile(kind string, reconcileErr error, drifted bool) {
+	reconcileTotal.WithLabelValues(kind, ReconcileCondition(reconcileErr, drifted)).Inc()
+}
+
+// ObserveBackup records the duration of a finished backup or restore
+func ObserveBackup(kind, result string, start, completion time.Time) {
+	backupDuration.WithLabelValues(kind, result).Observe(completion.Sub(start).Seconds())
+}
+
+// adminAPIIDRegexp matches the path segments holding 3scale object IDs
+var adminAPIIDRegexp = regexp.MustCompile(`/[0-9]+(/|\.|$)`)
+
+// AdminAPIEndpoint returns the path with the 3scale object IDs replaced,
+// so requests to the same endpoint share the label value
+func AdminAPIEndpoint(path string) string {
+	// Consecutive IDs share the slash, so replacement runs until there is no match
+	for adminAPIIDRegexp.MatchString(path) {
+		path = adminAPIIDRegexp.ReplaceAllString(path, "/{id}$1")
+	}
+	return path
+}
+
+// ObserveAdminAPIRequest records the latency of a request to the 3scale admin API.
+// Code is zero when no response was received
+func ObserveAdminAPIRequest(path, method string, code int, duration time.Duration) {
+	codeLabel := "error"
+	if code != 0 {
+		codeLabel = strconv.Itoa(code)
+	}
+	adminAPIRequestDuration.WithLabelValues(AdminAPIEndpoint(path), method, codeLabel).Observe(duration.Seconds())
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestAdminAPIEndpoint(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/admin/api/services.json", "/admin/api/services.json"},
+		{"/admin/api/services/12.json", "/admin/api/services/{id}.json"},
+		{"/admin/api/services/12/proxy/mapping_rules/345.json", "/admin/api/services/{id}/proxy/mapping_rules/{id}.json"},
+		{"/admin/api/backend_apis/3/metrics/4/methods/5", "/admin/api/backend_apis/{id}/metrics/{id}/methods/{id}"},
+		{"/admin/api/services/12/proxy/configs/sandbox/7/promote.json", "/admin/api/services/{id}/proxy/configs/sandbox/{id}/promote.json"},
+		{"/admin/api/accounts/1/2.json", "/admin/api/accounts/{id}/{id}.json"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(subT *testing.T) {
+			if endpoint := AdminAPIEndpoint(tc.path); endpoint != tc.expected {
+				subT.Errorf("expected %s, got %s", tc.expected, endpoint)
+			}
+		})
+	}
+}
+
+func TestReconcileCondition(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		drifted  bool
+		expected string
+	}{
+		{"synced", nil, false, ReconcileConditionSynced},
+		{"drifted", nil, true, ReconcileConditionDrifted},
+		{"invalid", &helper.SpecFieldError{ErrorType: helper.InvalidError}, false, ReconcileConditionInvalid},
+		{"orphan", &helper.SpecFieldError{ErrorType: helper.OrphanError}, false, ReconcileConditionOrphan},
+		{"failed", errors.New("unavailable"), true, ReconcileConditionFailed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			if condition := ReconcileCondition(tc.err, tc.drifted); condition != tc.expected {
+				subT.Errorf("expected %s, got %s", tc.expected, condition)
+			}
+		})
+	}
+}
+
+func TestManagedResourcesCollector(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := scheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := capabilitiesv1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	product := func(name, host string) runtime.Object {
+		return &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Status:     capabilitiesv1beta1.ProductStatus{ProviderAccountHost: host},
+		}
+	}
+	backend := &capabilitiesv1beta1.Backend{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend1", Namespace: "ns"},
+		Status:     capabilitiesv1beta1.BackendStatus{ProviderAccountHost: "https://a-admin.example.com"},
+	}
+
+	cl := fake.NewFakeClientWithScheme(s,
+		product("product1", "https://a-admin.example.com"),
+		product("product2", "https://a-admin.example.com"),
+		product("product3", "https://b-admin.example.com"),
+		backend,
+	)
+
+	expected := `
+# HELP threescale_operator_managed_resources Products and backends managed by the operator by kind and provider account host
+# TYPE threescale_operator_managed_resources gauge
+threescale_operator_managed_resources{kind="Backend",provider_account="https://a-admin.example.com"} 1
+threescale_operator_managed_resources{kind="Product",provider_account="https://a-admin.example.com"} 2
+threescale_operator_managed_resources{kind="Product",provider_account="https://b-admin.example.com"} 1
+`
+	collector := NewManagedResourcesCollector(cl, logf.Log.WithName("metrics"))
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
@@ -10,7 +10,11 @@ import (
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/go-logr/logr"
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	appsv1 "github.com/openshift/api/apps/v1"
 	consolev1 "github.com/openshift/api/console/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -203,6 +207,42 @@ func (b *BaseReconciler) HasPodMonitors() (bool, error) {
 		monitoringv1.PodMonitorsKind)
 }
 
+//HasDeploymentConfigs checks if the DeploymentConfig API is supported in current cluster
+func (b *BaseReconciler) HasDeploymentConfigs() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		appsv1.GroupVersion.String(), "DeploymentConfig")
+}
+
+//HasImageStreams checks if the ImageStream API is supported in current cluster
+func (b *BaseReconciler) HasImageStreams() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		imagev1.GroupVersion.String(), "ImageStream")
+}
+
+//HasRoutes checks if the Route API is supported in current cluster
+func (b *BaseReconciler) HasRoutes() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		routev1.GroupVersion.String(), "Route")
+}
+
+//HasIngresses checks if the networking.k8s.io/v1 Ingress API is supported in current cluster
+func (b *BaseReconciler) HasIngresses() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		"networking.k8s.io/v1", "Ingress")
+}
+
+//HasHTTPRoutes checks if the Gateway API HTTPRoute CRD is supported in current cluster
+func (b *BaseReconciler) HasHTTPRoutes() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		"gateway.networking.k8s.io/v1", "HTTPRoute")
+}
+
+//HasHorizontalPodAutoscalers checks if the autoscaling/v2beta2 HorizontalPodAutoscaler API is supported in current cluster
+func (b *BaseReconciler) HasHorizontalPodAutoscalers() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		autoscalingv2beta2.SchemeGroupVersion.String(), "HorizontalPodAutoscaler")
+}
+
 //SetOwnerReference sets owner as a Controller OwnerReference on owned
 func (b *BaseReconciler) SetOwnerReference(owner, obj common.KubernetesObject) error {
 	err := controllerutil.SetControllerReference(owner, obj, b.Scheme())
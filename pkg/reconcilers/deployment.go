@@ -0,0 +1,191 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func DeploymentResourcesMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*k8sappsv1.Deployment)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", existingObj)
+	}
+	desired, ok := desiredObj.(*k8sappsv1.Deployment)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", desiredObj)
+	}
+
+	update := false
+
+	tmpUpdate := DeploymentContainerImagesReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentContainerResourcesReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	return update, nil
+}
+
+func DeploymentResourcesAndAffinityAndTolerationsMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*k8sappsv1.Deployment)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", existingObj)
+	}
+	desired, ok := desiredObj.(*k8sappsv1.Deployment)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", desiredObj)
+	}
+
+	update := false
+
+	tmpUpdate := DeploymentContainerImagesReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentContainerResourcesReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentAffinityReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentTolerationsReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	return update, nil
+}
+
+func GenericDeploymentMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*k8sappsv1.Deployment)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", existingObj)
+	}
+	desired, ok := desiredObj.(*k8sappsv1.Deployment)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", desiredObj)
+	}
+
+	update := false
+
+	tmpUpdate := DeploymentReplicasReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentContainerImagesReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentContainerResourcesReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentAffinityReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	tmpUpdate = DeploymentTolerationsReconciler(desired, existing)
+	update = update || tmpUpdate
+
+	return update, nil
+}
+
+func DeploymentReplicasReconciler(desired, existing *k8sappsv1.Deployment) bool {
+	update := false
+
+	if !reflect.DeepEqual(desired.Spec.Replicas, existing.Spec.Replicas) {
+		existing.Spec.Replicas = desired.Spec.Replicas
+		update = true
+	}
+
+	return update
+}
+
+func DeploymentAffinityReconciler(desired, existing *k8sappsv1.Deployment) bool {
+	updated := false
+
+	if !reflect.DeepEqual(existing.Spec.Template.Spec.Affinity, desired.Spec.Template.Spec.Affinity) {
+		diff := cmp.Diff(existing.Spec.Template.Spec.Affinity, desired.Spec.Template.Spec.Affinity)
+		log.Info(fmt.Sprintf("%s spec.template.spec.Affinity has changed: %s", common.ObjectInfo(desired), diff))
+		existing.Spec.Template.Spec.Affinity = desired.Spec.Template.Spec.Affinity
+		updated = true
+	}
+
+	return updated
+}
+
+func DeploymentTolerationsReconciler(desired, existing *k8sappsv1.Deployment) bool {
+	updated := false
+
+	if !reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, desired.Spec.Template.Spec.Tolerations) {
+		diff := cmp.Diff(existing.Spec.Template.Spec.Tolerations, desired.Spec.Template.Spec.Tolerations)
+		log.Info(fmt.Sprintf("%s spec.template.spec.Tolerations has changed: %s", common.ObjectInfo(desired), diff))
+		existing.Spec.Template.Spec.Tolerations = desired.Spec.Template.Spec.Tolerations
+		updated = true
+	}
+
+	return updated
+}
+
+// DeploymentContainerImagesReconciler reconciles the images of the containers
+// and init containers. Deployments have no image change triggers, so this is
+// how new images are rolled out on upgrades
+func DeploymentContainerImagesReconciler(desired, existing *k8sappsv1.Deployment) bool {
+	desiredName := common.ObjectInfo(desired)
+	update := false
+
+	if len(existing.Spec.Template.Spec.InitContainers) != len(desired.Spec.Template.Spec.InitContainers) {
+		log.Info(fmt.Sprintf("%s spec.template.spec.initContainers length changed to '%d', recreating deployment init containers", desiredName, len(existing.Spec.Template.Spec.InitContainers)))
+		existing.Spec.Template.Spec.InitContainers = desired.Spec.Template.Spec.InitContainers
+		update = true
+	}
+
+	if len(existing.Spec.Template.Spec.Containers) != len(desired.Spec.Template.Spec.Containers) {
+		log.Info(fmt.Sprintf("%s spec.template.spec.containers length changed to '%d', recreating deployment containers", desiredName, len(existing.Spec.Template.Spec.Containers)))
+		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
+		update = true
+	}
+
+	for idx := range desired.Spec.Template.Spec.InitContainers {
+		if existing.Spec.Template.Spec.InitContainers[idx].Image != desired.Spec.Template.Spec.InitContainers[idx].Image {
+			log.Info(fmt.Sprintf("%s spec.template.spec.initContainers[%d].image has changed to '%s'", desiredName, idx, desired.Spec.Template.Spec.InitContainers[idx].Image))
+			existing.Spec.Template.Spec.InitContainers[idx].Image = desired.Spec.Template.Spec.InitContainers[idx].Image
+			update = true
+		}
+	}
+
+	for idx := range desired.Spec.Template.Spec.Containers {
+		if existing.Spec.Template.Spec.Containers[idx].Image != desired.Spec.Template.Spec.Containers[idx].Image {
+			log.Info(fmt.Sprintf("%s spec.template.spec.containers[%d].image has changed to '%s'", desiredName, idx, desired.Spec.Template.Spec.Containers[idx].Image))
+			existing.Spec.Template.Spec.Containers[idx].Image = desired.Spec.Template.Spec.Containers[idx].Image
+			update = true
+		}
+	}
+
+	return update
+}
+
+// DeploymentContainerResourcesReconciler reconciles the resource requirements
+// of every container
+func DeploymentContainerResourcesReconciler(desired, existing *k8sappsv1.Deployment) bool {
+	desiredName := common.ObjectInfo(desired)
+	update := false
+
+	if len(existing.Spec.Template.Spec.Containers) != len(desired.Spec.Template.Spec.Containers) {
+		log.Info(fmt.Sprintf("%s spec.template.spec.containers length changed to '%d', recreating deployment containers", desiredName, len(existing.Spec.Template.Spec.Containers)))
+		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
+		update = true
+	}
+
+	for idx := range desired.Spec.Template.Spec.Containers {
+		if !helper.CmpResources(&existing.Spec.Template.Spec.Containers[idx].Resources, &desired.Spec.Template.Spec.Containers[idx].Resources) {
+			diff := cmp.Diff(existing.Spec.Template.Spec.Containers[idx].Resources, desired.Spec.Template.Spec.Containers[idx].Resources, cmpopts.IgnoreUnexported(resource.Quantity{}))
+			log.Info(fmt.Sprintf("%s spec.template.spec.containers[%d].resources have changed: %s", desiredName, idx, diff))
+			existing.Spec.Template.Spec.Containers[idx].Resources = desired.Spec.Template.Spec.Containers[idx].Resources
+			update = true
+		}
+	}
+
+	return update
+}
@@ -0,0 +1,248 @@
+package reconcilers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentReplicasReconciler(t *testing.T) {
+	deploymentFactory := func(replicas int32) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myDeployment",
+				Namespace: "myNS",
+			},
+			Spec: k8sappsv1.DeploymentSpec{
+				Replicas: &replicas,
+			},
+		}
+	}
+
+	cases := []struct {
+		testName       string
+		desired        func() *k8sappsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", func() *k8sappsv1.Deployment { return deploymentFactory(3) }, false},
+		{"ReplicasReconcile", func() *k8sappsv1.Deployment { return deploymentFactory(1003) }, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := deploymentFactory(3)
+			update := DeploymentReplicasReconciler(tc.desired(), existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if *existing.Spec.Replicas != *tc.desired().Spec.Replicas {
+				subT.Fatalf("replica reconciliation failed, existing: %d, desired: %d", *existing.Spec.Replicas, *tc.desired().Spec.Replicas)
+			}
+		})
+	}
+}
+
+func TestDeploymentContainerImagesReconciler(t *testing.T) {
+	deploymentFactory := func(initImage, image string) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myDeployment",
+				Namespace: "myNS",
+			},
+			Spec: k8sappsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{
+							corev1.Container{Name: "init1", Image: initImage},
+						},
+						Containers: []corev1.Container{
+							corev1.Container{Name: "container1", Image: image},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName       string
+		existing       *k8sappsv1.Deployment
+		desired        *k8sappsv1.Deployment
+		expectedResult bool
+	}{
+		{"NothingToReconcile", deploymentFactory("a:1", "a:1"), deploymentFactory("a:1", "a:1"), false},
+		{"ContainerImage", deploymentFactory("a:1", "a:1"), deploymentFactory("a:1", "a:2"), true},
+		{"InitContainerImage", deploymentFactory("a:1", "a:1"), deploymentFactory("a:2", "a:1"), true},
+		{"InitContainerAdded",
+			&k8sappsv1.Deployment{Spec: k8sappsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{corev1.Container{Name: "container1", Image: "a:1"}},
+			}}}},
+			deploymentFactory("a:1", "a:1"), true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			update := DeploymentContainerImagesReconciler(tc.desired, tc.existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Template.Spec.InitContainers, tc.desired.Spec.Template.Spec.InitContainers) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec.InitContainers, tc.desired.Spec.Template.Spec.InitContainers))
+			}
+			if !reflect.DeepEqual(tc.existing.Spec.Template.Spec.Containers, tc.desired.Spec.Template.Spec.Containers) {
+				subT.Fatal(cmp.Diff(tc.existing.Spec.Template.Spec.Containers, tc.desired.Spec.Template.Spec.Containers))
+			}
+		})
+	}
+}
+
+func TestDeploymentContainerResourcesReconciler(t *testing.T) {
+	emptyResourceRequirements := corev1.ResourceRequirements{
+		Limits:   corev1.ResourceList{},
+		Requests: corev1.ResourceList{},
+	}
+	notEmptyResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("110Mi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("200m"),
+			corev1.ResourceMemory: resource.MustParse("220Mi"),
+		},
+	}
+	deploymentFactory := func(resources corev1.ResourceRequirements) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myDeployment",
+				Namespace: "myNS",
+			},
+			Spec: k8sappsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							corev1.Container{
+								Name:      "container1",
+								Resources: emptyResourceRequirements,
+							},
+							corev1.Container{
+								Name:      "container2",
+								Resources: resources,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName          string
+		existingResources corev1.ResourceRequirements
+		desiredResources  corev1.ResourceRequirements
+		expectedResult    bool
+	}{
+		{"NothingToReconcile", emptyResourceRequirements, emptyResourceRequirements, false},
+		{"NothingToReconcileWithResources", notEmptyResources, notEmptyResources, false},
+		{"AddResources", emptyResourceRequirements, notEmptyResources, true},
+		{"RemoveResources", notEmptyResources, emptyResourceRequirements, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := deploymentFactory(tc.existingResources)
+			desired := deploymentFactory(tc.desiredResources)
+			update := DeploymentContainerResourcesReconciler(desired, existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !helper.CmpResources(&existing.Spec.Template.Spec.Containers[1].Resources, &desired.Spec.Template.Spec.Containers[1].Resources) {
+				subT.Fatal(cmp.Diff(existing.Spec.Template.Spec.Containers[1].Resources, desired.Spec.Template.Spec.Containers[1].Resources, cmpopts.IgnoreUnexported(resource.Quantity{})))
+			}
+		})
+	}
+}
+
+func TestDeploymentTolerationsReconciler(t *testing.T) {
+	testTolerations1 := []corev1.Toleration{
+		corev1.Toleration{
+			Key:      "key1",
+			Effect:   corev1.TaintEffectNoExecute,
+			Operator: corev1.TolerationOpEqual,
+			Value:    "val1",
+		},
+	}
+	testTolerations2 := []corev1.Toleration{
+		corev1.Toleration{
+			Key:      "key2",
+			Effect:   corev1.TaintEffectNoExecute,
+			Operator: corev1.TolerationOpEqual,
+			Value:    "val2",
+		},
+	}
+	deploymentFactory := func(toleration []corev1.Toleration) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myDeployment",
+				Namespace: "myNS",
+			},
+			Spec: k8sappsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Tolerations: toleration,
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName            string
+		existingTolerations []corev1.Toleration
+		desiredTolerations  []corev1.Toleration
+		expectedResult      bool
+	}{
+		{"NothingToReconcile", nil, nil, false},
+		{"EqualTolerations", testTolerations1, testTolerations1, false},
+		{"DifferentTolerations", testTolerations1, testTolerations2, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := deploymentFactory(tc.existingTolerations)
+			desired := deploymentFactory(tc.desiredTolerations)
+			update := DeploymentTolerationsReconciler(desired, existing)
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, desired.Spec.Template.Spec.Tolerations) {
+				subT.Fatal(cmp.Diff(existing.Spec.Template.Spec.Tolerations, desired.Spec.Template.Spec.Tolerations))
+			}
+		})
+	}
+}
@@ -20,6 +20,15 @@ func GenericGrafanaDashboardsMutator(existingObj, desiredObj common.KubernetesOb
 		return false, fmt.Errorf("%T is not a *grafanav1alpha1.GrafanaDashboard", desiredObj)
 	}
 
+	userOwned, err := isUserOwnedMonitoringObject(existing, existing.Spec, desired.Spec)
+	if err != nil {
+		return false, err
+	}
+	if userOwned {
+		log.Info(fmt.Sprintf("%s has been modified out of the operator, skipping update", common.ObjectInfo(existing)))
+		return false, nil
+	}
+
 	updated := false
 
 	if !reflect.DeepEqual(existing.Spec, desired.Spec) {
@@ -29,5 +38,9 @@ func GenericGrafanaDashboardsMutator(existingObj, desiredObj common.KubernetesOb
 		updated = true
 	}
 
+	if monitoringObjectMetaMutator(existing, desired) {
+		updated = true
+	}
+
 	return updated, nil
 }
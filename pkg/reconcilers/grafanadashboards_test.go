@@ -37,6 +37,9 @@ func TestGenericGrafanaDashboardsMutatorWhenDiff(t *testing.T) {
 			Json: `{"somekey": "somevalue"}`,
 		},
 	}
+	if err := SetMonitoringSpecChecksum(desired, desired.Spec); err != nil {
+		t.Fatal(err)
+	}
 
 	existingTmp := desired.DeepCopyObject()
 	existing, ok := existingTmp.(*grafanav1alpha1.GrafanaDashboard)
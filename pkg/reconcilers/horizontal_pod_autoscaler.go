@@ -0,0 +1,57 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+)
+
+func GenericHorizontalPodAutoscalerMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *autoscalingv2beta2.HorizontalPodAutoscaler", existingObj)
+	}
+	desired, ok := desiredObj.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *autoscalingv2beta2.HorizontalPodAutoscaler", desiredObj)
+	}
+
+	updated := false
+	if !reflect.DeepEqual(desired.Spec, existing.Spec) {
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
+
+// ReplicasIgnoredMutator wraps a DeploymentConfig or Deployment mutator
+// keeping the existing replicas. Replicas of autoscaled workloads are managed
+// by their HorizontalPodAutoscaler
+func ReplicasIgnoredMutator(mutateFn MutateFn) MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		switch existing := existingObj.(type) {
+		case *appsv1.DeploymentConfig:
+			desired, ok := desiredObj.(*appsv1.DeploymentConfig)
+			if !ok {
+				return false, fmt.Errorf("%T is not a *appsv1.DeploymentConfig", desiredObj)
+			}
+			desired.Spec.Replicas = existing.Spec.Replicas
+		case *k8sappsv1.Deployment:
+			desired, ok := desiredObj.(*k8sappsv1.Deployment)
+			if !ok {
+				return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", desiredObj)
+			}
+			desired.Spec.Replicas = existing.Spec.Replicas
+		default:
+			return false, fmt.Errorf("%T is not a *appsv1.DeploymentConfig or *k8sappsv1.Deployment", existingObj)
+		}
+
+		return mutateFn(existingObj, desiredObj)
+	}
+}
@@ -0,0 +1,63 @@
+package reconcilers
+
+import (
+	"testing"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReplicasIgnoredMutator(t *testing.T) {
+	dcFactory := func(replicas int32) *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "myDC", Namespace: "myNS"},
+			Spec:       appsv1.DeploymentConfigSpec{Replicas: replicas},
+		}
+	}
+	deploymentFactory := func(replicas int32) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "myDeployment", Namespace: "myNS"},
+			Spec:       k8sappsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+
+	replicasMutator := func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		switch existing := existingObj.(type) {
+		case *appsv1.DeploymentConfig:
+			return DeploymentConfigReplicasReconciler(desiredObj.(*appsv1.DeploymentConfig), existing), nil
+		default:
+			return DeploymentReplicasReconciler(desiredObj.(*k8sappsv1.Deployment), existingObj.(*k8sappsv1.Deployment)), nil
+		}
+	}
+
+	t.Run("DeploymentConfig", func(subT *testing.T) {
+		existing := dcFactory(5)
+		update, err := ReplicasIgnoredMutator(replicasMutator)(existing, dcFactory(1))
+		if err != nil {
+			subT.Fatal(err)
+		}
+		if update {
+			subT.Fatal("expected no update")
+		}
+		if existing.Spec.Replicas != 5 {
+			subT.Fatalf("expected replicas 5, got %d", existing.Spec.Replicas)
+		}
+	})
+
+	t.Run("Deployment", func(subT *testing.T) {
+		existing := deploymentFactory(5)
+		update, err := ReplicasIgnoredMutator(replicasMutator)(existing, deploymentFactory(1))
+		if err != nil {
+			subT.Fatal(err)
+		}
+		if update {
+			subT.Fatal("expected no update")
+		}
+		if *existing.Spec.Replicas != 5 {
+			subT.Fatalf("expected replicas 5, got %d", *existing.Spec.Replicas)
+		}
+	})
+}
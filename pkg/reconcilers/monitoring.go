@@ -0,0 +1,96 @@
+package reconcilers
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+)
+
+// MonitoringSpecChecksumAnnotation holds the checksum of the spec last applied by the operator
+// to the PrometheusRules and GrafanaDashboards.
+// When the spec no longer matches the checksum, the object has been modified out of the operator
+// and it is considered owned by the user, so it is not updated anymore.
+// Objects without the annotation are considered owned by the user when their spec differs from the desired one.
+// Deleting the object gives it back to the operator
+const MonitoringSpecChecksumAnnotation = "apps.3scale.net/monitoring-spec-checksum"
+
+// MonitoringSpecChecksum returns the checksum of the spec of a monitoring object
+func MonitoringSpecChecksum(spec interface{}) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// SetMonitoringSpecChecksum annotates the object with the checksum of its spec
+func SetMonitoringSpecChecksum(obj common.KubernetesObject, spec interface{}) error {
+	checksum, err := MonitoringSpecChecksum(spec)
+	if err != nil {
+		return err
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[MonitoringSpecChecksumAnnotation] = checksum
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// isUserOwnedMonitoringObject returns true when the spec of the existing object
+// does not match the checksum of the spec last applied by the operator.
+// Without checksum, i.e. objects created by previous releases of the operator or by the user,
+// the spec of the existing object is compared with the desired one
+func isUserOwnedMonitoringObject(existing common.KubernetesObject, existingSpec, desiredSpec interface{}) (bool, error) {
+	checksum, err := MonitoringSpecChecksum(existingSpec)
+	if err != nil {
+		return false, err
+	}
+
+	appliedChecksum, ok := existing.GetAnnotations()[MonitoringSpecChecksumAnnotation]
+	if !ok {
+		appliedChecksum, err = MonitoringSpecChecksum(desiredSpec)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return checksum != appliedChecksum, nil
+}
+
+// monitoringObjectMetaMutator ensures the desired labels and checksum annotation
+// are set in the existing object. Labels added by the user are kept
+func monitoringObjectMetaMutator(existing, desired common.KubernetesObject) bool {
+	updated := false
+
+	existingLabels := existing.GetLabels()
+	if existingLabels == nil {
+		existingLabels = map[string]string{}
+	}
+	for key, value := range desired.GetLabels() {
+		if existingValue, ok := existingLabels[key]; !ok || existingValue != value {
+			existingLabels[key] = value
+			updated = true
+		}
+	}
+	if updated {
+		existing.SetLabels(existingLabels)
+	}
+
+	desiredChecksum, ok := desired.GetAnnotations()[MonitoringSpecChecksumAnnotation]
+	if ok && existing.GetAnnotations()[MonitoringSpecChecksumAnnotation] != desiredChecksum {
+		existingAnnotations := existing.GetAnnotations()
+		if existingAnnotations == nil {
+			existingAnnotations = map[string]string{}
+		}
+		existingAnnotations[MonitoringSpecChecksumAnnotation] = desiredChecksum
+		existing.SetAnnotations(existingAnnotations)
+		updated = true
+	}
+
+	return updated
+}
@@ -0,0 +1,336 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ManagedEnvVarsAnnotationPrefix, followed by the container name, lists the
+	// environment variables set by the operator in the last reconciliation.
+	// They are removed when no longer desired
+	ManagedEnvVarsAnnotationPrefix = "apps.3scale.net/managed-env-vars."
+	// ManagedVolumesAnnotation lists the volumes set by the operator in the
+	// last reconciliation. They are removed when no longer desired
+	ManagedVolumesAnnotation = "apps.3scale.net/managed-volumes"
+)
+
+// DeploymentConfigEnvAndVolumesMutator wraps a DeploymentConfig mutator
+// reconciling also the environment variables and volumes of the given containers
+func DeploymentConfigEnvAndVolumesMutator(mutateFn MutateFn, containerNames ...string) MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		update, err := mutateFn(existingObj, desiredObj)
+		if err != nil {
+			return false, err
+		}
+
+		existing, ok := existingObj.(*appsv1.DeploymentConfig)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *appsv1.DeploymentConfig", existingObj)
+		}
+		desired, ok := desiredObj.(*appsv1.DeploymentConfig)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *appsv1.DeploymentConfig", desiredObj)
+		}
+
+		for _, containerName := range containerNames {
+			tmpUpdate := DeploymentConfigContainerEnvAndVolumesReconciler(desired, existing, containerName)
+			update = update || tmpUpdate
+		}
+
+		return update, nil
+	}
+}
+
+// DeploymentEnvAndVolumesMutator wraps a Deployment mutator
+// reconciling also the environment variables and volumes of the given containers
+func DeploymentEnvAndVolumesMutator(mutateFn MutateFn, containerNames ...string) MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		update, err := mutateFn(existingObj, desiredObj)
+		if err != nil {
+			return false, err
+		}
+
+		existing, ok := existingObj.(*k8sappsv1.Deployment)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", existingObj)
+		}
+		desired, ok := desiredObj.(*k8sappsv1.Deployment)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", desiredObj)
+		}
+
+		for _, containerName := range containerNames {
+			tmpUpdate := DeploymentContainerEnvAndVolumesReconciler(desired, existing, containerName)
+			update = update || tmpUpdate
+		}
+
+		return update, nil
+	}
+}
+
+// DeploymentConfigContainerEnvAndVolumesReconciler reconciles the environment
+// variables and volume mounts of the given container and the pod volumes.
+// Environment variables and volumes added manually are kept
+func DeploymentConfigContainerEnvAndVolumesReconciler(desired, existing *appsv1.DeploymentConfig, containerName string) bool {
+	if desired.Spec.Template == nil || existing.Spec.Template == nil {
+		return false
+	}
+	return PodTemplateContainerEnvAndVolumesReconciler(desired.Spec.Template, existing.Spec.Template, existing, containerName)
+}
+
+// DeploymentContainerEnvAndVolumesReconciler reconciles the environment
+// variables and volume mounts of the given container and the pod volumes.
+// Environment variables and volumes added manually are kept
+func DeploymentContainerEnvAndVolumesReconciler(desired, existing *k8sappsv1.Deployment, containerName string) bool {
+	return PodTemplateContainerEnvAndVolumesReconciler(&desired.Spec.Template, &existing.Spec.Template, existing, containerName)
+}
+
+// PodTemplateContainerEnvAndVolumesReconciler adds or updates the desired
+// environment variables, volumes and volume mounts of a container or init
+// container. The names set are recorded in annotations of the existing
+// workload, so the ones no longer desired are removed in later reconciliations
+func PodTemplateContainerEnvAndVolumesReconciler(desired, existing *v1.PodTemplateSpec, existingWorkload metav1.Object, containerName string) bool {
+	desiredContainer := findContainer(&desired.Spec, containerName)
+	existingContainer := findContainer(&existing.Spec, containerName)
+	if desiredContainer == nil || existingContainer == nil {
+		return false
+	}
+
+	workloadInfo := fmt.Sprintf("%s/%s", existingWorkload.GetNamespace(), existingWorkload.GetName())
+	update := false
+
+	desiredEnvNames := []string{}
+	for idx := range desiredContainer.Env {
+		desiredEnvNames = append(desiredEnvNames, desiredContainer.Env[idx].Name)
+	}
+	envAnnotation := ManagedEnvVarsAnnotationPrefix + containerName
+	previousEnvNames := managedNames(existingWorkload, envAnnotation)
+
+	env, envUpdate := reconcileEnv(desiredContainer.Env, existingContainer.Env, previousEnvNames)
+	if envUpdate {
+		log.Info(fmt.Sprintf("%s container %s env has changed", workloadInfo, containerName))
+		existingContainer.Env = env
+		update = true
+	}
+
+	desiredVolumeNames := []string{}
+	for idx := range desired.Spec.Volumes {
+		desiredVolumeNames = append(desiredVolumeNames, desired.Spec.Volumes[idx].Name)
+	}
+	previousVolumeNames := managedNames(existingWorkload, ManagedVolumesAnnotation)
+
+	volumes, volumesUpdate := reconcileVolumes(desired.Spec.Volumes, existing.Spec.Volumes, previousVolumeNames)
+	if volumesUpdate {
+		log.Info(fmt.Sprintf("%s spec.template.spec.volumes have changed", workloadInfo))
+		existing.Spec.Volumes = volumes
+		update = true
+	}
+
+	volumeMounts, volumeMountsUpdate := reconcileVolumeMounts(desiredContainer.VolumeMounts, existingContainer.VolumeMounts, previousVolumeNames)
+	if volumeMountsUpdate {
+		log.Info(fmt.Sprintf("%s container %s volumeMounts have changed", workloadInfo, containerName))
+		existingContainer.VolumeMounts = volumeMounts
+		update = true
+	}
+
+	tmpUpdate := setManagedNames(existingWorkload, envAnnotation, desiredEnvNames)
+	update = update || tmpUpdate
+
+	tmpUpdate = setManagedNames(existingWorkload, ManagedVolumesAnnotation, desiredVolumeNames)
+	update = update || tmpUpdate
+
+	return update
+}
+
+func reconcileEnv(desired, existing []v1.EnvVar, previousNames map[string]bool) ([]v1.EnvVar, bool) {
+	desiredByName := map[string]v1.EnvVar{}
+	for _, envVar := range desired {
+		desiredByName[envVar.Name] = envVarWithDefaults(envVar)
+	}
+
+	update := false
+	result := []v1.EnvVar{}
+	existingNames := map[string]bool{}
+	for _, envVar := range existing {
+		desiredEnvVar, ok := desiredByName[envVar.Name]
+		if !ok {
+			if previousNames[envVar.Name] {
+				update = true
+				continue
+			}
+			result = append(result, envVar)
+			continue
+		}
+
+		existingNames[envVar.Name] = true
+		if !reflect.DeepEqual(desiredEnvVar, envVar) {
+			update = true
+		}
+		result = append(result, desiredEnvVar)
+	}
+
+	for _, envVar := range desired {
+		if !existingNames[envVar.Name] {
+			result = append(result, desiredByName[envVar.Name])
+			update = true
+		}
+	}
+
+	return result, update
+}
+
+func reconcileVolumes(desired, existing []v1.Volume, previousNames map[string]bool) ([]v1.Volume, bool) {
+	desiredByName := map[string]v1.Volume{}
+	for _, volume := range desired {
+		desiredByName[volume.Name] = volumeWithDefaults(volume)
+	}
+
+	update := false
+	result := []v1.Volume{}
+	existingNames := map[string]bool{}
+	for _, volume := range existing {
+		desiredVolume, ok := desiredByName[volume.Name]
+		if !ok {
+			if previousNames[volume.Name] {
+				update = true
+				continue
+			}
+			result = append(result, volume)
+			continue
+		}
+
+		existingNames[volume.Name] = true
+		if !reflect.DeepEqual(desiredVolume, volume) {
+			update = true
+		}
+		result = append(result, desiredVolume)
+	}
+
+	for _, volume := range desired {
+		if !existingNames[volume.Name] {
+			result = append(result, desiredByName[volume.Name])
+			update = true
+		}
+	}
+
+	return result, update
+}
+
+// reconcileVolumeMounts matches volume mounts by volume name,
+// volumes are mounted once in the operator managed containers
+func reconcileVolumeMounts(desired, existing []v1.VolumeMount, previousNames map[string]bool) ([]v1.VolumeMount, bool) {
+	desiredByName := map[string]v1.VolumeMount{}
+	for _, volumeMount := range desired {
+		desiredByName[volumeMount.Name] = volumeMount
+	}
+
+	update := false
+	result := []v1.VolumeMount{}
+	existingNames := map[string]bool{}
+	for _, volumeMount := range existing {
+		desiredVolumeMount, ok := desiredByName[volumeMount.Name]
+		if !ok {
+			if previousNames[volumeMount.Name] {
+				update = true
+				continue
+			}
+			result = append(result, volumeMount)
+			continue
+		}
+
+		existingNames[volumeMount.Name] = true
+		if !reflect.DeepEqual(desiredVolumeMount, volumeMount) {
+			update = true
+		}
+		result = append(result, desiredVolumeMount)
+	}
+
+	for _, volumeMount := range desired {
+		if !existingNames[volumeMount.Name] {
+			result = append(result, volumeMount)
+			update = true
+		}
+	}
+
+	return result, update
+}
+
+// envVarWithDefaults sets the defaults applied by the API server,
+// otherwise desired and existing environment variables never match
+func envVarWithDefaults(envVar v1.EnvVar) v1.EnvVar {
+	result := *envVar.DeepCopy()
+	if result.ValueFrom != nil && result.ValueFrom.FieldRef != nil && result.ValueFrom.FieldRef.APIVersion == "" {
+		result.ValueFrom.FieldRef.APIVersion = "v1"
+	}
+	return result
+}
+
+// volumeWithDefaults sets the defaults applied by the API server,
+// otherwise desired and existing volumes never match
+func volumeWithDefaults(volume v1.Volume) v1.Volume {
+	result := *volume.DeepCopy()
+	if result.ConfigMap != nil && result.ConfigMap.DefaultMode == nil {
+		result.ConfigMap.DefaultMode = &[]int32{v1.ConfigMapVolumeSourceDefaultMode}[0]
+	}
+	if result.Secret != nil && result.Secret.DefaultMode == nil {
+		result.Secret.DefaultMode = &[]int32{v1.SecretVolumeSourceDefaultMode}[0]
+	}
+	if result.Projected != nil && result.Projected.DefaultMode == nil {
+		result.Projected.DefaultMode = &[]int32{v1.ProjectedVolumeSourceDefaultMode}[0]
+	}
+	return result
+}
+
+func findContainer(podSpec *v1.PodSpec, name string) *v1.Container {
+	for idx := range podSpec.Containers {
+		if podSpec.Containers[idx].Name == name {
+			return &podSpec.Containers[idx]
+		}
+	}
+	for idx := range podSpec.InitContainers {
+		if podSpec.InitContainers[idx].Name == name {
+			return &podSpec.InitContainers[idx]
+		}
+	}
+	return nil
+}
+
+func managedNames(obj metav1.Object, annotation string) map[string]bool {
+	names := map[string]bool{}
+	value, ok := obj.GetAnnotations()[annotation]
+	if !ok || value == "" {
+		return names
+	}
+	for _, name := range strings.Split(value, ",") {
+		names[name] = true
+	}
+	return names
+}
+
+func setManagedNames(obj metav1.Object, annotation string, names []string) bool {
+	sortedNames := append([]string{}, names...)
+	sort.Strings(sortedNames)
+	value := strings.Join(sortedNames, ",")
+
+	annotations := obj.GetAnnotations()
+	if existingValue, ok := annotations[annotation]; ok && existingValue == value {
+		return false
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[annotation] = value
+	obj.SetAnnotations(annotations)
+	return true
+}
@@ -0,0 +1,102 @@
+package reconcilers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentContainerEnvAndVolumesReconciler(t *testing.T) {
+	deploymentFactory := func(annotations map[string]string, env []corev1.EnvVar, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "myDeployment",
+				Namespace:   "myNS",
+				Annotations: annotations,
+			},
+			Spec: k8sappsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: volumes,
+						Containers: []corev1.Container{
+							{Name: "myContainer", Env: env, VolumeMounts: volumeMounts},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	configMapVolume := func(name string) corev1.Volume {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+					DefaultMode:          &[]int32{corev1.ConfigMapVolumeSourceDefaultMode}[0],
+				},
+			},
+		}
+	}
+
+	desiredVolume := configMapVolume("added")
+	// Defaulted by the API server
+	desiredVolume.ConfigMap.DefaultMode = nil
+	desired := deploymentFactory(nil,
+		[]corev1.EnvVar{{Name: "A", Value: "new"}, {Name: "B", Value: "1"}},
+		[]corev1.Volume{desiredVolume},
+		[]corev1.VolumeMount{{Name: "added", MountPath: "/added"}},
+	)
+
+	expected := deploymentFactory(
+		map[string]string{
+			ManagedEnvVarsAnnotationPrefix + "myContainer": "A,B",
+			ManagedVolumesAnnotation:                       "added",
+		},
+		[]corev1.EnvVar{{Name: "A", Value: "new"}, {Name: "MANUAL", Value: "1"}, {Name: "B", Value: "1"}},
+		[]corev1.Volume{configMapVolume("manual"), configMapVolume("added")},
+		[]corev1.VolumeMount{{Name: "manual", MountPath: "/manual"}, {Name: "added", MountPath: "/added"}},
+	)
+
+	cases := []struct {
+		testName    string
+		annotations map[string]string
+	}{
+		{"ContainerAnnotation", map[string]string{
+			ManagedEnvVarsAnnotationPrefix + "myContainer": "A,REMOVED",
+			ManagedVolumesAnnotation:                       "removed",
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := deploymentFactory(tc.annotations,
+				[]corev1.EnvVar{{Name: "A", Value: "old"}, {Name: "REMOVED", Value: "1"}, {Name: "MANUAL", Value: "1"}},
+				[]corev1.Volume{configMapVolume("removed"), configMapVolume("manual")},
+				[]corev1.VolumeMount{{Name: "removed", MountPath: "/removed"}, {Name: "manual", MountPath: "/manual"}},
+			)
+
+			update := DeploymentContainerEnvAndVolumesReconciler(desired.DeepCopy(), existing, "myContainer")
+			if !update {
+				subT.Fatal("when desired changed, expected update")
+			}
+
+			if !reflect.DeepEqual(existing, expected) {
+				subT.Fatalf("reconciliation failed: %s", cmp.Diff(existing, expected))
+			}
+
+			update = DeploymentContainerEnvAndVolumesReconciler(desired.DeepCopy(), existing, "myContainer")
+			if update {
+				subT.Fatal("when nothing changed, expected no update")
+			}
+		})
+	}
+}
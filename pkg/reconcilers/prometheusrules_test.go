@@ -0,0 +1,151 @@
+package reconcilers
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func testDesiredPrometheusRule(t *testing.T) *monitoringv1.PrometheusRule {
+	desired := &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "rules",
+			Labels: map[string]string{"prometheus": "application-monitoring"},
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: "group",
+					Rules: []monitoringv1.Rule{
+						{
+							Alert:  "SomeAlert",
+							Expr:   intstr.FromString("up == 0"),
+							For:    "1m",
+							Labels: map[string]string{"severity": "critical"},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := SetMonitoringSpecChecksum(desired, desired.Spec); err != nil {
+		t.Fatal(err)
+	}
+	return desired
+}
+
+func TestGenericPrometheusRulesMutatorWhenCopied(t *testing.T) {
+	desired := testDesiredPrometheusRule(t)
+	existing := desired.DeepCopy()
+
+	update, err := GenericPrometheusRulesMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if update {
+		t.Fatal("when existing and desired are cloned, reconciler reported update needed")
+	}
+}
+
+func TestGenericPrometheusRulesMutatorWhenDiff(t *testing.T) {
+	desired := testDesiredPrometheusRule(t)
+	existing := desired.DeepCopy()
+	// applied by a previous operator version
+	existing.Spec.Groups[0].Rules[0].For = "5m"
+	if err := SetMonitoringSpecChecksum(existing, existing.Spec); err != nil {
+		t.Fatal(err)
+	}
+	existing.Labels["user"] = "label"
+	desired.Labels["extra"] = "label"
+
+	update, err := GenericPrometheusRulesMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !update {
+		t.Fatal("when existing and desired are different, reconciler reported not update needed")
+	}
+
+	if existing.Spec.Groups[0].Rules[0].For != "1m" {
+		t.Errorf("rule for does not match. got [%s], expected [1m]", existing.Spec.Groups[0].Rules[0].For)
+	}
+
+	if existing.Annotations[MonitoringSpecChecksumAnnotation] != desired.Annotations[MonitoringSpecChecksumAnnotation] {
+		t.Errorf("checksum annotation does not match. got [%s], expected [%s]",
+			existing.Annotations[MonitoringSpecChecksumAnnotation], desired.Annotations[MonitoringSpecChecksumAnnotation])
+	}
+
+	for _, label := range []string{"prometheus", "user", "extra"} {
+		if _, ok := existing.Labels[label]; !ok {
+			t.Errorf("label %s not found in %v", label, existing.Labels)
+		}
+	}
+}
+
+func TestGenericPrometheusRulesMutatorWhenUserOwned(t *testing.T) {
+	desired := testDesiredPrometheusRule(t)
+	existing := desired.DeepCopy()
+	existing.Spec.Groups[0].Rules[0].For = "5m"
+	desired.Spec.Groups[0].Rules[0].Labels["severity"] = "warning"
+	if err := SetMonitoringSpecChecksum(desired, desired.Spec); err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := GenericPrometheusRulesMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if update {
+		t.Fatal("when existing has been modified by the user, reconciler reported update needed")
+	}
+
+	if existing.Spec.Groups[0].Rules[0].For != "5m" {
+		t.Errorf("user modified rule for has been overridden. got [%s], expected [5m]", existing.Spec.Groups[0].Rules[0].For)
+	}
+}
+
+func TestGenericPrometheusRulesMutatorWithoutChecksum(t *testing.T) {
+	cases := []struct {
+		testName       string
+		existingFor    string
+		expectedUpdate bool
+		expectedFor    string
+	}{
+		// created by a previous operator version
+		{"sameSpec", "1m", true, "1m"},
+		// tuned by the user
+		{"differentSpec", "5m", false, "5m"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			desired := testDesiredPrometheusRule(subT)
+			existing := desired.DeepCopy()
+			existing.Annotations = nil
+			existing.Spec.Groups[0].Rules[0].For = tc.existingFor
+
+			update, err := GenericPrometheusRulesMutator(existing, desired)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if update != tc.expectedUpdate {
+				subT.Errorf("update does not match. got [%t], expected [%t]", update, tc.expectedUpdate)
+			}
+
+			if existing.Spec.Groups[0].Rules[0].For != tc.expectedFor {
+				subT.Errorf("rule for does not match. got [%s], expected [%s]", existing.Spec.Groups[0].Rules[0].For, tc.expectedFor)
+			}
+
+			_, annotated := existing.Annotations[MonitoringSpecChecksumAnnotation]
+			if annotated != tc.expectedUpdate {
+				subT.Errorf("checksum annotation set does not match. got [%t], expected [%t]", annotated, tc.expectedUpdate)
+			}
+		})
+	}
+}
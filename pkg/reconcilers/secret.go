@@ -61,3 +61,24 @@ func SecretReconcileField(desired, existing *v1.Secret, fieldName string) bool {
 	}
 	return updated
 }
+
+// SecretStringDataMutator reconciles the fields of the desired secret,
+// fields only present in the existing secret are kept
+func SecretStringDataMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*v1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", existingObj)
+	}
+	desired, ok := desiredObj.(*v1.Secret)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Secret", desiredObj)
+	}
+
+	updated := false
+	for fieldName := range desired.StringData {
+		tmpUpdated := SecretReconcileField(desired, existing, fieldName)
+		updated = updated || tmpUpdated
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,36 @@
+package reconcilers
+
+import (
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// UnstructuredSpecMutator reconciles the spec of unstructured objects.
+// Fields not set in the desired spec are ignored, so values defaulted
+// by the API server do not trigger updates
+func UnstructuredSpecMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*unstructured.Unstructured)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *unstructured.Unstructured", existingObj)
+	}
+	desired, ok := desiredObj.(*unstructured.Unstructured)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *unstructured.Unstructured", desiredObj)
+	}
+
+	updated := false
+
+	if !equality.Semantic.DeepDerivative(desired.Object["spec"], existing.Object["spec"]) {
+		diff := cmp.Diff(existing.Object["spec"], desired.Object["spec"])
+		log.V(1).Info(fmt.Sprintf("%s spec has changed: %s", common.ObjectInfo(desired), diff))
+		existing.Object["spec"] = desired.Object["spec"]
+		updated = true
+	}
+
+	return updated, nil
+}
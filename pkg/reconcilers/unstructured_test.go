@@ -0,0 +1,64 @@
+package reconcilers
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestUnstructuredSpecMutator(t *testing.T) {
+	objFactory := func(spec map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		obj.SetAPIVersion("networking.k8s.io/v1")
+		obj.SetKind("Ingress")
+		obj.SetName("myIngress")
+		obj.SetNamespace("myNS")
+		return obj
+	}
+
+	desiredSpec := func() map[string]interface{} {
+		return map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{"host": "example.com"},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName       string
+		existingSpec   map[string]interface{}
+		expectedResult bool
+	}{
+		{"NothingToReconcile", desiredSpec(), false},
+		{"ServerDefaults", map[string]interface{}{
+			"ingressClassName": "default",
+			"rules": []interface{}{
+				map[string]interface{}{"host": "example.com"},
+			},
+		}, false},
+		{"HostChanged", map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{"host": "other.example.com"},
+			},
+		}, true},
+		{"NoSpec", nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := objFactory(tc.existingSpec)
+			desired := objFactory(desiredSpec())
+			update, err := UnstructuredSpecMutator(existing, desired)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if update && !reflect.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+				subT.Fatalf("spec reconciliation failed, existing: %v, desired: %v", existing.Object["spec"], desired.Object["spec"])
+			}
+		})
+	}
+}
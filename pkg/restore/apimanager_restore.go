@@ -49,14 +49,25 @@ var configMapsToRestore map[string]string = map[string]string{
 	"APIcastEnvironment": "apicast-environment",
 }
 
-func (b *APIManagerRestore) restoreSourcePVCContainerVolumeMount() v1.VolumeMount {
+func (b *APIManagerRestore) restoreSourceContainerVolumeMount() v1.VolumeMount {
+	if b.options.APIManagerRestoreS3Options != nil {
+		return v1.VolumeMount{
+			Name:      backup.S3DataVolumeName,
+			MountPath: RestorePVCMountPath,
+		}
+	}
+
 	return v1.VolumeMount{
 		Name:      b.options.APIManagerRestorePVCOptions.PersistentVolumeClaimVolumeSource.ClaimName,
 		MountPath: RestorePVCMountPath,
 	}
 }
 
-func (b *APIManagerRestore) restoreSourcePVCPodVolume() v1.Volume {
+func (b *APIManagerRestore) restoreSourcePodVolume() v1.Volume {
+	if b.options.APIManagerRestoreS3Options != nil {
+		return backup.S3DataPodVolume()
+	}
+
 	return v1.Volume{
 		Name: b.options.APIManagerRestorePVCOptions.PersistentVolumeClaimVolumeSource.ClaimName,
 		VolumeSource: v1.VolumeSource{
@@ -65,6 +76,35 @@ func (b *APIManagerRestore) restoreSourcePVCPodVolume() v1.Volume {
 	}
 }
 
+// withS3Download adds an init container to the job downloading the backup
+// data matching the include patterns from the S3 restore source. The job is
+// not changed when S3 is not the restore source
+func (b *APIManagerRestore) withS3Download(job *batchv1.Job, includes ...string) *batchv1.Job {
+	if b.options.APIManagerRestoreS3Options == nil {
+		return job
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	podSpec.InitContainers = append(podSpec.InitContainers, v1.Container{
+		Name:  "download-from-s3",
+		Image: b.options.S3CLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.options.APIManagerRestoreS3Options.DownloadContainerArgs(),
+		},
+		Env: b.options.APIManagerRestoreS3Options.DownloadContainerEnv(RestorePVCMountPath, includes...),
+		VolumeMounts: []v1.VolumeMount{
+			b.restoreSourceContainerVolumeMount(),
+		},
+	})
+
+	return job
+}
+
 func (b *APIManagerRestore) systemFileStoragePVCContainerVolumeMount() v1.VolumeMount {
 	return v1.VolumeMount{
 		Name:      component.SystemFileStoragePVCName,
@@ -84,7 +124,7 @@ func (b *APIManagerRestore) systemFileStoragePVCPodVolume() v1.Volume {
 }
 
 func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+	if b.options.APIManagerRestorePVCOptions == nil && b.options.APIManagerRestoreS3Options == nil {
 		return nil
 	}
 
@@ -94,7 +134,7 @@ func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -109,7 +149,7 @@ func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
+						b.restoreSourcePodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
@@ -125,7 +165,7 @@ func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
+								b.restoreSourceContainerVolumeMount(),
 							},
 						},
 					},
@@ -135,10 +175,12 @@ func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job
 			},
 		},
 	}
+
+	return b.withS3Download(job, "secrets/*", "configmaps/*")
 }
 
 func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+	if b.options.APIManagerRestorePVCOptions == nil && b.options.APIManagerRestoreS3Options == nil {
 		return nil
 	}
 
@@ -148,7 +190,7 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -163,7 +205,7 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
+						b.restoreSourcePodVolume(),
 						b.systemFileStoragePVCPodVolume(),
 					},
 					Containers: []v1.Container{
@@ -180,7 +222,7 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
+								b.restoreSourceContainerVolumeMount(),
 								b.systemFileStoragePVCContainerVolumeMount(),
 							},
 						},
@@ -191,10 +233,71 @@ func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job
 			},
 		},
 	}
+
+	return b.withS3Download(job, backup.SystemFileStorageArchiveFileName)
+}
+
+// RestoreDatabasesJob returns the job loading the dumps of the databases
+// deployed by the APIManager. Databases without a dump in the restore
+// source, i.e. when the backed up APIManager used external databases,
+// are not loaded
+func (b *APIManagerRestore) RestoreDatabasesJob() *batchv1.Job {
+	if b.options.APIManagerRestorePVCOptions == nil && b.options.APIManagerRestoreS3Options == nil {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("restore-databases", b.options.APIManagerRestoreUID)
+	if err != nil {
+		panic(err)
+	}
+
+	var completions int32 = 1
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: b.options.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			// TODO BackoffLimit field controls how many times the job is retried
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						b.restoreSourcePodVolume(),
+					},
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "restore-databases",
+							Image: b.options.OCCLIImageURL,
+							Command: []string{
+								"/bin/bash",
+							},
+							Args: []string{
+								"-c",
+								"-e",
+								b.restoreDatabasesContainerArgs(),
+							},
+							VolumeMounts: []v1.VolumeMount{
+								b.restoreSourceContainerVolumeMount(),
+							},
+						},
+					},
+					ServiceAccountName: "3scale-operator",     // TODO create our own SA, Role and RoleBinding to do just what we need
+					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+				},
+			},
+		},
+	}
+
+	return b.withS3Download(job, fmt.Sprintf("%s/*", backup.DatabaseDumpsSubdir))
 }
 
 func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+	if b.options.APIManagerRestorePVCOptions == nil && b.options.APIManagerRestoreS3Options == nil {
 		return nil
 	}
 
@@ -204,7 +307,7 @@ func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
 	}
 
 	var completions int32 = 1
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "batch/v1",
 			Kind:       "Job",
@@ -219,7 +322,7 @@ func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
 			Template: v1.PodTemplateSpec{
 				Spec: v1.PodSpec{
 					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
+						b.restoreSourcePodVolume(),
 					},
 					Containers: []v1.Container{
 						v1.Container{
@@ -235,7 +338,7 @@ func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
 							},
 							//Env: []v1.EnvVar{},
 							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
+								b.restoreSourceContainerVolumeMount(),
 							},
 						},
 					},
@@ -245,10 +348,12 @@ func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
 			},
 		},
 	}
+
+	return b.withS3Download(job, "apimanager/*")
 }
 
 func (b *APIManagerRestore) ZyncResyncDomainsJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+	if b.options.APIManagerRestorePVCOptions == nil && b.options.APIManagerRestoreS3Options == nil {
 		return nil
 	}
 
@@ -336,6 +441,21 @@ func (b *APIManagerRestore) restoreSystemFilestoragePVCContainerArgs() string {
 	// like reaching glob extension limit, or not taking into account dot files in the
 	// main directory etc...
 	// So it seems there's no "perfect" solution
+	if b.options.APIManagerRestoreS3Options != nil {
+		// For the same reason, attributes of the existing
+		// destination directory are not overwritten
+		return fmt.Sprintf(`
+	BASEPATH='%s';
+	SYSTEM_FILESTORAGE_PVC_DIR='%s'
+	SYSTEM_FILESTORAGE_ARCHIVE='%s'
+	tar -xzvf ${BASEPATH}/${SYSTEM_FILESTORAGE_ARCHIVE} -C ${SYSTEM_FILESTORAGE_PVC_DIR} --no-overwrite-dir --no-same-owner;
+`,
+			RestorePVCMountPath,
+			SystemFileStoragePVCMountPath,
+			backup.SystemFileStorageArchiveFileName,
+		)
+	}
+
 	return fmt.Sprintf(`
 	BASEPATH='%s';
 	SYSTEM_FILESTORAGE_PVC_DIR='%s'
@@ -404,6 +524,37 @@ func (b *APIManagerRestore) restoreSecretsAndConfigMapsContainerArgs() string {
 	)
 }
 
+func (b *APIManagerRestore) restoreDatabasesContainerArgs() string {
+	var loads strings.Builder
+	for _, database := range backup.AllInternalDatabases() {
+		fmt.Fprintf(&loads, `
+if [ -f ${DATABASES_SUBDIR}/%s ]; then
+	POD=$(database_pod %s);
+	echo "Loading database %s into pod ${POD}";
+	oc exec -i ${POD} -c %s -- %s < ${DATABASES_SUBDIR}/%s;
+fi;
+`,
+			database.DumpFileName(),
+			database.Name,
+			database.Name,
+			database.Container,
+			databaseLoadCommand(database),
+			database.DumpFileName(),
+		)
+	}
+
+	return fmt.Sprintf(`
+BASEPATH='%s';
+DATABASES_SUBDIR="${BASEPATH}/%s";
+%s
+%s`,
+		RestorePVCMountPath,
+		backup.DatabaseDumpsSubdir,
+		backup.DatabasePodShellFunction,
+		loads.String(),
+	)
+}
+
 func (b *APIManagerRestore) zyncResyncDomainsContainerArgs() string {
 	return fmt.Sprintf(`
 	dcname="system-sidekiq"
@@ -0,0 +1,126 @@
+package restore
+
+import (
+	"reflect"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+// databaseLoadCommand returns the command run in the database
+// container loading the dump read from the standard input
+func databaseLoadCommand(database backup.InternalDatabase) string {
+	switch database.Kind {
+	case backup.InternalDatabaseKindMySQL:
+		return `/bin/sh -i -c 'MYSQL_PWD="${MYSQL_ROOT_PASSWORD}" mysql -u root'`
+	case backup.InternalDatabaseKindPostgreSQL:
+		return `/bin/sh -i -c 'PGPASSWORD="${POSTGRESQL_PASSWORD}" psql -h 127.0.0.1 -U ${POSTGRESQL_USER} -d ${POSTGRESQL_DATABASE} -q -v ON_ERROR_STOP=1'`
+	default:
+		// Redis is configured with append only file persistence, so a
+		// RDB snapshot placed in the data directory would not be loaded on
+		// restart. Instead, the snapshot is served by a temporary Redis
+		// server which the database replicates from
+		return `container-entrypoint bash -c '
+set -e;
+cat > /var/lib/redis/data/restore.rdb;
+redis-server --port 6380 --dir /var/lib/redis/data --dbfilename restore.rdb --appendonly no --save "" --daemonize yes;
+until redis-cli -p 6380 ping | grep -q PONG; do sleep 1; done;
+redis-cli slaveof 127.0.0.1 6380;
+until redis-cli info replication | grep -q master_link_status:up; do sleep 1; done;
+redis-cli slaveof no one;
+redis-cli -p 6380 shutdown nosave || true;
+rm -f /var/lib/redis/data/restore.rdb'`
+	}
+}
+
+// databaseClientsReplicas returns the replicas and autoscaling fields of
+// the components connecting to the internal databases
+func databaseClientsReplicas(apimanager *appsv1alpha1.APIManager) ([]**int64, []**appsv1alpha1.AutoscalingSpec) {
+	replicas := []**int64{}
+	autoscaling := []**appsv1alpha1.AutoscalingSpec{}
+
+	if backend := apimanager.Spec.Backend; backend != nil {
+		if backend.ListenerSpec != nil {
+			replicas = append(replicas, &backend.ListenerSpec.Replicas)
+			autoscaling = append(autoscaling, &backend.ListenerSpec.Autoscaling)
+		}
+		if backend.WorkerSpec != nil {
+			replicas = append(replicas, &backend.WorkerSpec.Replicas)
+			autoscaling = append(autoscaling, &backend.WorkerSpec.Autoscaling)
+		}
+		if backend.CronSpec != nil {
+			replicas = append(replicas, &backend.CronSpec.Replicas)
+		}
+	}
+
+	if system := apimanager.Spec.System; system != nil {
+		if system.AppSpec != nil {
+			replicas = append(replicas, &system.AppSpec.Replicas)
+			autoscaling = append(autoscaling, &system.AppSpec.Autoscaling)
+		}
+		if system.SidekiqSpec != nil {
+			replicas = append(replicas, &system.SidekiqSpec.Replicas)
+		}
+	}
+
+	if zync := apimanager.Spec.Zync; zync != nil {
+		if zync.AppSpec != nil {
+			replicas = append(replicas, &zync.AppSpec.Replicas)
+		}
+		if zync.QueSpec != nil {
+			replicas = append(replicas, &zync.QueSpec.Replicas)
+		}
+	}
+
+	return replicas, autoscaling
+}
+
+// ScaleDownDatabaseClients sets to zero the replicas of the components
+// connecting to the internal databases, so the databases can be loaded
+// before they start. Autoscaling of those components is disabled
+func ScaleDownDatabaseClients(apimanager *appsv1alpha1.APIManager) error {
+	// Component specs are set so none of them get the default replicas
+	if _, err := apimanager.SetDefaults(); err != nil {
+		return err
+	}
+
+	replicas, autoscaling := databaseClientsReplicas(apimanager)
+	for _, field := range replicas {
+		var zero int64 = 0
+		*field = &zero
+	}
+	for _, field := range autoscaling {
+		*field = nil
+	}
+
+	return nil
+}
+
+// ScaleUpDatabaseClients sets the replicas and autoscaling of the components
+// connecting to the internal databases back to the ones in the backed up
+// APIManager. Returns true when the existing APIManager has been changed
+func ScaleUpDatabaseClients(existing, backedUp *appsv1alpha1.APIManager) (bool, error) {
+	desired := backedUp.DeepCopy()
+	if _, err := desired.SetDefaults(); err != nil {
+		return false, err
+	}
+
+	existingReplicas, existingAutoscaling := databaseClientsReplicas(existing)
+	desiredReplicas, desiredAutoscaling := databaseClientsReplicas(desired)
+
+	changed := false
+	for idx := range existingReplicas {
+		if idx < len(desiredReplicas) && !reflect.DeepEqual(*existingReplicas[idx], *desiredReplicas[idx]) {
+			*existingReplicas[idx] = *desiredReplicas[idx]
+			changed = true
+		}
+	}
+	for idx := range existingAutoscaling {
+		if idx < len(desiredAutoscaling) && !reflect.DeepEqual(*existingAutoscaling[idx], *desiredAutoscaling[idx]) {
+			*existingAutoscaling[idx] = *desiredAutoscaling[idx]
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
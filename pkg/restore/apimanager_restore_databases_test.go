@@ -0,0 +1,107 @@
+package restore
+
+import (
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScaleDatabaseClients(t *testing.T) {
+	var twoReplicas int64 = 2
+	backedUp := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager"},
+		Spec: appsv1alpha1.APIManagerSpec{
+			Backend: &appsv1alpha1.BackendSpec{
+				WorkerSpec: &appsv1alpha1.BackendWorkerSpec{Replicas: &twoReplicas},
+			},
+			System: &appsv1alpha1.SystemSpec{
+				AppSpec: &appsv1alpha1.SystemAppSpec{
+					Autoscaling: &appsv1alpha1.AutoscalingSpec{MaxReplicas: 4},
+				},
+			},
+		},
+	}
+
+	restored := backedUp.DeepCopy()
+	if err := ScaleDownDatabaseClients(restored); err != nil {
+		t.Fatal(err)
+	}
+
+	replicas, autoscaling := databaseClientsReplicas(restored)
+	if len(replicas) != 7 {
+		t.Fatalf("expected 7 scaled down components, got %d", len(replicas))
+	}
+	for idx, field := range replicas {
+		if *field == nil || **field != 0 {
+			t.Errorf("component %d: expected zero replicas, got %v", idx, *field)
+		}
+	}
+	for idx, field := range autoscaling {
+		if *field != nil {
+			t.Errorf("component %d: expected autoscaling disabled, got %v", idx, *field)
+		}
+	}
+	if restored.Spec.Apicast.ProductionSpec.Replicas == nil || *restored.Spec.Apicast.ProductionSpec.Replicas != 1 {
+		t.Errorf("expected apicast production not to be scaled down, got %v", restored.Spec.Apicast.ProductionSpec.Replicas)
+	}
+
+	changed, err := ScaleUpDatabaseClients(restored, backedUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected scaled up APIManager to change")
+	}
+	if *restored.Spec.Backend.WorkerSpec.Replicas != 2 {
+		t.Errorf("expected backend worker replicas 2, got %d", *restored.Spec.Backend.WorkerSpec.Replicas)
+	}
+	if *restored.Spec.Zync.QueSpec.Replicas != 1 {
+		t.Errorf("expected zync que default replicas, got %d", *restored.Spec.Zync.QueSpec.Replicas)
+	}
+	if restored.Spec.System.AppSpec.Autoscaling == nil || restored.Spec.System.AppSpec.Autoscaling.MaxReplicas != 4 {
+		t.Errorf("expected system app autoscaling restored, got %v", restored.Spec.System.AppSpec.Autoscaling)
+	}
+
+	changed, err = ScaleUpDatabaseClients(restored, backedUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Error("expected already scaled up APIManager not to change")
+	}
+}
+
+func TestRestoreDatabasesJob(t *testing.T) {
+	options := &APIManagerRestoreOptions{
+		Namespace:             "3scale",
+		APIManagerRestoreName: "myrestore",
+		APIManagerRestoreUID:  "c2a3d4bc-5e3d-4c47-a5b2-7fc9b0ae4a5d",
+		APIManagerRestorePVCOptions: &APIManagerRestorePVCOptions{
+			PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{ClaimName: "apimanager-backup-mybackup"},
+		},
+		OCCLIImageURL: "oc-cli",
+		S3CLIImageURL: "s3-cli",
+	}
+
+	job := NewAPIManagerRestore(options).RestoreDatabasesJob()
+	if job == nil {
+		t.Fatal("expected databases restore job")
+	}
+
+	script := job.Spec.Template.Spec.Containers[0].Args[2]
+	for _, expected := range []string{
+		"if [ -f ${DATABASES_SUBDIR}/system-mysql.sql ]; then",
+		"oc exec -i ${POD} -c system-mysql -- /bin/sh -i -c 'MYSQL_PWD=\"${MYSQL_ROOT_PASSWORD}\" mysql -u root' < ${DATABASES_SUBDIR}/system-mysql.sql",
+		"if [ -f ${DATABASES_SUBDIR}/system-postgresql.sql ]; then",
+		"-c postgresql -- /bin/sh -i -c 'PGPASSWORD=\"${POSTGRESQL_PASSWORD}\" psql",
+		"redis-cli slaveof 127.0.0.1 6380",
+		"< ${DATABASES_SUBDIR}/backend-redis.rdb",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("restore script does not contain %q: %s", expected, script)
+		}
+	}
+}
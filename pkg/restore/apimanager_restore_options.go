@@ -1,6 +1,7 @@
 package restore
 
 import (
+	"github.com/3scale/3scale-operator/pkg/backup"
 	validator "github.com/go-playground/validator/v10"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -10,8 +11,10 @@ type APIManagerRestoreOptions struct {
 	APIManagerRestoreName string    `validate:"required"` // Name of the APIManagerRestore CR. NOT the backup or APIManager name
 	APIManagerRestoreUID  types.UID `validate:"required"` // UID of the APIManagerRestore CR
 
-	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions `validate:"required"`
-	OCCLIImageURL               string                       `validate:"required"`
+	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions   `validate:"required_without=APIManagerRestoreS3Options"`
+	APIManagerRestoreS3Options  *backup.S3ObjectStorageOptions `validate:"required_without=APIManagerRestorePVCOptions"`
+	OCCLIImageURL               string                         `validate:"required"`
+	S3CLIImageURL               string                         `validate:"required"`
 }
 
 func NewAPIManagerRestoreOptions() *APIManagerRestoreOptions {
@@ -5,6 +5,7 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,18 +29,28 @@ func (a *APIManagerRestoreOptionsProvider) Options() (*APIManagerRestoreOptions,
 	res.Namespace = a.APIManagerRestoreCR.Namespace
 
 	res.OCCLIImageURL = a.ocCLIImageURL()
+	res.S3CLIImageURL = a.s3CLIImageURL()
 
 	pvcOptions, err := a.pvcRestoreOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.s3RestoreOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerRestore struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one restore source has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one restore source can be specified")
+	}
 
 	res.APIManagerRestorePVCOptions = pvcOptions
+	res.APIManagerRestoreS3Options = s3Options
 
 	return res, res.Validate()
 }
@@ -55,6 +66,21 @@ func (a *APIManagerRestoreOptionsProvider) pvcRestoreOptions() (*APIManagerResto
 	return res, res.Validate()
 }
 
+func (a *APIManagerRestoreOptionsProvider) s3RestoreOptions() (*backup.S3ObjectStorageOptions, error) {
+	if a.APIManagerRestoreCR.Spec.RestoreSource.S3 == nil {
+		return nil, nil
+	}
+
+	// The prefix is the path of the backup data
+	res := backup.NewS3ObjectStorageOptions(a.APIManagerRestoreCR.Spec.RestoreSource.S3)
+
+	return res, res.Validate()
+}
+
 func (a *APIManagerRestoreOptionsProvider) ocCLIImageURL() string {
 	return helper.GetEnvVar("OC_CLI_IMAGE", component.OCCLIImageURL())
 }
+
+func (a *APIManagerRestoreOptionsProvider) s3CLIImageURL() string {
+	return helper.GetEnvVar("S3_CLI_IMAGE", component.S3CLIImageURL())
+}
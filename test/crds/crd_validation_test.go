@@ -20,13 +20,20 @@ import (
 // Missing fields path omissions
 const (
 	backupDestinationPVCResourceRequestsPath = "/spec/backupDestination/persistentVolumeClaim/resources/requests"
+	backupTemplatePVCResourceRequestsPath    = "/spec/backupTemplate/backupDestination/persistentVolumeClaim/resources/requests"
 	startTimePath                            = "/status/startTime"
 	completionTimePath                       = "/status/completionTime"
 	lastTransitionTimePath                   = "/status/conditions/lastTransitionTime"
+	productSmokeCheckLastCheckTimePath       = "/status/proxyConfigSmokeCheck/lastCheckTime"
+	backupRetentionMaxAgePath                = "/spec/retention/maxAge"
+	lastScheduleTimePath                     = "/status/lastScheduleTime"
+	lastSuccessfulTimePath                   = "/status/lastSuccessfulTime"
 	systemSharedPVCResourceRequestsPath      = "/spec/system/fileStorage/persistentVolumeClaim/resources/requests"
 	systemMySQLPVCResourceRequestsPath       = "/spec/system/database/mysql/persistentVolumeClaim/resources/requests"
 	systemPostgreSQLPVCResourceRequestsPath  = "/spec/system/database/postgresql/persistentVolumeClaim/resources/requests"
 	productPoliciesConfigurationPath         = "/spec/policies/configuration"
+	apicastProductionEnvDivisorPath          = "/spec/apicast/productionSpec/env/valueFrom/resourceFieldRef/divisor"
+	apicastStagingEnvDivisorPath             = "/spec/apicast/stagingSpec/env/valueFrom/resourceFieldRef/divisor"
 )
 
 func TestSampleCustomResources(t *testing.T) {
@@ -34,24 +41,47 @@ func TestSampleCustomResources(t *testing.T) {
 	samplesRoot := "../../config/samples"
 	// Map of CRD:CR_sample_prefix
 	crdCrMap := map[string]string{
-		"apps.3scale.net_apimanagers.yaml":        "apps_v1alpha1_apimanager_",
-		"apps.3scale.net_apimanagerbackups.yaml":  "apps_v1alpha1_apimanagerbackup.yaml",
-		"apps.3scale.net_apimanagerrestores.yaml": "apps_v1alpha1_apimanagerrestore.yaml",
-		"capabilities.3scale.net_tenants.yaml":    "capabilities_v1alpha1_tenant",
-		"capabilities.3scale.net_backends.yaml":   "capabilities_v1beta1_backend",
-		"capabilities.3scale.net_products.yaml":   "capabilities_v1beta1_product",
-		"capabilities.3scale.net_openapis.yaml":   "capabilities_v1beta1_openapi",
+		"apps.3scale.net_apimanagers.yaml":               "apps_v1alpha1_apimanager_",
+		"apps.3scale.net_apimanagerbackups.yaml":         "apps_v1alpha1_apimanagerbackup.yaml",
+		"apps.3scale.net_apimanagerbackupschedules.yaml": "apps_v1alpha1_apimanagerbackupschedule.yaml",
+		"apps.3scale.net_apimanagerrestores.yaml":        "apps_v1alpha1_apimanagerrestore.yaml",
+		"capabilities.3scale.net_backends.yaml":          "capabilities_v1beta1_backend",
+		"capabilities.3scale.net_products.yaml":          "capabilities_v1beta1_product",
+		"capabilities.3scale.net_openapis.yaml":          "capabilities_v1beta1_openapi",
+		"capabilities.3scale.net_developeraccounts.yaml": "capabilities_v1beta1_developeraccount",
+		"capabilities.3scale.net_applications.yaml":      "capabilities_v1beta1_application",
+		"capabilities.3scale.net_productimports.yaml":    "capabilities_v1beta1_productimport",
+		"capabilities.3scale.net_provideraccounts.yaml":  "capabilities_v1beta1_provideraccount",
+	}
+	// Some prefixes contain other prefixes, i.e. product and productimport
+	prefixes := make([]string, 0, len(crdCrMap))
+	for _, prefix := range crdCrMap {
+		prefixes = append(prefixes, prefix)
 	}
 	for crd, prefix := range crdCrMap {
-		validateCustomResources(t, schemaRoot, samplesRoot, crd, prefix)
+		schema := getSchema(t, fmt.Sprintf("%s/%s", schemaRoot, crd))
+		validateCustomResources(t, schema, samplesRoot, crd, prefix, prefixes...)
+	}
+
+	// Map of CRD:version:CR_sample_prefix for CRDs serving multiple versions
+	versionedCrdCrMap := map[string]map[string]string{
+		"capabilities.3scale.net_tenants.yaml": {
+			"v1alpha1": "capabilities_v1alpha1_tenant",
+			"v1beta1":  "capabilities_v1beta1_tenant",
+		},
+	}
+	for crd, versions := range versionedCrdCrMap {
+		for version, prefix := range versions {
+			schema := getVersionedSchema(t, fmt.Sprintf("%s/%s", schemaRoot, crd), version)
+			validateCustomResources(t, schema, samplesRoot, crd, prefix)
+		}
 	}
 }
 
-func validateCustomResources(t *testing.T, schemaRoot, samplesRoot, crd, prefix string) {
-	schema := getSchema(t, fmt.Sprintf("%s/%s", schemaRoot, crd))
+func validateCustomResources(t *testing.T, schema validation.Schema, samplesRoot, crd, prefix string, otherPrefixes ...string) {
 	assert.NotNil(t, schema)
 	walkFunc := func(path string, info os.FileInfo, err error) error {
-		if strings.HasPrefix(info.Name(), prefix) {
+		if longestSamplePrefix(info.Name(), prefix, otherPrefixes) {
 			t.Run(info.Name(), func(subT *testing.T) {
 				bytes, err := ioutil.ReadFile(path)
 				assert.NoError(subT, err, "Error reading CR yaml from %v", path)
@@ -66,42 +96,90 @@ func validateCustomResources(t *testing.T, schemaRoot, samplesRoot, crd, prefix
 	assert.NoError(t, err, "Error reading CR yaml files from ", samplesRoot)
 }
 
+// longestSamplePrefix returns true when the sample file name matches the prefix
+// and no other longer prefix
+func longestSamplePrefix(name, prefix string, otherPrefixes []string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+
+	for _, other := range otherPrefixes {
+		if len(other) > len(prefix) && strings.HasPrefix(name, other) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func TestCompleteCRD(t *testing.T) {
 	root := "../../bundle/manifests"
 	crdStructMap := map[string]interface{}{
-		"apps.3scale.net_apimanagers.yaml":        &apps.APIManager{},
-		"apps.3scale.net_apimanagerbackups.yaml":  &apps.APIManagerBackup{},
-		"apps.3scale.net_apimanagerrestores.yaml": &apps.APIManagerRestore{},
-		"capabilities.3scale.net_tenants.yaml":    &capabilitiesv1alpha1.Tenant{},
-		"capabilities.3scale.net_backends.yaml":   &capabilitiesv1beta1.Backend{},
-		"capabilities.3scale.net_products.yaml":   &capabilitiesv1beta1.Product{},
-		"capabilities.3scale.net_openapis.yaml":   &capabilitiesv1beta1.OpenAPI{},
+		"apps.3scale.net_apimanagers.yaml":               &apps.APIManager{},
+		"apps.3scale.net_apimanagerbackups.yaml":         &apps.APIManagerBackup{},
+		"apps.3scale.net_apimanagerbackupschedules.yaml": &apps.APIManagerBackupSchedule{},
+		"apps.3scale.net_apimanagerrestores.yaml":        &apps.APIManagerRestore{},
+		"capabilities.3scale.net_backends.yaml":          &capabilitiesv1beta1.Backend{},
+		"capabilities.3scale.net_products.yaml":          &capabilitiesv1beta1.Product{},
+		"capabilities.3scale.net_openapis.yaml":          &capabilitiesv1beta1.OpenAPI{},
+		"capabilities.3scale.net_developeraccounts.yaml": &capabilitiesv1beta1.DeveloperAccount{},
+		"capabilities.3scale.net_applications.yaml":      &capabilitiesv1beta1.Application{},
+		"capabilities.3scale.net_productimports.yaml":    &capabilitiesv1beta1.ProductImport{},
+		"capabilities.3scale.net_provideraccounts.yaml":  &capabilitiesv1beta1.ProviderAccount{},
 	}
 
 	pathOmissions := []string{
 		backupDestinationPVCResourceRequestsPath,
+		backupTemplatePVCResourceRequestsPath,
 		startTimePath,
 		completionTimePath,
 		lastTransitionTimePath,
+		productSmokeCheckLastCheckTimePath,
+		backupRetentionMaxAgePath,
+		lastScheduleTimePath,
+		lastSuccessfulTimePath,
 		systemSharedPVCResourceRequestsPath,
 		systemMySQLPVCResourceRequestsPath,
 		systemPostgreSQLPVCResourceRequestsPath,
 		productPoliciesConfigurationPath,
+		apicastProductionEnvDivisorPath,
+		apicastStagingEnvDivisorPath,
 	}
 
 	for crd, obj := range crdStructMap {
 		t.Run(crd, func(subT *testing.T) {
 			schema := getSchema(subT, fmt.Sprintf("%s/%s", root, crd))
-			missingEntries := schema.GetMissingEntries(obj)
-			for _, missing := range missingEntries {
-
-				if missingFieldPathInPathOmissions(missing.Path, pathOmissions) {
-					continue
-				}
-				assert.Fail(subT, "Discrepancy between CRD and Struct", "CRD: %s: Missing or incorrect schema validation at %s, expected type %s", crd, missing.Path, missing.Type)
-			}
+			validateCompleteSchema(subT, schema, crd, obj, pathOmissions)
 		})
 	}
+
+	// CRDs serving multiple versions
+	versionedCrdStructMap := map[string]map[string]interface{}{
+		"capabilities.3scale.net_tenants.yaml": {
+			"v1alpha1": &capabilitiesv1alpha1.Tenant{},
+			"v1beta1":  &capabilitiesv1beta1.Tenant{},
+		},
+	}
+
+	for crd, versions := range versionedCrdStructMap {
+		for version, obj := range versions {
+			t.Run(fmt.Sprintf("%s/%s", crd, version), func(subT *testing.T) {
+				schema := getVersionedSchema(subT, fmt.Sprintf("%s/%s", root, crd), version)
+				validateCompleteSchema(subT, schema, crd, obj, pathOmissions)
+			})
+		}
+	}
+}
+
+func validateCompleteSchema(t *testing.T, schema validation.Schema, crd string, obj interface{}, pathOmissions []string) {
+	missingEntries := schema.GetMissingEntries(obj)
+	for _, missing := range missingEntries {
+
+		if missingFieldPathInPathOmissions(missing.Path, pathOmissions) {
+			continue
+		}
+		assert.Fail(t, "Discrepancy between CRD and Struct", "CRD: %s: Missing or incorrect schema validation at %s, expected type %s", crd, missing.Path, missing.Type)
+	}
 }
 
 func getSchema(t *testing.T, crd string) validation.Schema {
@@ -112,6 +190,14 @@ func getSchema(t *testing.T, crd string) validation.Schema {
 	return schema
 }
 
+func getVersionedSchema(t *testing.T, crd, version string) validation.Schema {
+	bytes, err := ioutil.ReadFile(crd)
+	assert.NoError(t, err, "Error reading CRD yaml from %v", crd)
+	schema, err := validation.NewVersioned(bytes, version)
+	assert.NoError(t, err)
+	return schema
+}
+
 func missingFieldPathInPathOmissions(path string, omissions []string) bool {
 	for _, omit := range omissions {
 		if strings.HasPrefix(path, omit) {
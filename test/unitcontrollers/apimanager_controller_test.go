@@ -13,6 +13,7 @@ import (
 	appsv1 "github.com/openshift/api/apps/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -120,3 +121,135 @@ func TestAPIManagerControllerCreate(t *testing.T) {
 		t.Errorf("APIManager's backend listener replicas size (%d) is not the expected size (%d)", backendListenerExistingReplicas, 1)
 	}
 }
+
+func TestAPIManagerControllerDeployments(t *testing.T) {
+	var (
+		name           = "example-apimanager"
+		namespace      = "operator-unittest"
+		wildcardDomain = "test.3scale.net"
+		deploymentKind = appsv1alpha1.DeploymentKindDeployment
+	)
+
+	ctx := context.TODO()
+
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				WildcardDomain: wildcardDomain,
+				DeploymentKind: &deploymentKind,
+			},
+		},
+	}
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager}
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := imagev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := monitoringv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := grafanav1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(cl, s, clientAPIReader, ctx, ctrl.Log.WithName("controllers").WithName("APIManager"),
+		clientset.Discovery(), recorder)
+	r := &appscontrollers.APIManagerReconciler{
+		BaseReconciler: baseReconciler,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	endLoop := false
+	for i := 0; i < 100 && !endLoop; i++ {
+		res, err := r.Reconcile(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		endLoop = !res.Requeue
+	}
+
+	if !endLoop {
+		t.Fatal("reconcile did not finish end of reconciliation as expected. APIManager should have been reconciled at this point")
+	}
+
+	dcList := &appsv1.DeploymentConfigList{}
+	if err := cl.List(ctx, dcList); err != nil {
+		t.Fatal(err)
+	}
+	if len(dcList.Items) != 0 {
+		t.Errorf("unexpected DeploymentConfigs: %d", len(dcList.Items))
+	}
+
+	imageStreamList := &imagev1.ImageStreamList{}
+	if err := cl.List(ctx, imageStreamList); err != nil {
+		t.Fatal(err)
+	}
+	if len(imageStreamList.Items) != 0 {
+		t.Errorf("unexpected ImageStreams: %d", len(imageStreamList.Items))
+	}
+
+	deploymentList := &k8sappsv1.DeploymentList{}
+	if err := cl.List(ctx, deploymentList); err != nil {
+		t.Fatal(err)
+	}
+	if len(deploymentList.Items) == 0 {
+		t.Fatal("expected Deployments to be created")
+	}
+
+	finalAPIManager := &appsv1alpha1.APIManager{}
+	if err := r.Client().Get(ctx, req.NamespacedName, finalAPIManager); err != nil {
+		t.Fatalf("get APIManager: (%v)", err)
+	}
+	statusDeployments := len(finalAPIManager.Status.Deployments.Ready) +
+		len(finalAPIManager.Status.Deployments.Starting) +
+		len(finalAPIManager.Status.Deployments.Stopped)
+	if statusDeployments != len(deploymentList.Items) {
+		t.Errorf("expected %d deployments in status, got %d", len(deploymentList.Items), statusDeployments)
+	}
+
+	// Deployments are not rolled out by the fake client
+	if len(finalAPIManager.Status.Components) != len(deploymentList.Items) {
+		t.Errorf("expected %d components in status, got %d", len(deploymentList.Items), len(finalAPIManager.Status.Components))
+	}
+	conditions := finalAPIManager.Status.Conditions
+	if !conditions.IsTrueFor(appsv1alpha1.APIManagerProgressingConditionType) {
+		t.Errorf("expected Progressing condition to be true: %v", conditions)
+	}
+	if !conditions.IsFalseFor(appsv1alpha1.APIManagerAvailableConditionType) {
+		t.Errorf("expected Available condition to be false: %v", conditions)
+	}
+	if !conditions.IsFalseFor(appsv1alpha1.APIManagerDegradedConditionType) {
+		t.Errorf("expected Degraded condition to be false: %v", conditions)
+	}
+	if !conditions.IsFalseFor(appsv1alpha1.APIManagerUpgradeInProgressConditionType) {
+		t.Errorf("expected UpgradeInProgress condition to be false: %v", conditions)
+	}
+}
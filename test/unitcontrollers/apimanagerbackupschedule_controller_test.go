@@ -0,0 +1,324 @@
+package unitcontrollers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	appscontrollers "github.com/3scale/3scale-operator/controllers/apps"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestAPIManagerBackupScheduleController(t *testing.T) {
+	var (
+		name      = "example-schedule"
+		namespace = "operator-unittest"
+		keepLast  = int32(1)
+		trueValue = true
+	)
+
+	ctx := context.TODO()
+
+	schedule := &appsv1alpha1.APIManagerBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			UID:               "schedule-uid",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-72 * time.Hour)),
+		},
+		Spec: appsv1alpha1.APIManagerBackupScheduleSpec{
+			Schedule:  "@daily",
+			Retention: &appsv1alpha1.APIManagerBackupRetention{KeepLast: &keepLast},
+			BackupTemplate: appsv1alpha1.APIManagerBackupSpec{
+				BackupDestination: appsv1alpha1.APIManagerBackupDestination{
+					PersistentVolumeClaim: &appsv1alpha1.PersistentVolumeClaimBackupDestination{},
+				},
+			},
+		},
+	}
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{schedule}
+
+	// Two completed backups, the oldest one must be pruned with its PVC
+	for idx := 1; idx <= 2; idx++ {
+		backupName := fmt.Sprintf("%s-%d", name, idx)
+		pvcName := fmt.Sprintf("apimanager-backup-%s", backupName)
+		completionTime := metav1.NewTime(time.Now().Add(time.Duration(idx-3) * 24 * time.Hour))
+		objs = append(objs,
+			&appsv1alpha1.APIManagerBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              backupName,
+					Namespace:         namespace,
+					CreationTimestamp: completionTime,
+					Labels:            map[string]string{appsv1alpha1.APIManagerBackupScheduleLabelKey: name},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: appsv1alpha1.GroupVersion.String(),
+						Kind:       "APIManagerBackupSchedule",
+						Name:       name,
+						UID:        schedule.UID,
+						Controller: &trueValue,
+					}},
+				},
+				Status: appsv1alpha1.APIManagerBackupStatus{
+					Completed:                       &trueValue,
+					CompletionTime:                  &completionTime,
+					BackupPersistentVolumeClaimName: &pvcName,
+				},
+			},
+			&v1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: namespace},
+			},
+		)
+	}
+
+	// Register operator types with the runtime scheme.
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, schedule, &appsv1alpha1.APIManagerBackup{}, &appsv1alpha1.APIManagerBackupList{})
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(cl, s, clientAPIReader, ctx, ctrl.Log.WithName("controllers").WithName("APIManagerBackupSchedule"),
+		clientset.Discovery(), recorder)
+	r := &appscontrollers.APIManagerBackupScheduleReconciler{
+		BaseReconciler: baseReconciler,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	res, err := r.Reconcile(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RequeueAfter <= 0 || res.RequeueAfter > 24*time.Hour {
+		t.Errorf("expected requeue at next schedule time, got %s", res.RequeueAfter)
+	}
+
+	// Pruned
+	prunedPVC := &v1.PersistentVolumeClaim{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "apimanager-backup-example-schedule-1", Namespace: namespace}, prunedPVC)
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected backup PVC to be pruned, got: %v", err)
+	}
+	prunedBackup := &appsv1alpha1.APIManagerBackup{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "example-schedule-1", Namespace: namespace}, prunedBackup)
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected backup to be pruned, got: %v", err)
+	}
+
+	// Scheduled backup created
+	backupList := &appsv1alpha1.APIManagerBackupList{}
+	err = cl.List(ctx, backupList, client.InNamespace(namespace))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backupList.Items) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backupList.Items))
+	}
+
+	finalSchedule := &appsv1alpha1.APIManagerBackupSchedule{}
+	err = cl.Get(ctx, req.NamespacedName, finalSchedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if finalSchedule.Status.LastScheduleTime == nil {
+		t.Fatal("expected last schedule time to be set")
+	}
+	expectedBackupName := fmt.Sprintf("%s-%s", name, finalSchedule.Status.LastScheduleTime.UTC().Format("200601021504"))
+	if finalSchedule.Status.ActiveBackup == nil || *finalSchedule.Status.ActiveBackup != expectedBackupName {
+		t.Errorf("expected active backup %s, got %v", expectedBackupName, finalSchedule.Status.ActiveBackup)
+	}
+	if finalSchedule.Status.LastSuccessfulBackup == nil || *finalSchedule.Status.LastSuccessfulBackup != "example-schedule-2" {
+		t.Errorf("expected last successful backup example-schedule-2, got %v", finalSchedule.Status.LastSuccessfulBackup)
+	}
+
+	scheduledBackup := &appsv1alpha1.APIManagerBackup{}
+	err = cl.Get(ctx, types.NamespacedName{Name: expectedBackupName, Namespace: namespace}, scheduledBackup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metav1.IsControlledBy(scheduledBackup, finalSchedule) {
+		t.Error("expected scheduled backup to be controlled by the schedule")
+	}
+	if scheduledBackup.Spec.BackupDestination.PersistentVolumeClaim == nil {
+		t.Error("expected scheduled backup spec from the backup template")
+	}
+
+	// No new backup until the next schedule time
+	if _, err = r.Reconcile(req); err != nil {
+		t.Fatal(err)
+	}
+	err = cl.List(ctx, backupList, client.InNamespace(namespace))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backupList.Items) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backupList.Items))
+	}
+}
+
+func TestAPIManagerBackupScheduleControllerS3Retention(t *testing.T) {
+	var (
+		name      = "example-schedule"
+		namespace = "operator-unittest"
+		keepLast  = int32(1)
+		trueValue = true
+	)
+
+	ctx := context.TODO()
+
+	s3 := &appsv1alpha1.S3ObjectStorage{
+		Bucket:               "mybucket",
+		CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+	}
+	schedule := &appsv1alpha1.APIManagerBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			UID:               "schedule-uid",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-72 * time.Hour)),
+		},
+		Spec: appsv1alpha1.APIManagerBackupScheduleSpec{
+			Schedule:  "@daily",
+			Suspend:   &trueValue,
+			Retention: &appsv1alpha1.APIManagerBackupRetention{KeepLast: &keepLast},
+			BackupTemplate: appsv1alpha1.APIManagerBackupSpec{
+				BackupDestination: appsv1alpha1.APIManagerBackupDestination{S3: s3},
+			},
+		},
+	}
+
+	objs := []runtime.Object{schedule}
+
+	// Two completed backups, the data of the oldest one must be pruned before the backup
+	for idx := 1; idx <= 2; idx++ {
+		backupName := fmt.Sprintf("%s-%d", name, idx)
+		location := fmt.Sprintf("s3://mybucket/%s", backupName)
+		completionTime := metav1.NewTime(time.Now().Add(time.Duration(idx-3) * 24 * time.Hour))
+		objs = append(objs,
+			&appsv1alpha1.APIManagerBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              backupName,
+					Namespace:         namespace,
+					UID:               types.UID(fmt.Sprintf("fc9b0ae4-5e3d-4c47-a5b2-7c2a3d4bca5%d", idx)),
+					CreationTimestamp: completionTime,
+					Labels:            map[string]string{appsv1alpha1.APIManagerBackupScheduleLabelKey: name},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: appsv1alpha1.GroupVersion.String(),
+						Kind:       "APIManagerBackupSchedule",
+						Name:       name,
+						UID:        schedule.UID,
+						Controller: &trueValue,
+					}},
+				},
+				Spec: appsv1alpha1.APIManagerBackupSpec{
+					BackupDestination: appsv1alpha1.APIManagerBackupDestination{S3: s3},
+				},
+				Status: appsv1alpha1.APIManagerBackupStatus{
+					Completed:        &trueValue,
+					CompletionTime:   &completionTime,
+					BackupS3Location: &location,
+				},
+			},
+		)
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, schedule, &appsv1alpha1.APIManagerBackup{}, &appsv1alpha1.APIManagerBackupList{})
+
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(cl, s, clientAPIReader, ctx, ctrl.Log.WithName("controllers").WithName("APIManagerBackupSchedule"),
+		clientset.Discovery(), recorder)
+	r := &appscontrollers.APIManagerBackupScheduleReconciler{
+		BaseReconciler: baseReconciler,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatal(err)
+	}
+
+	// Prune job created, backup kept until the job succeeds
+	jobList := &batchv1.JobList{}
+	if err := cl.List(ctx, jobList, client.InNamespace(namespace)); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobList.Items) != 1 {
+		t.Fatalf("expected 1 prune job, got %d", len(jobList.Items))
+	}
+	job := &jobList.Items[0]
+	if url := jobEnvValue(job, "S3_URL"); url != "s3://mybucket/example-schedule-1" {
+		t.Errorf("unexpected prune location: %s", url)
+	}
+	prunedBackup := &appsv1alpha1.APIManagerBackup{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "example-schedule-1", Namespace: namespace}, prunedBackup); err != nil {
+		t.Fatalf("expected backup to be kept while its data is pruned, got: %v", err)
+	}
+
+	job.Status.Succeeded = 1
+	if err := cl.Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatal(err)
+	}
+
+	err := cl.Get(ctx, types.NamespacedName{Name: "example-schedule-1", Namespace: namespace}, prunedBackup)
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected backup to be pruned, got: %v", err)
+	}
+	err = cl.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: namespace}, &batchv1.Job{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected prune job to be deleted, got: %v", err)
+	}
+	keptBackup := &appsv1alpha1.APIManagerBackup{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: "example-schedule-2", Namespace: namespace}, keptBackup); err != nil {
+		t.Errorf("expected last backup to be kept, got: %v", err)
+	}
+}
+
+func jobEnvValue(job *batchv1.Job, name string) string {
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
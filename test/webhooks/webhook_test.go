@@ -0,0 +1,165 @@
+package webhooks
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+)
+
+const testNamespace = "default"
+
+var _ = Describe("APIManager webhooks", func() {
+	It("applies the defaults", func() {
+		apimanager := &appsv1alpha1.APIManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaulted", Namespace: testNamespace},
+			Spec: appsv1alpha1.APIManagerSpec{
+				APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{WildcardDomain: "example.com"},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), apimanager)).To(Succeed())
+
+		created := &appsv1alpha1.APIManager{}
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: "defaulted", Namespace: testNamespace}, created)).To(Succeed())
+		Expect(created.Spec.AppLabel).NotTo(BeNil())
+		Expect(created.Spec.Apicast.ProductionSpec.Replicas).NotTo(BeNil())
+		Expect(created.Annotations).To(HaveKey(appsv1alpha1.OperatorVersionAnnotation))
+	})
+
+	It("rejects conflicting system databases", func() {
+		apimanager := &appsv1alpha1.APIManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "conflicting-databases", Namespace: testNamespace},
+			Spec: appsv1alpha1.APIManagerSpec{
+				APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{WildcardDomain: "example.com"},
+				System: &appsv1alpha1.SystemSpec{
+					DatabaseSpec: &appsv1alpha1.SystemDatabaseSpec{
+						MySQL:      &appsv1alpha1.SystemMySQLSpec{},
+						PostgreSQL: &appsv1alpha1.SystemPostgreSQLSpec{},
+					},
+				},
+			},
+		}
+		err := k8sClient.Create(context.TODO(), apimanager)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Only one System Database"))
+	})
+
+	It("rejects changing the system database type", func() {
+		apimanager := &appsv1alpha1.APIManager{
+			ObjectMeta: metav1.ObjectMeta{Name: "database-change", Namespace: testNamespace},
+			Spec: appsv1alpha1.APIManagerSpec{
+				APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{WildcardDomain: "example.com"},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), apimanager)).To(Succeed())
+
+		apimanager.Spec.System.DatabaseSpec = &appsv1alpha1.SystemDatabaseSpec{
+			PostgreSQL: &appsv1alpha1.SystemPostgreSQLSpec{},
+		}
+		err := k8sClient.Update(context.TODO(), apimanager)
+		Expect(apierrors.IsForbidden(err) || apierrors.IsInvalid(err)).To(BeTrue(), "unexpected error: %v", err)
+		Expect(err.Error()).To(ContainSubstring("spec.system.database"))
+	})
+})
+
+var _ = Describe("Product webhooks", func() {
+	It("applies the defaults", func() {
+		product := &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaulted", Namespace: testNamespace},
+			Spec:       capabilitiesv1beta1.ProductSpec{Name: "My Product"},
+		}
+		Expect(k8sClient.Create(context.TODO(), product)).To(Succeed())
+
+		created := &capabilitiesv1beta1.Product{}
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: "defaulted", Namespace: testNamespace}, created)).To(Succeed())
+		Expect(created.Spec.SystemName).To(Equal("myproduct"))
+		Expect(created.Spec.Metrics).To(HaveKey("hits"))
+	})
+
+	It("rejects an empty deployment", func() {
+		product := &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty-deployment", Namespace: testNamespace},
+			Spec: capabilitiesv1beta1.ProductSpec{
+				Name:       "Empty deployment",
+				Deployment: &capabilitiesv1beta1.ProductDeploymentSpec{},
+			},
+		}
+		err := k8sClient.Create(context.TODO(), product)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exactly one of apicastHosted or apicastSelfManaged"))
+	})
+
+	It("rejects changing the system name", func() {
+		product := &capabilitiesv1beta1.Product{
+			ObjectMeta: metav1.ObjectMeta{Name: "systemname-change", Namespace: testNamespace},
+			Spec:       capabilitiesv1beta1.ProductSpec{Name: "System name change"},
+		}
+		Expect(k8sClient.Create(context.TODO(), product)).To(Succeed())
+
+		product.Spec.SystemName = "othersystemname"
+		err := k8sClient.Update(context.TODO(), product)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field is immutable"))
+	})
+})
+
+var _ = Describe("Backend webhooks", func() {
+	It("rejects duplicated metric and method system names", func() {
+		backend := &capabilitiesv1beta1.Backend{
+			ObjectMeta: metav1.ObjectMeta{Name: "duplicated-metrics", Namespace: testNamespace},
+			Spec: capabilitiesv1beta1.BackendSpec{
+				Name:           "Duplicated metrics",
+				PrivateBaseURL: "https://example.com",
+				Methods: map[string]capabilitiesv1beta1.MethodSpec{
+					"hits": {Name: "Hits method"},
+				},
+			},
+		}
+		err := k8sClient.Create(context.TODO(), backend)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("method system_name not unique"))
+	})
+})
+
+var _ = Describe("OpenAPI webhooks", func() {
+	It("rejects both provider account references", func() {
+		openapiURL := "https://example.com/openapi.json"
+		openapi := &capabilitiesv1beta1.OpenAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "provider-account-refs", Namespace: testNamespace},
+			Spec: capabilitiesv1beta1.OpenAPISpec{
+				OpenAPIRef:           capabilitiesv1beta1.OpenAPIRefSpec{URL: &openapiURL},
+				ProviderAccountRef:   &corev1.LocalObjectReference{Name: "mysecret"},
+				ProviderAccountCRRef: &capabilitiesv1beta1.ProviderAccountCRReference{Name: "myprovideraccount"},
+			},
+		}
+		err := k8sClient.Create(context.TODO(), openapi)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("mutually exclusive"))
+	})
+})
+
+var _ = Describe("Tenant webhooks", func() {
+	It("rejects an invalid master URL", func() {
+		tenant := &capabilitiesv1beta1.Tenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-master-url", Namespace: testNamespace},
+			Spec: capabilitiesv1beta1.TenantSpec{
+				Username:               "admin",
+				Email:                  "admin@example.com",
+				OrganizationName:       "org",
+				SystemMasterUrl:        "master.example.com",
+				PasswordCredentialsRef: corev1.SecretReference{Name: "password"},
+				MasterCredentialsRef:   corev1.SecretReference{Name: "master"},
+			},
+		}
+		err := k8sClient.Create(context.TODO(), tenant)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.systemMasterUrl"))
+	})
+})